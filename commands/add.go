@@ -96,16 +96,61 @@ func init_add_project_command(parentCmd *cobra.Command, app *types.AppContext) {
 }
 
 func Init_Add_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var noInstall bool
+	var tools []string
+
 	var addCmd = &cobra.Command{
 		Use:     "add [resource]",
 		Aliases: []string{"ad"},
 		Short:   "Add command",
-		Long:    `Adds a resource.`,
+		Long:    `Adds a resource or development tools with --tool.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			cmd.Help()
+			if len(tools) == 0 {
+				cmd.Help()
+				return
+			}
+
+			// tools by module path without version
+			toolIndexes := map[string]int{}
+			allTools := append([]string{}, app.GpmFile.Tools...)
+			for i, t := range allTools {
+				modulePath, _, _ := strings.Cut(strings.TrimSpace(t), "@")
+				toolIndexes[modulePath] = i
+			}
+
+			newTools := []string{}
+			for _, t := range tools {
+				tool := strings.TrimSpace(t)
+				if tool == "" {
+					continue
+				}
+
+				modulePath, _, _ := strings.Cut(tool, "@")
+				i, ok := toolIndexes[modulePath]
+				if ok {
+					app.Debug(fmt.Sprintf("Replacing tool '%v' with '%v' ...", allTools[i], tool))
+					allTools[i] = tool
+				} else {
+					app.Debug(fmt.Sprintf("Adding tool '%v' ...", tool))
+					toolIndexes[modulePath] = len(allTools)
+					allTools = append(allTools, tool)
+				}
+
+				newTools = append(newTools, tool)
+			}
+
+			err := app.SetGpmFileValue("tools", allTools)
+			utils.CheckForError(err)
+
+			if !noInstall {
+				install_gpm_tools(app, newTools)
+			}
 		},
 	}
 
+	addCmd.Flags().BoolVarP(&noInstall, "no-install", "", false, "only add tools to gpm.yaml without installing them")
+	addCmd.Flags().StringArrayVarP(&tools, "tool", "t", []string{}, "module path of a development tool, like 'golang.org/x/tools/cmd/stringer@latest'")
+
 	init_add_alias_command(addCmd, app)
 	init_add_project_command(addCmd, app)
 
@@ -0,0 +1,564 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+// auditSeverityLevels stores the sort values of
+// `GetSeverityDisplayValues()` by name
+var auditSeverityLevels = map[string]int{
+	"low":      0,
+	"moderate": 1,
+	"high":     2,
+	"critical": 3,
+}
+
+// auditSarifLevels stores the SARIF levels by sort values
+// of `GetSeverityDisplayValues()`
+var auditSarifLevels = map[int]string{
+	0: "note",
+	1: "warning",
+	2: "error",
+	3: "error",
+}
+
+// auditSecuritySeverities stores the `security-severity` scores,
+// as used by GitHub code scanning, by sort values of `GetSeverityDisplayValues()`
+var auditSecuritySeverities = map[int]string{
+	0: "2.0",
+	1: "5.0",
+	2: "7.0",
+	3: "9.0",
+}
+
+type auditFinding struct {
+	modulePath    string
+	moduleVersion string
+	reachable     bool // vulnerable code is called, reported by `govulncheck`
+	vulnerability types.OsvDevResponseVulnerabilityItem
+}
+
+// create_audit_sarif_log() - creates a SARIF document from findings
+// of `get_audit_findings()`
+func create_audit_sarif_log(app *types.AppContext, findings []auditFinding, toolVersion string) types.SarifLog {
+	goModLines := []string{}
+	goModData, err := os.ReadFile(path.Join(app.Cwd, "go.mod"))
+	if err == nil {
+		goModLines = strings.Split(string(goModData), "\n")
+	}
+
+	// find line of a module in go.mod file
+	getRegion := func(f auditFinding) *types.SarifRegion {
+		for i, l := range goModLines {
+			fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(l), "require "))
+			if len(fields) >= 2 && fields[0] == f.modulePath && fields[1] == f.moduleVersion {
+				return &types.SarifRegion{
+					StartLine: i + 1,
+				}
+			}
+		}
+
+		return nil
+	}
+
+	rules := []types.SarifRule{}
+	results := []types.SarifResult{}
+	knownRuleIds := map[string]bool{}
+
+	for _, f := range findings {
+		v := f.vulnerability
+		_, level := v.GetSeverityDisplayValues()
+
+		sarifLevel, ok := auditSarifLevels[level]
+		if !ok {
+			sarifLevel = "warning"
+		}
+
+		if !knownRuleIds[v.Id] {
+			knownRuleIds[v.Id] = true
+
+			summary := strings.TrimSpace(v.Summary)
+			if summary == "" {
+				summary = v.Id
+			}
+
+			rule := types.SarifRule{
+				DefaultConfiguration: &types.SarifReportingConfiguration{
+					Level: sarifLevel,
+				},
+				HelpUri: fmt.Sprintf("https://osv.dev/vulnerability/%s", v.Id),
+				Id:      v.Id,
+				Name:    v.Id,
+				Properties: map[string]interface{}{
+					"tags": []string{"security", "vulnerability"},
+				},
+				ShortDescription: &types.SarifMessage{
+					Text: summary,
+				},
+			}
+			if strings.TrimSpace(v.Details) != "" {
+				rule.FullDescription = &types.SarifMessage{
+					Text: strings.TrimSpace(v.Details),
+				}
+			}
+
+			securitySeverity, ok := auditSecuritySeverities[level]
+			if ok {
+				rule.Properties["security-severity"] = securitySeverity
+			}
+
+			rules = append(rules, rule)
+		}
+
+		var properties map[string]interface{}
+		if f.reachable {
+			properties = map[string]interface{}{
+				"reachable": true,
+			}
+		}
+
+		results = append(results, types.SarifResult{
+			Level: sarifLevel,
+			Locations: []types.SarifLocation{
+				{
+					PhysicalLocation: types.SarifPhysicalLocation{
+						ArtifactLocation: types.SarifArtifactLocation{
+							Uri: "go.mod",
+						},
+						Region: getRegion(f),
+					},
+				},
+			},
+			Message: types.SarifMessage{
+				Text: fmt.Sprintf("%s@%s is affected by %s: %s", f.modulePath, f.moduleVersion, v.Id, strings.TrimSpace(v.Summary)),
+			},
+			Properties: properties,
+			RuleId:     v.Id,
+		})
+	}
+
+	return types.SarifLog{
+		Schema: types.SarifSchemaUrl,
+		Runs: []types.SarifRun{
+			{
+				Results: results,
+				Tool: types.SarifTool{
+					Driver: types.SarifToolDriver{
+						InformationUri: "https://gpm.kloubert.dev",
+						Name:           "gpm",
+						Rules:          rules,
+						Version:        toolVersion,
+					},
+				},
+			},
+		},
+		Version: types.SarifVersion,
+	}
+}
+
+// format_reachable_vulnerability() - returns the description of a reachable
+// issue of a `govulncheck` result, which also contains the vulnerable module
+// and if the issue is also reported by osv.dev, which means one of its IDs
+// is part of `knownIds`
+func format_reachable_vulnerability(result *types.GovulncheckResult, id string, knownIds map[string]bool) string {
+	osv := result.Osvs[id]
+
+	// is issue also reported by osv.dev?
+	isKnown := knownIds[id]
+	for _, a := range osv.Aliases {
+		isKnown = isKnown || knownIds[a]
+	}
+
+	source := "govulncheck"
+	if isKnown {
+		source = "govulncheck + osv.dev"
+	}
+
+	modulePath := ""
+	for _, f := range result.Findings[id] {
+		if f.IsReachable() {
+			frame := f.Trace[0] // the vulnerable symbol
+			modulePath = fmt.Sprintf(" in '%s@%s'", frame.Module, frame.Version)
+			if strings.TrimSpace(f.FixedVersion) != "" {
+				modulePath += fmt.Sprintf(" (fixed in %s)", f.FixedVersion)
+			}
+
+			break
+		}
+	}
+
+	return fmt.Sprintf("%s%s: %s [%s]", id, modulePath, osv.Summary, source)
+}
+
+// get_audit_findings() - queries osv.dev for all dependencies
+// of the current project and returns the findings, which are not ignored
+func get_audit_findings(app *types.AppContext, ignoredIds []string) []auditFinding {
+	red := color.New(color.FgRed).SprintFunc()
+
+	p := exec.Command("go", "mod", "edit", "-json")
+	p.Dir = app.Cwd
+	p.Stderr = nil
+	p.Stdin = nil
+	p.Stdout = nil
+	output, err := p.Output()
+	utils.CheckForError(err)
+
+	var goMod GoModFile
+	err = json.Unmarshal(output, &goMod)
+	utils.CheckForError(err)
+
+	findings := []auditFinding{}
+	for i, item := range goMod.Require {
+		modulePath := strings.TrimSpace(item.Path)
+		moduleVersion := strings.TrimSpace(item.Version)
+
+		s := app.NewSpinner()
+		if !app.Quiet {
+			s.Writer = app.ErrorOut
+		}
+		s.Prefix = "["
+		s.Suffix = fmt.Sprintf("] Checking '%s' (%v/%v) ...", modulePath, i+1, len(goMod.Require))
+		s.Start()
+
+		osvResponse, err := query_osv_dev(modulePath, moduleVersion)
+
+		s.Stop()
+
+		if err != nil {
+			fmt.Fprintf(app.ErrorOut, "[%s] Could not check '%s': %s%s", red("!"), modulePath, err.Error(), fmt.Sprintln())
+			continue
+		}
+		if osvResponse.Vulnerabilities == nil {
+			continue
+		}
+
+		for _, v := range *osvResponse.Vulnerabilities {
+			ids := []string{v.Id}
+			if v.Aliases != nil {
+				ids = append(ids, *v.Aliases...)
+			}
+
+			if is_audit_id_ignored(ids, ignoredIds) {
+				app.Debug(fmt.Sprintf("Ignoring '%s' in '%s'", v.Id, modulePath))
+				continue
+			}
+
+			findings = append(findings, auditFinding{
+				modulePath:    modulePath,
+				moduleVersion: moduleVersion,
+				vulnerability: v,
+			})
+		}
+	}
+
+	// highest severity first
+	sort.SliceStable(findings, func(x int, y int) bool {
+		_, compX := findings[x].vulnerability.GetSeverityDisplayValues()
+		_, compY := findings[y].vulnerability.GetSeverityDisplayValues()
+
+		return compX > compY
+	})
+
+	return findings
+}
+
+// is_audit_id_ignored() - checks if one of the IDs and aliases
+// of an issue is part of `ignoredIds`
+// is_audit_finding_failing() - checks if a finding should let the command fail,
+// where findings without known severity fail if `failOnUnknown` is set
+func is_audit_finding_failing(f auditFinding, failOnLevel int, failOnUnknown bool) bool {
+	if failOnLevel < 0 {
+		return false
+	}
+
+	_, level := f.vulnerability.GetSeverityDisplayValues()
+	if level < 0 {
+		return failOnUnknown
+	}
+
+	return level >= failOnLevel
+}
+
+func is_audit_id_ignored(ids []string, ignoredIds []string) bool {
+	for _, id := range ids {
+		for _, ignoredId := range ignoredIds {
+			if strings.EqualFold(strings.TrimSpace(id), strings.TrimSpace(ignoredId)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// query_osv_dev() - requests known vulnerabilities of a Go module from osv.dev
+func query_osv_dev(modulePath string, moduleVersion string) (*types.OsvDevResponse, error) {
+	url := "https://api.osv.dev/v1/query"
+	body := map[string]interface{}{
+		"version": moduleVersion,
+		"package": map[string]interface{}{
+			"name":      modulePath,
+			"ecosystem": "Go",
+		},
+	}
+
+	jsonData, err := json.Marshal(&body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected response from '%s': %v", url, resp.Status)
+	}
+
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var osvResponse types.OsvDevResponse
+	err = json.Unmarshal(responseData, &osvResponse)
+
+	return &osvResponse, err
+}
+
+// run_govulncheck() - runs `govulncheck -json` for all packages
+// of the current project and returns the parsed result
+func run_govulncheck(app *types.AppContext) (*types.GovulncheckResult, error) {
+	govulncheckPath, err := exec.LookPath("govulncheck")
+	if err != nil {
+		return nil, fmt.Errorf("'govulncheck' not found, install it with 'go install golang.org/x/vuln/cmd/govulncheck@latest'")
+	}
+
+	p := exec.Command(govulncheckPath, "-json", "./...")
+	p.Dir = app.Cwd
+	p.Stderr = nil
+	p.Stdin = nil
+	p.Stdout = nil
+	output, err := p.Output()
+	if err != nil && len(output) == 0 {
+		// exit code is not 0, if issues have been found
+		return nil, fmt.Errorf("'govulncheck' failed: %w", err)
+	}
+
+	result, err := types.ParseGovulncheckResult(bytes.NewReader(output))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON from 'govulncheck': %w", err)
+	}
+
+	return result, nil
+}
+
+func Init_Audit_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var failOn string
+	var failOnUnknown bool
+	var format string
+	var ignore []string
+	var output string
+	var useGovulncheck bool
+
+	var auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Audits dependencies",
+		Long:  `Checks all dependencies of the current project for known security issues.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			green := color.New(color.FgGreen).SprintFunc()
+			red := color.New(color.FgRed).SprintFunc()
+
+			failOnLevel := -1
+			failOn = strings.TrimSpace(strings.ToLower(failOn))
+			if failOn != "" {
+				level, ok := auditSeverityLevels[failOn]
+				if !ok {
+					utils.CloseWithError(fmt.Errorf("invalid value '%v' for fail-on", failOn))
+				}
+
+				failOnLevel = level
+			}
+
+			ignoredIds := append([]string{}, app.SettingsFile.Audit.Ignore...)
+			ignoredIds = append(ignoredIds, ignore...)
+
+			format = strings.TrimSpace(strings.ToLower(format))
+			if format != "" && format != "text" && format != "sarif" {
+				utils.CloseWithError(fmt.Errorf("invalid value '%v' for format", format))
+			}
+
+			findings := get_audit_findings(app, ignoredIds)
+
+			var govulncheckResult *types.GovulncheckResult
+			reachableIds := []string{}
+			knownIds := map[string]bool{}
+			if useGovulncheck {
+				s := app.NewSpinner()
+				if !app.Quiet {
+					s.Writer = app.ErrorOut
+				}
+				s.Prefix = "["
+				s.Suffix = "] Running 'govulncheck' ..."
+				s.Start()
+
+				result, err := run_govulncheck(app)
+
+				s.Stop()
+				utils.CheckForError(err)
+
+				// IDs and aliases of reachable issues
+				reachableAliases := map[string]bool{}
+				for _, id := range result.GetReachableIds() {
+					ids := append([]string{id}, result.Osvs[id].Aliases...)
+					if is_audit_id_ignored(ids, ignoredIds) {
+						continue
+					}
+
+					reachableIds = append(reachableIds, id)
+					for _, a := range ids {
+						reachableAliases[a] = true
+					}
+				}
+				sort.Strings(reachableIds)
+
+				for i := range findings {
+					v := findings[i].vulnerability
+
+					ids := []string{v.Id}
+					if v.Aliases != nil {
+						ids = append(ids, *v.Aliases...)
+					}
+
+					for _, id := range ids {
+						knownIds[id] = true
+						findings[i].reachable = findings[i].reachable || reachableAliases[id]
+					}
+				}
+
+				govulncheckResult = result
+			}
+
+			var out io.Writer = app.Out
+			output = strings.TrimSpace(output)
+			if output != "" {
+				outputFile, err := os.Create(app.GetFullPathOrDefault(output, ""))
+				utils.CheckForError(err)
+				defer outputFile.Close()
+
+				out = outputFile
+			}
+			if !utils.IsTerminal(out) {
+				// no ANSI codes in files or pipes
+				green = fmt.Sprint
+				red = fmt.Sprint
+			}
+
+			findingsToFailOn := 0
+			for _, f := range findings {
+				severity, _ := f.vulnerability.GetSeverityDisplayValues()
+
+				if format != "sarif" {
+					reachableMarker := ""
+					if f.reachable {
+						reachableMarker = "[REACHABLE] "
+					}
+
+					fmt.Fprintf(
+						out,
+						"[%s] %s%s %s in '%s@%s': %s%s",
+						red("!"), reachableMarker, severity, f.vulnerability.Id, f.modulePath, f.moduleVersion,
+						strings.TrimSpace(f.vulnerability.Summary), fmt.Sprintln(),
+					)
+				}
+
+				if is_audit_finding_failing(f, failOnLevel, failOnUnknown) {
+					findingsToFailOn++
+				}
+			}
+
+			if format == "sarif" {
+				sarifLog := create_audit_sarif_log(app, findings, cmd.Root().Version)
+
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+
+				err := encoder.Encode(&sarifLog)
+				utils.CheckForError(err)
+			} else {
+				if len(findings) == 0 {
+					fmt.Fprintf(out, "[%s] No known security issues found%s", green("✓"), fmt.Sprintln())
+				}
+
+				if govulncheckResult != nil {
+					if len(reachableIds) == 0 {
+						fmt.Fprintf(out, "[%s] No reachable security issues found%s", green("✓"), fmt.Sprintln())
+					}
+
+					for _, id := range reachableIds {
+						fmt.Fprintf(out, "[%s] [REACHABLE] %s%s", red("!"), format_reachable_vulnerability(govulncheckResult, id, knownIds), fmt.Sprintln())
+					}
+				}
+			}
+
+			if findingsToFailOn > 0 {
+				unknownSuffix := ""
+				if failOnUnknown {
+					unknownSuffix = " or unknown severity"
+				}
+
+				fmt.Fprintf(app.ErrorOut, "Found %v security issue(s) with severity '%v' or higher%s%s", findingsToFailOn, failOn, unknownSuffix, fmt.Sprintln())
+				utils.Exit(1)
+			}
+		},
+	}
+
+	auditCmd.Flags().StringVarP(&failOn, "fail-on", "", "", "exit with non-zero code for issues with this severity or higher: low, moderate, high or critical")
+	auditCmd.Flags().BoolVarP(&failOnUnknown, "fail-on-unknown", "", true, "let --fail-on also exit with non-zero code for issues without known severity")
+	auditCmd.Flags().StringVarP(&format, "format", "", "text", "output format: text or sarif")
+	auditCmd.Flags().BoolVarP(&useGovulncheck, "govulncheck", "", false, "check reachability of security issues with govulncheck")
+	auditCmd.Flags().StringArrayVarP(&ignore, "ignore", "", []string{}, "one or more IDs of advisories to ignore")
+	auditCmd.Flags().StringVarP(&output, "output", "o", "", "custom output file")
+
+	parentCmd.AddCommand(
+		auditCmd,
+	)
+}
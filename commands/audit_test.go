@@ -0,0 +1,107 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+)
+
+func TestFormatReachableVulnerability(t *testing.T) {
+	result, err := types.ParseGovulncheckResult(strings.NewReader(`
+{"osv":{"id":"GO-2024-0001","aliases":["CVE-2024-0001"],"summary":"Bad things"}}
+{"finding":{"osv":"GO-2024-0001","fixed_version":"v1.2.3","trace":[{"module":"example.com/mod","version":"v1.0.0","function":"Parse"}]}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := format_reachable_vulnerability(result, "GO-2024-0001", map[string]bool{})
+	expected := "GO-2024-0001 in 'example.com/mod@v1.0.0' (fixed in v1.2.3): Bad things [govulncheck]"
+	if actual != expected {
+		t.Errorf("expected '%v', got '%v'", expected, actual)
+	}
+
+	// also reported by osv.dev with an alias
+	actual = format_reachable_vulnerability(result, "GO-2024-0001", map[string]bool{"CVE-2024-0001": true})
+	if !strings.HasSuffix(actual, "[govulncheck + osv.dev]") {
+		t.Errorf("expected osv.dev as source, got '%v'", actual)
+	}
+}
+
+func TestCreateAuditSarifLogWithReachableFinding(t *testing.T) {
+	app := &types.AppContext{
+		Cwd: t.TempDir(),
+	}
+
+	findings := []auditFinding{
+		{modulePath: "example.com/a", moduleVersion: "v1.0.0", reachable: true, vulnerability: types.OsvDevResponseVulnerabilityItem{Id: "GO-2024-0001"}},
+		{modulePath: "example.com/b", moduleVersion: "v1.0.0", vulnerability: types.OsvDevResponseVulnerabilityItem{Id: "GO-2024-0002"}},
+	}
+
+	sarifLog := create_audit_sarif_log(app, findings, "1.0.0")
+
+	results := sarifLog.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %v", len(results))
+	}
+	if results[0].Properties["reachable"] != true {
+		t.Errorf("expected first result to be reachable, got %v", results[0].Properties)
+	}
+	if results[1].Properties != nil {
+		t.Errorf("expected no properties for second result, got %v", results[1].Properties)
+	}
+}
+
+func TestIsAuditFindingFailing(t *testing.T) {
+	high := auditFinding{vulnerability: types.OsvDevResponseVulnerabilityItem{
+		Id:               "GHSA-0001",
+		DatabaseSpecific: &types.OsvDevResponseVulnerabilityItemDataSpecificInfo{Severity: "HIGH"},
+	}}
+	unrated := auditFinding{vulnerability: types.OsvDevResponseVulnerabilityItem{Id: "GO-2024-0001"}}
+
+	tests := []struct {
+		name          string
+		finding       auditFinding
+		failOnLevel   int
+		failOnUnknown bool
+		expected      bool
+	}{
+		{"no fail-on", high, -1, true, false},
+		{"high with fail-on low", high, auditSeverityLevels["low"], true, true},
+		{"high with fail-on critical", high, auditSeverityLevels["critical"], true, false},
+		{"unrated with fail-on low", unrated, auditSeverityLevels["low"], true, true},
+		{"unrated with fail-on critical", unrated, auditSeverityLevels["critical"], true, true},
+		{"unrated without fail-on-unknown", unrated, auditSeverityLevels["low"], false, false},
+		{"unrated without fail-on", unrated, -1, true, false},
+	}
+
+	for _, test := range tests {
+		actual := is_audit_finding_failing(test.finding, test.failOnLevel, test.failOnUnknown)
+		if actual != test.expected {
+			t.Errorf("%v: expected %v, got %v", test.name, test.expected, actual)
+		}
+	}
+}
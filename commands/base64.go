@@ -25,24 +25,64 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
-	"io"
+	"strings"
 
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
+// decode_base64_any() - decodes Base64 data with standard or URL-safe
+// alphabet, with or without padding
+func decode_base64_any(data string) ([]byte, error) {
+	// ignore line breaks and other whitespaces
+	data = strings.Join(strings.Fields(data), "")
+
+	encoding := base64.RawStdEncoding
+	if strings.ContainsAny(data, "-_") {
+		encoding = base64.RawURLEncoding
+	}
+
+	return encoding.DecodeString(strings.TrimRight(data, "="))
+}
+
 func Init_Base64_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var decode bool
+	var noPad bool
+	var urlSafe bool
+
 	var base64Cmd = &cobra.Command{
-		Use:     "base64",
+		Use:     "base64 [files]",
 		Aliases: []string{"b64"},
 		Short:   "Encode Base64",
-		Long:    `Encode data from STDIN to STDOUT as Base64 encoded data.`,
+		Long:    `Encode data from STDIN and/or files to STDOUT as Base64 encoded data or decode it.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			encoder := base64.NewEncoder(base64.StdEncoding, app.Out)
+			if decode {
+				data, err := app.ReadAllInputs(args...)
+				utils.CheckForError(err)
+
+				decodedData, err := decode_base64_any(string(data))
+				utils.CheckForError(err)
+
+				written, err := app.Out.Write(decodedData)
+				utils.CheckForError(err)
+
+				app.Debug(fmt.Sprintf("Bytes written: %v", written))
+				return
+			}
+
+			encoding := base64.StdEncoding
+			if urlSafe {
+				encoding = base64.URLEncoding
+			}
+			if noPad {
+				encoding = encoding.WithPadding(base64.NoPadding)
+			}
+
+			encoder := base64.NewEncoder(encoding, app.Out)
 			defer encoder.Close()
 
-			written, err := io.Copy(encoder, app.In)
+			written, err := app.WriteAllInputsTo(encoder, args...)
 			utils.CheckForError(err)
 
 			if app.Verbose {
@@ -52,6 +92,10 @@ func Init_Base64_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		},
 	}
 
+	base64Cmd.Flags().BoolVarP(&decode, "decode", "d", false, "decode data and detect variant automatically")
+	base64Cmd.Flags().BoolVarP(&noPad, "no-pad", "", false, "do not add padding characters")
+	base64Cmd.Flags().BoolVarP(&urlSafe, "url", "", false, "use URL-safe alphabet")
+
 	parentCmd.AddCommand(
 		base64Cmd,
 	)
@@ -0,0 +1,134 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+// set_test_stdin() - replaces STDIN with a pipe, which
+// provides `data`, until the end of the current test
+func set_test_stdin(t *testing.T, data []byte) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		w.Write(data)
+		w.Close()
+	}()
+
+	oldStdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() {
+		os.Stdin = oldStdin
+		r.Close()
+	})
+}
+
+// run_base64_test_command() - runs `base64` command with `stdin`
+// as STDIN and returns its output
+func run_base64_test_command(t *testing.T, stdin []byte, args ...string) []byte {
+	set_test_stdin(t, stdin)
+
+	var out bytes.Buffer
+
+	app := &types.AppContext{
+		Out: &out,
+	}
+
+	rootCmd := &cobra.Command{Use: "gpm"}
+	Init_Base64_Command(rootCmd, app)
+
+	rootCmd.SetArgs(append([]string{"base64"}, args...))
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return out.Bytes()
+}
+
+func TestBase64RoundTrip(t *testing.T) {
+	// all byte values, so that encoded data contains `+` and `/`
+	// or `-` and `_`, and a length, which requires padding
+	data := make([]byte, 256)
+	for i := range data {
+		data[i] = byte(255 - i)
+	}
+
+	tests := []struct {
+		args     []string
+		encoding *base64.Encoding
+	}{
+		{[]string{}, base64.StdEncoding},
+		{[]string{"--url"}, base64.URLEncoding},
+		{[]string{"--no-pad"}, base64.RawStdEncoding},
+		{[]string{"--url", "--no-pad"}, base64.RawURLEncoding},
+	}
+
+	for _, test := range tests {
+		encoded := run_base64_test_command(t, data, test.args...)
+
+		expected := test.encoding.EncodeToString(data)
+		if string(encoded) != expected {
+			t.Fatalf("%v: expected '%v', got '%s'", test.args, expected, encoded)
+		}
+
+		decoded := run_base64_test_command(t, encoded, "--decode")
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("%v: decoded data is different", test.args)
+		}
+	}
+}
+
+func TestDecodeBase64Any(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0xfe, 0x01}
+
+	for _, encoded := range []string{
+		"+//+AQ==",
+		"+//+AQ",
+		"-__-AQ==",
+		"-__-AQ",
+		" +//+\nAQ==\n",
+	} {
+		decoded, err := decode_base64_any(encoded)
+		if err != nil {
+			t.Fatalf("%q: %v", encoded, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("%q: expected %v, got %v", encoded, data, decoded)
+		}
+	}
+
+	if _, err := decode_base64_any("+/-_"); err == nil {
+		t.Error("expected error for mixed alphabets")
+	}
+}
@@ -0,0 +1,421 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/mkloubert/go-package-manager/constants"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+// benchmarkResults stores the values of benchmarks
+// grouped by `<package>/<benchmark>` and unit
+type benchmarkResults struct {
+	names  []string                        // keys in order of appearance
+	units  map[string][]string             // units by key in order of appearance
+	values map[string]map[string][]float64 // values by key and unit
+}
+
+// mean() - returns the mean value of a benchmark unit and `false` if not found
+func (r *benchmarkResults) mean(name string, unit string) (float64, bool) {
+	values := r.values[name][unit]
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values)), true
+}
+
+// get_benchmark_file_path() - returns the path of a saved benchmark
+// inside `<GPM-ROOT>/benchmarks` folder
+func get_benchmark_file_path(app *types.AppContext, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return "", fmt.Errorf("invalid benchmark name '%v'", name)
+	}
+
+	rootPath, err := app.GetRootPath()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(rootPath, "benchmarks", name), nil
+}
+
+// load_benchmark_results() - loads saved results of a benchmark by name
+func load_benchmark_results(app *types.AppContext, name string) (benchmarkResults, error) {
+	filePath, err := get_benchmark_file_path(app, name)
+	if err != nil {
+		return benchmarkResults{}, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return benchmarkResults{}, err
+	}
+
+	return parse_benchmark_results(bytes.NewReader(data)), nil
+}
+
+// get_benchmark_gomaxprocs() - returns the value of GOMAXPROCS for
+// running benchmarks, which is also used as suffix of benchmark names
+func get_benchmark_gomaxprocs() int {
+	procs, err := strconv.Atoi(strings.TrimSpace(os.Getenv("GOMAXPROCS")))
+	if err == nil && procs > 0 {
+		return procs
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// parse_benchmark_line() - returns the fields of a result line like
+// `<name> <iterations> <value> <unit> [<value> <unit> ...]`
+func parse_benchmark_line(line string) ([]string, bool) {
+	if !strings.HasPrefix(line, "Benchmark") {
+		return nil, false
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) < 4 || len(fields)%2 != 0 {
+		return nil, false
+	}
+	if _, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return nil, false
+	}
+
+	return fields, true
+}
+
+// parse_benchmark_results() - parses output of `go test -bench`
+func parse_benchmark_results(r io.Reader) benchmarkResults {
+	results := benchmarkResults{
+		names:  []string{},
+		units:  map[string][]string{},
+		values: map[string]map[string][]float64{},
+	}
+
+	lines := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSpace(scanner.Text()))
+	}
+
+	// `go test` adds `-<GOMAXPROCS>` to benchmark names, if it is not 1,
+	// which is written as `gomaxprocs: <value>` to saved results
+	procsSuffix := ""
+	hasProcs := false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "gomaxprocs:") {
+			procs := strings.TrimSpace(line[11:])
+			if procs != "1" {
+				procsSuffix = "-" + procs
+			}
+
+			hasProcs = true
+			break
+		}
+	}
+	if !hasProcs {
+		// without the value the suffix can only be
+		// detected, if all benchmarks have the same one
+		suffixRegex := regexp.MustCompile(`-\d+$`)
+
+		for _, line := range lines {
+			fields, ok := parse_benchmark_line(line)
+			if !ok {
+				continue
+			}
+
+			suffix := suffixRegex.FindString(fields[0])
+			if suffix == "" || (procsSuffix != "" && suffix != procsSuffix) {
+				procsSuffix = ""
+				break
+			}
+
+			procsSuffix = suffix
+		}
+	}
+
+	pkg := ""
+	for _, line := range lines {
+		if strings.HasPrefix(line, "pkg:") {
+			pkg = strings.TrimSpace(line[4:])
+			continue
+		}
+
+		fields, ok := parse_benchmark_line(line)
+		if !ok {
+			continue
+		}
+
+		name := fields[0]
+		if procsSuffix != "" {
+			name = strings.TrimSuffix(name, procsSuffix)
+		}
+		if pkg != "" {
+			name = pkg + "/" + name
+		}
+
+		if _, ok := results.values[name]; !ok {
+			results.names = append(results.names, name)
+			results.values[name] = map[string][]float64{}
+		}
+
+		for i := 2; i < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				continue
+			}
+
+			unit := fields[i+1]
+			if !slices.Contains(results.units[name], unit) {
+				results.units[name] = append(results.units[name], unit)
+			}
+
+			results.values[name][unit] = append(results.values[name][unit], value)
+		}
+	}
+
+	return results
+}
+
+// print_benchmark_comparison() - writes a table with deltas
+// between baseline and current benchmark results
+func print_benchmark_comparison(app *types.AppContext, baseline benchmarkResults, current benchmarkResults) {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	tHeadColor := color.New(color.FgWhite, color.Bold).SprintFunc()
+
+	formatValue := func(v float64, ok bool) string {
+		if !ok {
+			return "-"
+		}
+
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	names := append([]string{}, current.names...)
+	for _, n := range baseline.names {
+		if !slices.Contains(names, n) {
+			names = append(names, n)
+		}
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(app.Out)
+
+	t.AppendHeader(table.Row{tHeadColor("Benchmark"), tHeadColor("Unit"), tHeadColor("Baseline"), tHeadColor("Current"), tHeadColor("Delta"), tHeadColor("%")})
+	for _, n := range names {
+		units := append([]string{}, current.units[n]...)
+		for _, u := range baseline.units[n] {
+			if !slices.Contains(units, u) {
+				units = append(units, u)
+			}
+		}
+
+		for _, u := range units {
+			baselineValue, hasBaseline := baseline.mean(n, u)
+			currentValue, hasCurrent := current.mean(n, u)
+
+			delta := "-"
+			percentage := "-"
+			if hasBaseline && hasCurrent {
+				diff := currentValue - baselineValue
+				delta = fmt.Sprintf("%+g", diff)
+
+				if baselineValue != 0 {
+					p := diff / baselineValue * 100.0
+					percentage = fmt.Sprintf("%+.2f%%", p)
+
+					// only for throughput higher values are better
+					isBetter := p < 0
+					if strings.HasSuffix(u, "/s") {
+						isBetter = p > 0
+					}
+
+					if p != 0 {
+						if isBetter {
+							percentage = green(percentage)
+						} else {
+							percentage = red(percentage)
+						}
+					}
+				}
+			}
+
+			t.AppendRow(table.Row{
+				n,
+				u,
+				formatValue(baselineValue, hasBaseline),
+				formatValue(currentValue, hasCurrent),
+				delta,
+				percentage,
+			})
+		}
+	}
+
+	t.Render()
+}
+
+// save_benchmark_results() - saves the raw output of `go test -bench`
+// by name
+func save_benchmark_results(app *types.AppContext, name string, data []byte) (string, error) {
+	filePath, err := get_benchmark_file_path(app, name)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(path.Dir(filePath), constants.DefaultDirMode)
+	if err != nil {
+		return "", err
+	}
+
+	return filePath, os.WriteFile(filePath, data, constants.DefaultFileMode)
+}
+
+func Init_Bench_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var baseline string
+	var benchmem bool
+	var compare string
+	var count int
+	var pattern string
+	var save string
+
+	var benchCmd = &cobra.Command{
+		Use:     "bench [packages]",
+		Aliases: []string{"benchmark", "benchmarks"},
+		Short:   "Runs benchmarks",
+		Long:    `Runs benchmarks of the current project and compares them with saved ones.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			baseline = strings.TrimSpace(baseline)
+			compare = strings.TrimSpace(compare)
+			save = strings.TrimSpace(save)
+
+			if compare != "" {
+				// compare two saved runs
+				if baseline == "" {
+					utils.CloseWithError(fmt.Errorf("--compare requires --baseline"))
+				}
+
+				baselineResults, err := load_benchmark_results(app, baseline)
+				utils.CheckForError(err)
+
+				compareResults, err := load_benchmark_results(app, compare)
+				utils.CheckForError(err)
+
+				print_benchmark_comparison(app, baselineResults, compareResults)
+				return
+			}
+
+			var baselineResults *benchmarkResults
+			if baseline != "" {
+				// load first, so we fail before running benchmarks
+				results, err := load_benchmark_results(app, baseline)
+				utils.CheckForError(err)
+
+				baselineResults = &results
+			}
+
+			packages := args
+			if len(packages) == 0 {
+				packages = []string{"."}
+			}
+
+			goTestArgs := []string{"test", "-run", "^$", "-bench", pattern}
+			if benchmem {
+				goTestArgs = append(goTestArgs, "-benchmem")
+			}
+			if count > 0 {
+				goTestArgs = append(goTestArgs, "-count", strconv.Itoa(count))
+			}
+			goTestArgs = append(goTestArgs, packages...)
+
+			// set explicitly, so we know the suffix of the benchmark names
+			gomaxprocs := get_benchmark_gomaxprocs()
+			env := []string{fmt.Sprintf("GOMAXPROCS=%v", gomaxprocs)}
+
+			if app.DryRun {
+				app.RunShellCommandByArgsWithEnv(env, "go", goTestArgs...)
+				return
+			}
+
+			var output bytes.Buffer
+			output.WriteString(fmt.Sprintf("gomaxprocs: %v%v", gomaxprocs, fmt.Sprintln()))
+
+			p := utils.CreateShellCommandByArgs("go", goTestArgs...)
+			p.Dir = app.Cwd
+			p.Env = append(p.Env, env...)
+			p.Stdout = io.MultiWriter(app.Out, &output)
+
+			app.Debug(fmt.Sprintf("Running 'go %v' ...", strings.Join(goTestArgs, " ")))
+			exitCode, err := utils.RunCommandWithExitCode(p)
+			utils.CheckForError(err)
+			if exitCode != 0 {
+				utils.CloseWithError(fmt.Errorf("benchmarks failed with exit code %v", exitCode))
+			}
+
+			if save != "" {
+				filePath, err := save_benchmark_results(app, save, output.Bytes())
+				utils.CheckForError(err)
+
+				app.Info(fmt.Sprintf("Saved benchmark results to '%v'", filePath))
+			}
+
+			if baselineResults != nil {
+				fmt.Fprintln(app.Out)
+				print_benchmark_comparison(app, *baselineResults, parse_benchmark_results(bytes.NewReader(output.Bytes())))
+			}
+		},
+	}
+
+	benchCmd.Flags().StringVarP(&baseline, "baseline", "", "", "name of saved results to compare with")
+	benchCmd.Flags().BoolVarP(&benchmem, "benchmem", "m", false, "print memory allocation statistics")
+	benchCmd.Flags().StringVarP(&compare, "compare", "", "", "name of saved results to compare with baseline instead of running benchmarks")
+	benchCmd.Flags().IntVarP(&count, "count", "c", 1, "number of runs of each benchmark")
+	benchCmd.Flags().StringVarP(&pattern, "pattern", "p", ".", "regular expression of benchmarks to run")
+	benchCmd.Flags().StringVarP(&save, "save", "s", "", "name for saving the results")
+
+	parentCmd.AddCommand(
+		benchCmd,
+	)
+}
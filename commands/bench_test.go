@@ -0,0 +1,111 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseBenchmarkResults(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected []string
+	}{
+		{
+			"suffix of GOMAXPROCS",
+			`gomaxprocs: 8
+goos: linux
+goarch: amd64
+pkg: example.com/a
+cpu: Intel(R) Core(TM) i7-8565U CPU @ 1.80GHz
+BenchmarkAdd-8            	1000000000	         0.2500 ns/op
+BenchmarkX/size-1024-8    	    1000	      1200 ns/op	     512 B/op	       2 allocs/op
+PASS
+ok  	example.com/a	1.234s`,
+			[]string{"example.com/a/BenchmarkAdd", "example.com/a/BenchmarkX/size-1024"},
+		},
+		{
+			"no suffix with GOMAXPROCS=1",
+			`gomaxprocs: 1
+pkg: example.com/a
+cpu: Intel(R) Core(TM) i7-8565U CPU @ 1.80GHz
+BenchmarkX/size-1024      	    1000	      1200 ns/op
+PASS`,
+			[]string{"example.com/a/BenchmarkX/size-1024"},
+		},
+		{
+			"other suffix than GOMAXPROCS",
+			`gomaxprocs: 4
+pkg: example.com/a
+BenchmarkX/size-1024-4    	    1000	      1200 ns/op
+BenchmarkX/size-8-4       	    1000	       100 ns/op`,
+			[]string{"example.com/a/BenchmarkX/size-1024", "example.com/a/BenchmarkX/size-8"},
+		},
+		{
+			"same suffix without GOMAXPROCS",
+			`pkg: example.com/a
+BenchmarkAdd-8            	1000000000	         0.2500 ns/op
+pkg: example.com/b
+BenchmarkSub-8            	1000000000	         0.2500 ns/op`,
+			[]string{"example.com/a/BenchmarkAdd", "example.com/b/BenchmarkSub"},
+		},
+		{
+			"different suffixes without GOMAXPROCS",
+			`pkg: example.com/a
+BenchmarkAdd              	1000000000	         0.2500 ns/op
+BenchmarkX/size-1024      	    1000	      1200 ns/op`,
+			[]string{"example.com/a/BenchmarkAdd", "example.com/a/BenchmarkX/size-1024"},
+		},
+	}
+
+	for _, test := range tests {
+		results := parse_benchmark_results(strings.NewReader(test.output))
+
+		if strings.Join(results.names, ",") != strings.Join(test.expected, ",") {
+			t.Errorf("%v: expected %v, got %v", test.name, test.expected, results.names)
+		}
+	}
+
+	results := parse_benchmark_results(strings.NewReader(`gomaxprocs: 8
+pkg: example.com/a
+BenchmarkX/size-1024-8    	    1000	      1200 ns/op	     512 B/op	       2 allocs/op
+BenchmarkX/size-1024-8    	    1000	      1000 ns/op	     512 B/op	       2 allocs/op
+BenchmarkInvalid-8        	    abc	      1000 ns/op
+--- FAIL: BenchmarkFailed-8`))
+
+	name := "example.com/a/BenchmarkX/size-1024"
+	if len(results.names) != 1 || results.names[0] != name {
+		t.Fatalf("unexpected names: %v", results.names)
+	}
+	if strings.Join(results.units[name], ",") != "ns/op,B/op,allocs/op" {
+		t.Errorf("unexpected units: %v", results.units[name])
+	}
+	if mean, ok := results.mean(name, "ns/op"); !ok || mean != 1100 {
+		t.Errorf("expected mean of 1100 ns/op, got %v", mean)
+	}
+	if _, ok := results.mean(name, "MB/s"); ok {
+		t.Error("expected no value for MB/s")
+	}
+}
@@ -23,7 +23,16 @@
 package commands
 
 import (
+	"fmt"
+	"os/exec"
+	"path"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/mkloubert/go-package-manager/constants"
 	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -31,10 +40,98 @@ const buildScriptName = "build"
 const postBuildScriptName = "postbuild"
 const preBuildScriptName = "prebuild"
 
+type buildTarget struct {
+	goarch string
+	goos   string
+}
+
+// get_build_targets() - returns the list of targets from `--os` and `--arch` flags
+// validated by `go tool dist list` or an empty list if not defined
+func get_build_targets(app *types.AppContext, oses []string, architectures []string) []buildTarget {
+	targets := []buildTarget{}
+
+	normalize := func(values []string) []string {
+		normalizedValues := []string{}
+		for _, v := range values {
+			for _, part := range strings.Split(v, ",") {
+				part = strings.TrimSpace(strings.ToLower(part))
+				if part != "" && !slices.Contains(normalizedValues, part) {
+					normalizedValues = append(normalizedValues, part)
+				}
+			}
+		}
+
+		return normalizedValues
+	}
+
+	oses = normalize(oses)
+	architectures = normalize(architectures)
+	if len(oses) == 0 && len(architectures) == 0 {
+		return targets
+	}
+
+	if len(oses) == 0 {
+		oses = append(oses, runtime.GOOS)
+	}
+	if len(architectures) == 0 {
+		architectures = append(architectures, runtime.GOARCH)
+	}
+
+	supportedTargets, err := get_supported_go_targets(app)
+	utils.CheckForError(err)
+
+	for _, goos := range oses {
+		for _, goarch := range architectures {
+			t := fmt.Sprintf("%v/%v", goos, goarch)
+			if !slices.Contains(supportedTargets, t) {
+				utils.CloseWithError(fmt.Errorf("target '%v' is not supported", t))
+			}
+
+			targets = append(targets, buildTarget{
+				goarch: goarch,
+				goos:   goos,
+			})
+		}
+	}
+
+	return targets
+}
+
+// get_supported_go_targets() - returns the list of supported
+// `<os>/<arch>` targets by `go tool dist list`
+func get_supported_go_targets(app *types.AppContext) ([]string, error) {
+	app.Debug(fmt.Sprintf("Running '%v' ...", "go tool dist list"))
+	output, err := exec.Command("go", "tool", "dist", "list").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var supportedTargets []string
+	for _, l := range strings.Split(string(output), "\n") {
+		supportedTarget := strings.TrimSpace(l)
+		if supportedTarget != "" {
+			supportedTargets = append(supportedTargets, supportedTarget)
+		}
+	}
+
+	return supportedTargets, nil
+}
+
 func Init_Build_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var commitVar string
+	var dirtyVar string
+	var injectVersion bool
+	var ldflags string
 	var noScript bool
 	var noPostScript bool
 	var noPreScript bool
+	var race bool
+	var targetArchitectures []string
+	var targetOSes []string
+	var reproducible bool
+	var tags string
+	var trimpath bool
+	var versionVar string
 
 	var buildCmd = &cobra.Command{
 		Use:     "build",
@@ -42,25 +139,122 @@ func Init_Build_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		Short:   "Runs build command",
 		Long:    `Runs the 'build' script or the official 'go build .'.`,
 		Run: func(cmd *cobra.Command, args []string) {
+			ok := app.HasScript(buildScriptName)
+			if !noScript && ok {
+				changedFlags := get_changed_flags(
+					cmd,
+					"arch", "commit-var", "dirty-var", "inject-version", "ldflags", "os",
+					"race", "reproducible", "tags", "trimpath", "version-var",
+				)
+				if len(changedFlags) > 0 {
+					utils.CloseWithError(fmt.Errorf("%v cannot be used with '%v' script, use --no-script to run 'go build' instead", strings.Join(changedFlags, ", "), buildScriptName))
+				}
+			}
+
 			if !noPreScript {
-				_, ok := app.GpmFile.Scripts[preBuildScriptName]
+				ok := app.HasScript(preBuildScriptName)
 				if ok {
 					app.RunScript(preBuildScriptName)
 				}
 			}
 
-			_, ok := app.GpmFile.Scripts[buildScriptName]
 			if !noScript && ok {
 				app.RunScript(buildScriptName, args...)
 			} else {
-				cmdArgs := []string{"go", "build", "."}
-				cmdArgs = append(cmdArgs, args...)
+				cmdArgs := []string{"go", "build"}
+
+				allLdflags := strings.TrimSpace(ldflags)
+				if injectVersion {
+					latestVersion, err := app.NewVersionManager().GetLatestVersion()
+					utils.CheckForError(err)
+
+					versionToInject := "0.0.0"
+					if latestVersion != nil {
+						versionToInject = latestVersion.String()
+					}
 
-				app.RunShellCommandByArgs(cmdArgs[0], cmdArgs[1:]...)
+					app.Debug(fmt.Sprintf("Injecting version '%v' into '%v' ...", versionToInject, versionVar))
+					allLdflags = strings.TrimSpace(
+						fmt.Sprintf("%v -X %v=%v", allLdflags, strings.TrimSpace(versionVar), versionToInject),
+					)
+
+					commitVarName := strings.TrimSpace(commitVar)
+					dirtyVarName := strings.TrimSpace(dirtyVar)
+					if commitVarName != "" || dirtyVarName != "" {
+						commitHash, err := app.GetGitCommitHash(false)
+						if err == nil {
+							if commitVarName != "" {
+								app.Debug(fmt.Sprintf("Injecting commit '%v' into '%v' ...", commitHash, commitVarName))
+								allLdflags = fmt.Sprintf("%v -X %v=%v", allLdflags, commitVarName, commitHash)
+							}
+
+							if dirtyVarName != "" {
+								isDirty, err := app.GetGitDirty()
+								utils.CheckForError(err)
+
+								app.Debug(fmt.Sprintf("Injecting dirty flag '%v' into '%v' ...", isDirty, dirtyVarName))
+								allLdflags = fmt.Sprintf("%v -X %v=%v", allLdflags, dirtyVarName, isDirty)
+							}
+						} else {
+							app.Warn(fmt.Sprintf("Will not inject commit information: %v", err))
+						}
+					}
+				}
+				if reproducible {
+					// no build ID and VCS information
+					allLdflags = strings.TrimSpace(allLdflags + " -buildid=")
+					cmdArgs = append(cmdArgs, "-buildvcs=false")
+				}
+				if allLdflags != "" {
+					cmdArgs = append(cmdArgs, "-ldflags", allLdflags)
+				}
+
+				if race {
+					cmdArgs = append(cmdArgs, "-race")
+				}
+
+				allTags := strings.TrimSpace(tags)
+				if allTags != "" {
+					cmdArgs = append(cmdArgs, "-tags", allTags)
+				}
+
+				if trimpath || reproducible {
+					cmdArgs = append(cmdArgs, "-trimpath")
+				}
+
+				targets := get_build_targets(app, targetOSes, targetArchitectures)
+				if len(targets) == 0 {
+					cmdArgs = append(cmdArgs, ".")
+					cmdArgs = append(cmdArgs, args...)
+
+					app.RunShellCommandByArgs(cmdArgs[0], cmdArgs[1:]...)
+				} else {
+					projectName := path.Base(app.Cwd)
+
+					for _, t := range targets {
+						targetArgs := append([]string{}, cmdArgs...)
+						if len(targets) > 1 {
+							// one executable per target
+							outputFilename := fmt.Sprintf("%v-%v-%v", projectName, t.goos, t.goarch)
+							if t.goos == "windows" {
+								outputFilename += constants.WindowsExecutableExt
+							}
+
+							targetArgs = append(targetArgs, "-o", outputFilename)
+						}
+						targetArgs = append(targetArgs, ".")
+						targetArgs = append(targetArgs, args...)
+
+						app.RunShellCommandByArgsWithEnv(
+							[]string{"GOOS=" + t.goos, "GOARCH=" + t.goarch},
+							targetArgs[0], targetArgs[1:]...,
+						)
+					}
+				}
 			}
 
 			if !noPostScript {
-				_, ok := app.GpmFile.Scripts[postBuildScriptName]
+				ok := app.HasScript(postBuildScriptName)
 				if ok {
 					app.RunScript(postBuildScriptName)
 				}
@@ -68,9 +262,20 @@ func Init_Build_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		},
 	}
 
+	buildCmd.Flags().StringSliceVarP(&targetArchitectures, "arch", "", []string{}, "one or more target cpu architectures")
+	buildCmd.Flags().StringVarP(&commitVar, "commit-var", "", "main.commit", "variable for commit hash with --inject-version")
+	buildCmd.Flags().StringVarP(&dirtyVar, "dirty-var", "", "main.dirty", "variable for dirty flag with --inject-version")
+	buildCmd.Flags().BoolVarP(&injectVersion, "inject-version", "", false, "inject latest version from git tags via ldflags")
+	buildCmd.Flags().StringVarP(&ldflags, "ldflags", "", "", "custom ldflags for go build")
 	buildCmd.Flags().BoolVarP(&noScript, "no-script", "n", false, "do not handle '"+buildScriptName+"' script")
 	buildCmd.Flags().BoolVarP(&noPostScript, "no-post-script", "", false, "do not handle '"+postBuildScriptName+"' script")
 	buildCmd.Flags().BoolVarP(&noPreScript, "no-pre-script", "", false, "do not handle '"+preBuildScriptName+"' script")
+	buildCmd.Flags().StringSliceVarP(&targetOSes, "os", "", []string{}, "one or more target operating systems")
+	buildCmd.Flags().BoolVarP(&race, "race", "", false, "enable data race detection")
+	buildCmd.Flags().BoolVarP(&reproducible, "reproducible", "", false, "create reproducible build")
+	buildCmd.Flags().StringVarP(&tags, "tags", "", "", "comma-separated list of build tags")
+	buildCmd.Flags().BoolVarP(&trimpath, "trimpath", "", false, "remove file system paths from executable")
+	buildCmd.Flags().StringVarP(&versionVar, "version-var", "", "main.version", "variable for --inject-version")
 
 	parentCmd.AddCommand(
 		buildCmd,
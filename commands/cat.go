@@ -24,21 +24,111 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/quick"
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
+// is_cat_stdin_piped() - checks if the input of `app` is no terminal
+func is_cat_stdin_piped(app *types.AppContext) bool {
+	if app.In == nil {
+		return false
+	}
+
+	f, ok := app.In.(*os.File)
+	if !ok {
+		return true // like a buffer
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return (stat.Mode() & os.ModeCharDevice) == 0
+}
+
 func Init_Cat_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var formatter string
+	var highlight bool
+	var style string
+
 	var catCmd = &cobra.Command{
 		Use:     "cat",
 		Aliases: []string{"meow"},
 		Short:   "Outputs input",
 		Long:    `Outputs input from STDIN and/or files to STDOUT.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			written, err := app.WriteAllInputsTo(app.Out, args...)
-			utils.CheckForError(err)
+			var writeData func(data []byte, source string)
+			if !highlight || !utils.IsTerminal(app.Out) {
+				writeData = func(data []byte, source string) {
+					_, err := app.Out.Write(data)
+					utils.CheckForError(err)
+				}
+			} else {
+				consoleFormatter := strings.TrimSpace(formatter)
+				if consoleFormatter == "" {
+					consoleFormatter = utils.GetBestChromaFormatterName()
+				}
+				consoleStyle := strings.TrimSpace(style)
+				if consoleStyle == "" {
+					consoleStyle = utils.GetBestChromaStyleName()
+				}
+
+				writeData = func(data []byte, source string) {
+					text := string(data)
+
+					filename := ""
+					if source != "-" {
+						filename = filepath.Base(source)
+					}
+
+					lexer := lexers.Match(filename)
+					if lexer == nil {
+						lexer = lexers.Analyse(text)
+					}
+					if lexer == nil {
+						fmt.Fprint(app.Out, text)
+						return
+					}
+
+					lexerName := lexer.Config().Name
+					app.Debug(fmt.Sprintf("Highlighting '%v' as '%v' ...", source, lexerName))
+
+					err := quick.Highlight(app.Out, text, lexerName, consoleFormatter, consoleStyle)
+					if err != nil {
+						fmt.Fprint(app.Out, text)
+					}
+				}
+			}
+
+			sources := []string{}
+			if !slices.Contains(args, "-") && is_cat_stdin_piped(app) {
+				// first from STDIN
+				sources = append(sources, "-")
+			}
+			for _, a := range args {
+				source := strings.TrimSpace(a)
+				if source != "" {
+					sources = append(sources, source)
+				}
+			}
+
+			var written int
+			for _, source := range sources {
+				data, err := app.LoadDataFrom(source)
+				utils.CheckForError(err)
+
+				writeData(data, source)
+				written += len(data)
+			}
 
 			if app.Verbose {
 				fmt.Println()
@@ -47,6 +137,10 @@ func Init_Cat_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		},
 	}
 
+	catCmd.Flags().StringVarP(&formatter, "formatter", "", "", "custom formatter for syntax highlighting")
+	catCmd.Flags().BoolVarP(&highlight, "highlight", "", false, "highlight syntax of known file types")
+	catCmd.Flags().StringVarP(&style, "style", "", "", "custom style for syntax highlighting")
+
 	parentCmd.AddCommand(
 		catCmd,
 	)
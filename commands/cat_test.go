@@ -0,0 +1,80 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+func TestCatCommandWithInputs(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("from web\n"))
+	}))
+	defer server.Close()
+
+	for _, highlight := range []bool{false, true} {
+		var out bytes.Buffer
+
+		app := &types.AppContext{
+			Cwd: dir,
+			In:  strings.NewReader("from stdin\n"),
+			Out: &out,
+		}
+
+		rootCmd := &cobra.Command{Use: "gpm"}
+		Init_Cat_Command(rootCmd, app)
+
+		args := []string{"cat", "main.go", server.URL}
+		if highlight {
+			// output is no terminal, so highlighting is skipped
+			args = append(args, "--highlight")
+		}
+
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := "from stdin\npackage main\nfrom web\n"
+		if out.String() != expected {
+			t.Errorf("highlight %v: expected %q, got %q", highlight, expected, out.String())
+		}
+	}
+}
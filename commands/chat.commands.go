@@ -0,0 +1,118 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mkloubert/go-package-manager/constants"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+// attach_to_ai_chat() - loads a file or URL as text and appends it to the
+// history of a chat as user message(s); returns the number of messages
+func attach_to_ai_chat(app *types.AppContext, api types.ChatAI, source string) (int, error) {
+	data, err := app.LoadDataFrom(source)
+	if err != nil {
+		return 0, err
+	}
+
+	if !utils.IsReadableText(data) {
+		return 0, fmt.Errorf("'%v' seems to be a binary file and cannot be attached", source)
+	}
+
+	chunks := utils.SplitText(string(data))
+
+	history := api.ExportHistory()
+	for i, chunk := range chunks {
+		title := fmt.Sprintf("Content of '%v'", source)
+		if len(chunks) > 1 {
+			title += fmt.Sprintf(" (part %v of %v)", i+1, len(chunks))
+		}
+
+		history = append(history, types.ChatAIMessage{
+			Content: title + ":\n\n```\n" + chunk + "\n```",
+			Role:    "user",
+		})
+	}
+	api.ImportHistory(history)
+
+	return len(chunks), nil
+}
+
+// export_ai_chat_to_markdown() - writes the history of a chat
+// as Markdown document to a file, relative to the current directory of `app`
+func export_ai_chat_to_markdown(app *types.AppContext, api types.ChatAI, file string) error {
+	var markdown strings.Builder
+
+	markdown.WriteString(fmt.Sprintf("# Chat with %v@%v\n", api.GetModel(), api.GetProvider()))
+	for _, m := range api.ExportHistory() {
+		if strings.TrimSpace(m.Content) == "" {
+			continue
+		}
+
+		role := m.Role
+		if role != "" {
+			role = strings.ToUpper(role[:1]) + role[1:]
+		}
+
+		// content is kept as it is, so fenced code blocks are preserved
+		markdown.WriteString(fmt.Sprintf("\n## %v\n\n%v\n", role, strings.TrimSpace(m.Content)))
+	}
+
+	filePath := app.GetFullPathOrDefault(file, "")
+
+	app.Debug(fmt.Sprintf("Writing conversation to '%v' ...", filePath))
+	return os.WriteFile(filePath, []byte(markdown.String()), constants.DefaultFileMode)
+}
+
+// get_last_ai_chat_user_message() - returns the last user message
+// of a chat without changing its history
+func get_last_ai_chat_user_message(api types.ChatAI) (string, bool) {
+	history := api.ExportHistory()
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Role == "user" {
+			return history[i].Content, true
+		}
+	}
+
+	return "", false
+}
+
+// send_edited_ai_chat_message() - replaces the last turn of a chat with a new
+// message, where the old turn is restored if the message could not be sent
+func send_edited_ai_chat_message(api types.ChatAI, message string, onUpdate types.ChatAIMessageChunkReceiver) error {
+	history := api.ExportHistory()
+
+	api.RemoveLastTurn()
+
+	err := api.SendMessage(message, onUpdate)
+	if err != nil {
+		api.ImportHistory(history)
+	}
+
+	return err
+}
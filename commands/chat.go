@@ -28,10 +28,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/alecthomas/chroma/quick"
-	"github.com/briandowns/spinner"
 	"github.com/c-bata/go-prompt"
 	"github.com/fatih/color"
 	"github.com/mkloubert/go-package-manager/types"
@@ -40,6 +38,9 @@ import (
 )
 
 func Init_Chat_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var exportFile string
+	var maxContextMessages int
+	var maxContextTokens int
 	var temperature float32
 
 	var chatCmd = &cobra.Command{
@@ -59,7 +60,9 @@ func Init_Chat_Command(parentCmd *cobra.Command, app *types.AppContext) {
 			currentTemperature := temperature
 
 			apiOptions := types.CreateAIChatOptions{
-				SystemPrompt: &systemPrompt,
+				MaxContextMessages: &maxContextMessages,
+				MaxContextTokens:   &maxContextTokens,
+				SystemPrompt:       &systemPrompt,
 			}
 
 			api, err := app.CreateAIChat(apiOptions)
@@ -133,6 +136,8 @@ func Init_Chat_Command(parentCmd *cobra.Command, app *types.AppContext) {
 			}
 
 			showCompletionAtStart := true
+			initialInput := ""
+			isEditPending := false // last message has been opened by /edit
 			for {
 				fmt.Printf(
 					"%v@%v%v",
@@ -152,6 +157,11 @@ func Init_Chat_Command(parentCmd *cobra.Command, app *types.AppContext) {
 				if showCompletionAtStart {
 					userInputOptions = append(userInputOptions, prompt.OptionShowCompletionAtStart())
 				}
+				if initialInput != "" {
+					// message to edit
+					userInputOptions = append(userInputOptions, prompt.OptionInitialBufferText(initialInput))
+					initialInput = ""
+				}
 
 				userInput := strings.TrimSpace(
 					prompt.Input(
@@ -160,6 +170,11 @@ func Init_Chat_Command(parentCmd *cobra.Command, app *types.AppContext) {
 						userInputOptions...,
 					),
 				)
+
+				// the last turn is only replaced, if the next input is sent
+				isEditing := isEditPending
+				isEditPending = false
+
 				if userInput == "" {
 					fmt.Printf("[INPUT ERROR] Please submit input%v", fmt.Sprintln())
 					continue
@@ -169,11 +184,57 @@ func Init_Chat_Command(parentCmd *cobra.Command, app *types.AppContext) {
 
 				lowerUserInput := strings.ToLower(userInput)
 
-				if lowerUserInput == "/cls" {
+				if strings.HasPrefix(lowerUserInput, "/attach ") {
+					source := strings.TrimSpace(userInput[8:])
+					if source == "" {
+						fmt.Printf("[INPUT ERROR] Please define a file or URL%v", fmt.Sprintln())
+					} else {
+						messageCount, err := attach_to_ai_chat(app, api, source)
+						if err != nil {
+							fmt.Printf("[ATTACH ERROR] %v%v", err, fmt.Sprintln())
+						} else {
+							fmt.Printf("Attached '%v' as %v message(s)%v", source, messageCount, fmt.Sprintln())
+						}
+					}
+
+					continue
+				} else if lowerUserInput == "/cls" {
 					utils.ClearConsole()
 					continue
+				} else if lowerUserInput == "/edit" || strings.HasPrefix(lowerUserInput, "/edit ") {
+					lastMessage, ok := get_last_ai_chat_user_message(api)
+					if !ok {
+						fmt.Printf("[INPUT ERROR] No message to edit%v", fmt.Sprintln())
+						continue
+					}
+
+					newMessage := strings.TrimSpace(userInput[5:])
+					if newMessage == "" {
+						// open last message for editing
+						initialInput = lastMessage
+						isEditPending = true
+						continue
+					}
+
+					// send new text instead
+					userInput = newMessage
+					isEditing = true
 				} else if lowerUserInput == "/exit" {
 					break
+				} else if strings.HasPrefix(lowerUserInput, "/export ") {
+					file := strings.TrimSpace(userInput[8:])
+					if file == "" {
+						fmt.Printf("[INPUT ERROR] Please define a file%v", fmt.Sprintln())
+					} else {
+						err := export_ai_chat_to_markdown(app, api, file)
+						if err != nil {
+							fmt.Printf("[EXPORT ERROR] %v%v", err, fmt.Sprintln())
+						} else {
+							fmt.Printf("Exported conversation to '%v'%v", file, fmt.Sprintln())
+						}
+					}
+
+					continue
 				} else if strings.HasPrefix(lowerUserInput, "/format ") {
 					newFormatter := strings.TrimSpace(lowerUserInput[8:])
 					if newFormatter == "" {
@@ -241,24 +302,37 @@ func Init_Chat_Command(parentCmd *cobra.Command, app *types.AppContext) {
 						}
 					}
 
+					continue
+				} else if lowerUserInput == "/undo" {
+					_, ok := api.RemoveLastTurn()
+					if ok {
+						fmt.Println("Removed last message and its answer")
+					} else {
+						fmt.Printf("[INPUT ERROR] Nothing to undo%v", fmt.Sprintln())
+					}
+
 					continue
 				} else if strings.HasPrefix(lowerUserInput, "/") {
 					fmt.Printf("[INPUT ERROR] Invalid command '%v'%v", userInput, fmt.Sprintln())
 					continue
 				}
 
-				s := spinner.New(spinner.CharSets[24], 100*time.Millisecond)
+				s := app.NewSpinner()
 				s.Start()
 				s.Suffix = " Waiting for assistant ..."
 
 				answer := ""
-				err := api.SendMessage(
-					userInput,
-					func(messageChunk string) error {
-						answer += messageChunk
-						return nil
-					},
-				)
+				onMessageUpdate := func(messageChunk string) error {
+					answer += messageChunk
+					return nil
+				}
+
+				var err error
+				if isEditing {
+					err = send_edited_ai_chat_message(api, userInput, onMessageUpdate)
+				} else {
+					err = api.SendMessage(userInput, onMessageUpdate)
+				}
 
 				s.Stop()
 
@@ -274,10 +348,19 @@ func Init_Chat_Command(parentCmd *cobra.Command, app *types.AppContext) {
 				}
 				fmt.Println()
 			}
+
+			exportFile = strings.TrimSpace(exportFile)
+			if exportFile != "" {
+				err := export_ai_chat_to_markdown(app, api, exportFile)
+				utils.CheckForError(err)
+			}
 		},
 	}
 
-	chatCmd.Flags().Float32VarP(&temperature, "temperature", "", utils.GetAIChatTemperature(0.3), "custom temperature value")
+	chatCmd.Flags().StringVarP(&exportFile, "export", "", "", "write conversation to a Markdown file at the end")
+	chatCmd.Flags().IntVarP(&maxContextMessages, "max-context-messages", "", 0, "maximum number of recent messages to send, 0 for no limit")
+	chatCmd.Flags().IntVarP(&maxContextTokens, "max-context-tokens", "", 0, "maximum number of estimated tokens to send, 0 for no limit")
+	chatCmd.Flags().Float32VarP(&temperature, "temperature", "", app.GetAIChatTemperature(0.3), "custom temperature value")
 
 	parentCmd.AddCommand(
 		chatCmd,
@@ -0,0 +1,117 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+)
+
+func TestChatAttachAndExportUseAppCwd(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("Hello from notes"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := &types.AppContext{
+		Cwd: dir,
+	}
+	api := &types.MockAIChat{
+		Model: "mock-model",
+	}
+
+	messageCount, err := attach_to_ai_chat(app, api, "notes.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if messageCount != 1 {
+		t.Errorf("expected 1 message, got %v", messageCount)
+	}
+
+	err = export_ai_chat_to_markdown(app, api, "chat.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	markdown, err := os.ReadFile(filepath.Join(dir, "chat.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(markdown), "Hello from notes") {
+		t.Errorf("unexpected Markdown: %v", string(markdown))
+	}
+}
+
+func TestChatEditKeepsLastTurnUntilSent(t *testing.T) {
+	api := &types.MockAIChat{
+		Responses: []string{"First answer"},
+	}
+
+	err := api.SendMessage("First question", func(messageChunk string) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// bare /edit only looks up the message
+	lastMessage, ok := get_last_ai_chat_user_message(api)
+	if !ok || lastMessage != "First question" {
+		t.Fatalf("expected 'First question', got '%v' (%v)", lastMessage, ok)
+	}
+
+	// edit is canceled, so last turn must still exist
+	if len(api.Conversation) != 2 {
+		t.Fatalf("expected 2 messages after canceled edit, got %v", len(api.Conversation))
+	}
+
+	// sending fails, because there is no mock response left
+	err = send_edited_ai_chat_message(api, "Edited question", func(messageChunk string) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(api.Conversation) != 2 || api.Conversation[0].Content != "First question" {
+		t.Fatalf("expected restored last turn, got %v", api.Conversation)
+	}
+
+	// now the edited message replaces the last turn
+	api.Responses = []string{"Edited answer"}
+
+	err = send_edited_ai_chat_message(api, "Edited question", func(messageChunk string) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(api.Conversation) != 2 || api.Conversation[0].Content != "Edited question" || api.Conversation[1].Content != "Edited answer" {
+		t.Errorf("expected replaced last turn, got %v", api.Conversation)
+	}
+}
@@ -91,7 +91,7 @@ Your full name for the branch without your explanation:`, string(jsonStr)),
 						if response == "y" || response == "" {
 							break
 						} else if response == "n" {
-							os.Exit(3)
+							utils.Exit(3)
 							return
 						}
 					}
@@ -139,6 +139,7 @@ Your full name for the branch without your explanation:`, string(jsonStr)),
 				utils.RunCommand(p)
 			}
 		},
+		ValidArgsFunction: utils.CreateCompletionFunc(app.GetGitBranchesSafe),
 	}
 
 	checkoutCmd.Flags().BoolVarP(&suggest, "suggest", "s", false, "suggest name for new branch by AI")
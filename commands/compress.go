@@ -0,0 +1,152 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"strings"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+func Init_Compress_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var algorithm string
+	var format string
+	var level int
+	var stripPrefix string
+
+	var compressCmd = &cobra.Command{
+		Use:     "compress [files or directories]",
+		Aliases: []string{"cmp"},
+		Short:   "Compress data",
+		Long:    `Compresses data from STDIN and/or files to STDOUT or archives directories.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			hasDirectories := false
+			for _, a := range args {
+				isDir, err := utils.IsDirExisting(app.GetFullPathOrDefault(a, ""))
+				utils.CheckForError(err)
+
+				if isDir {
+					hasDirectories = true
+					break
+				}
+			}
+
+			if !hasDirectories {
+				compressor, err := utils.CreateCompressor(app.Out, algorithm, level)
+				utils.CheckForError(err)
+				defer compressor.Close()
+
+				written, err := app.WriteAllInputsTo(compressor, args...)
+				utils.CheckForError(err)
+
+				app.Debug(fmt.Sprintf("Bytes compressed: %v", written))
+				return
+			}
+
+			// collect files
+			var entries []utils.ArchiveEntry
+			for _, a := range args {
+				fullPath := app.GetFullPathOrDefault(a, "")
+
+				isDir, err := utils.IsDirExisting(fullPath)
+				utils.CheckForError(err)
+
+				if isDir {
+					dirEntries, err := utils.CollectArchiveEntries(fullPath, a, stripPrefix)
+					utils.CheckForError(err)
+
+					entries = append(entries, dirEntries...)
+				} else {
+					entries = append(entries, utils.ArchiveEntry{
+						FilePath: fullPath,
+						Name:     utils.ToArchiveEntryName(a, stripPrefix),
+					})
+				}
+			}
+
+			var addFile func(entry utils.ArchiveEntry) error
+			var closeArchive func() error
+
+			switch strings.TrimSpace(strings.ToLower(format)) {
+			case "", utils.ArchiveFormatTar:
+				compressor, err := utils.CreateCompressor(app.Out, algorithm, level)
+				utils.CheckForError(err)
+
+				tarWriter := tar.NewWriter(compressor)
+
+				addFile = func(entry utils.ArchiveEntry) error {
+					return utils.AddFileToTar(tarWriter, entry.FilePath, entry.Name)
+				}
+				closeArchive = func() error {
+					err := tarWriter.Close()
+					if err != nil {
+						return err
+					}
+
+					return compressor.Close()
+				}
+			case utils.ArchiveFormatZip:
+				if cmd.Flags().Changed("algo") || cmd.Flags().Changed("level") {
+					// zip entries are always compressed with deflate
+					utils.CloseWithError(fmt.Errorf("--algo and --level cannot be used with zip format"))
+				}
+
+				zipWriter := zip.NewWriter(app.Out)
+
+				addFile = func(entry utils.ArchiveEntry) error {
+					return utils.AddFileToZip(zipWriter, entry.FilePath, entry.Name)
+				}
+				closeArchive = zipWriter.Close
+			default:
+				utils.CloseWithError(fmt.Errorf("archive format '%v' is not supported", format))
+			}
+
+			for _, e := range entries {
+				app.Debug(fmt.Sprintf("Adding '%v' as '%v' ...", e.FilePath, e.Name))
+
+				err := addFile(e)
+				utils.CheckForError(err)
+			}
+
+			err := closeArchive()
+			utils.CheckForError(err)
+
+			app.Debug(fmt.Sprintf("Files archived: %v", len(entries)))
+		},
+	}
+
+	compressCmd.Flags().StringVarP(&algorithm, "algo", "", utils.CompressionAlgorithmGzip, "compression algorithm like gzip, xz, zlib or zstd")
+	compressCmd.Flags().StringVarP(&format, "format", "", utils.ArchiveFormatTar, "archive format for directories like tar or zip")
+	compressCmd.Flags().IntVarP(&level, "level", "", gzip.DefaultCompression, "compression level from 1 (fastest) to 9 (best)")
+	compressCmd.Flags().StringVarP(&stripPrefix, "strip-prefix", "", "", "prefix to remove from paths stored in archives")
+
+	parentCmd.AddCommand(
+		compressCmd,
+	)
+}
@@ -0,0 +1,118 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+func TestCompressParentDirectory(t *testing.T) {
+	rootDir := t.TempDir()
+
+	cwd := filepath.Join(rootDir, "work")
+	for _, name := range []string{"work/.keep", "src/a.txt", "src/sub/b.txt"} {
+		p := filepath.Join(rootDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(name), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runCompress := func(args ...string) []byte {
+		var out bytes.Buffer
+
+		app := &types.AppContext{
+			Cwd: cwd,
+			Out: &out,
+		}
+
+		rootCmd := &cobra.Command{Use: "gpm"}
+		Init_Compress_Command(rootCmd, app)
+
+		rootCmd.SetArgs(append([]string{"compress"}, args...))
+		err := rootCmd.Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return out.Bytes()
+	}
+
+	expected := []string{"src/a.txt", "src/sub/b.txt"}
+
+	// tar
+	gzipReader, err := gzip.NewReader(bytes.NewReader(runCompress("../src")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tarNames []string
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tarNames = append(tarNames, header.Name)
+	}
+	slices.Sort(tarNames)
+
+	if !slices.Equal(tarNames, expected) {
+		t.Errorf("tar: expected %v, got %v", expected, tarNames)
+	}
+
+	// zip
+	zipData := runCompress("--format=zip", "../src")
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var zipNames []string
+	for _, f := range zipReader.File {
+		zipNames = append(zipNames, f.Name)
+	}
+	slices.Sort(zipNames)
+
+	if !slices.Equal(zipNames, expected) {
+		t.Errorf("zip: expected %v, got %v", expected, zipNames)
+	}
+}
@@ -0,0 +1,188 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+// format_config_value() - formats a value of the settings file for the console
+func format_config_value(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}, map[string]interface{}:
+		jsonData, err := json.Marshal(v)
+		if err == nil {
+			return string(jsonData)
+		}
+	}
+
+	return fmt.Sprint(value)
+}
+
+// parse_config_value() - parses a value from the command line as YAML,
+// so `true` or `[a, b]` are handled as boolean or list
+func parse_config_value(value string) interface{} {
+	var parsedValue interface{}
+
+	err := yaml.Unmarshal([]byte(value), &parsedValue)
+	if err != nil || parsedValue == nil {
+		return value
+	}
+	if _, ok := parsedValue.(map[string]interface{}); ok {
+		return value // no objects
+	}
+
+	return parsedValue
+}
+
+func init_config_get_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var getCmd = &cobra.Command{
+		Use:   "get [key]",
+		Short: "Get setting",
+		Long:  `Outputs a value of the settings file by a dotted key like 'audit.ignore'.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			value, ok := utils.GetValueByDottedKey(app.SettingsFile.GetValues(), args[0])
+			if !ok {
+				utils.Exit(1)
+			}
+
+			fmt.Fprintln(app.Out, format_config_value(value))
+		},
+	}
+
+	parentCmd.AddCommand(
+		getCmd,
+	)
+}
+
+func init_config_list_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var outputJson bool
+
+	var listCmd = &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List settings",
+		Long:    `Lists all values of the settings file with dotted keys.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			values := utils.FlattenMapByDottedKeys(app.SettingsFile.GetValues())
+
+			if outputJson {
+				encoder := json.NewEncoder(app.Out)
+				encoder.SetIndent("", "  ")
+
+				err := encoder.Encode(values)
+				utils.CheckForError(err)
+
+				return
+			}
+
+			for _, key := range utils.GetSortedKeys(values) {
+				fmt.Fprintf(app.Out, "%v=%v%v", key, format_config_value(values[key]), fmt.Sprintln())
+			}
+		},
+	}
+
+	listCmd.Flags().BoolVarP(&outputJson, "json", "", false, "output as JSON")
+
+	parentCmd.AddCommand(
+		listCmd,
+	)
+}
+
+func init_config_set_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var setCmd = &cobra.Command{
+		Use:   "set [key] [value]",
+		Short: "Set setting",
+		Long:  `Sets a value in the settings file by a dotted key like 'audit.ignore'.`,
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			key := strings.TrimSpace(args[0])
+
+			err := utils.SetValueByDottedKey(app.SettingsFile.GetValues(), key, parse_config_value(args[1]))
+			utils.CheckForError(err)
+
+			err = app.SaveSettingsFile()
+			utils.CheckForError(err)
+		},
+	}
+
+	parentCmd.AddCommand(
+		setCmd,
+	)
+}
+
+func init_config_unset_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var unsetCmd = &cobra.Command{
+		Use:   "unset [key]",
+		Short: "Unset setting",
+		Long:  `Removes a value from the settings file by a dotted key like 'audit.ignore'.`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			key := strings.TrimSpace(args[0])
+
+			isRemoved := utils.UnsetValueByDottedKey(app.SettingsFile.GetValues(), key)
+			if !isRemoved {
+				utils.CloseWithError(fmt.Errorf("setting '%v' not found", key))
+			}
+
+			err := app.SaveSettingsFile()
+			utils.CheckForError(err)
+		},
+	}
+
+	parentCmd.AddCommand(
+		unsetCmd,
+	)
+}
+
+func Init_Config_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var configCmd = &cobra.Command{
+		Use:   "config [resource]",
+		Short: "Manage settings",
+		Long:  `Manages values of the settings.yaml file.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	init_config_get_command(configCmd, app)
+	init_config_list_command(configCmd, app)
+	init_config_set_command(configCmd, app)
+	init_config_unset_command(configCmd, app)
+
+	parentCmd.AddCommand(
+		configCmd,
+	)
+}
@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+func Init_Cron_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var count uint
+	var once bool
+	var preview uint
+	var timezone string
+
+	var cronCmd = &cobra.Command{
+		Use:     "cron [expression] [gpm arguments]",
+		Aliases: []string{"schedule"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if preview > 0 {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.MinimumNArgs(2)(cmd, args)
+		},
+		Short: "Run on schedule",
+		Long:  `Runs a gpm command, like 'run <script>', on a schedule defined by a cron expression with 5 or 6 fields.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			loc := time.Local
+			timezone = strings.TrimSpace(timezone)
+			if timezone != "" {
+				l, err := time.LoadLocation(timezone)
+				utils.CheckForError(err)
+
+				loc = l
+			}
+
+			schedule, err := utils.ParseCronExpression(args[0], loc)
+			utils.CheckForError(err)
+
+			if preview > 0 {
+				// only output next fire times
+
+				t := time.Now()
+				for i := uint(0); i < preview; i++ {
+					t = schedule.Next(t)
+					if t.IsZero() {
+						break
+					}
+
+					fmt.Println(t.In(loc).Format(time.RFC3339))
+				}
+				return
+			}
+
+			if once {
+				count = 1
+			}
+
+			selfPath, err := os.Executable()
+			utils.CheckForError(err)
+
+			gpmArgs := args[1:]
+			cmdToOutput := strings.Join(gpmArgs, " ")
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			var executions uint = 0
+			for count == 0 || executions < count {
+				nextRun := schedule.Next(time.Now())
+				if nextRun.IsZero() {
+					utils.CloseWithError(fmt.Errorf("cron expression '%v' will never match", args[0]))
+				}
+
+				app.Debug(fmt.Sprintf("Next run of '%v' at %v", cmdToOutput, nextRun.In(loc).Format(time.RFC3339)))
+
+				timer := time.NewTimer(time.Until(nextRun))
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					app.Info("Cron interrupted")
+					return
+				}
+
+				executions++
+
+				startTime := time.Now()
+				app.Info(fmt.Sprintf("Cron #%v %v: running '%v' ...", executions, startTime.In(loc).Format(time.RFC3339), cmdToOutput))
+
+				p := utils.CreateShellCommandByArgs(selfPath, gpmArgs...)
+				p.Dir = app.Cwd
+
+				exitCode, err := utils.RunCommandWithExitCode(p)
+				if err != nil {
+					app.Info(fmt.Sprintf("Cron #%v failed: %v", executions, err))
+				} else {
+					app.Info(fmt.Sprintf("Cron #%v finished with exit code %v after %v", executions, exitCode, time.Since(startTime).Round(time.Millisecond)))
+				}
+			}
+		},
+	}
+
+	cronCmd.Flags().UintVarP(&count, "count", "", 0, "stop after this number of executions")
+	cronCmd.Flags().BoolVarP(&once, "once", "", false, "wait for next scheduled time, run once and exit")
+	cronCmd.Flags().UintVarP(&preview, "preview", "", 0, "only output the next N fire times")
+	cronCmd.Flags().StringVarP(&timezone, "tz", "", "", "IANA time zone in which the expression is evaluated")
+
+	// everything after the expression belongs to the scheduled command
+	cronCmd.Flags().SetInterspersed(false)
+
+	parentCmd.AddCommand(
+		cronCmd,
+	)
+}
@@ -0,0 +1,179 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+// write_dependency_tree() - writes the tree of a module graph like `npm ls`,
+// where already shown subtrees are marked with `(*)`
+func write_dependency_tree(w io.Writer, graph *types.GoModuleGraph, maxDepth int, filter string) {
+	filter = strings.TrimSpace(strings.ToLower(filter))
+
+	// checks if a module has a path to a module, which matches `filter`,
+	// and returns the lowest depth of modules on the stack, which have been
+	// skipped because of a cycle
+	leadsToMatch := map[string]bool{}
+	var isOnMatchingPath func(module string, visiting map[string]int) (bool, int)
+	isOnMatchingPath = func(module string, visiting map[string]int) (bool, int) {
+		noCycle := math.MaxInt
+		if filter == "" {
+			return true, noCycle
+		}
+
+		result, ok := leadsToMatch[module]
+		if ok {
+			return result, noCycle
+		}
+		if cycleDepth, ok := visiting[module]; ok {
+			return false, cycleDepth // cycle
+		}
+
+		depth := len(visiting)
+		visiting[module] = depth
+		defer delete(visiting, module)
+
+		lowestCycleDepth := noCycle
+
+		name, _ := types.SplitGoModuleAndVersion(module)
+		result = strings.Contains(strings.ToLower(name), filter)
+		if !result {
+			for _, child := range graph.Children[module] {
+				childResult, childCycleDepth := isOnMatchingPath(child, visiting)
+				lowestCycleDepth = min(lowestCycleDepth, childCycleDepth)
+
+				if childResult {
+					result = true
+					break
+				}
+			}
+		}
+
+		// a negative result is incomplete, if it depends on
+		// a module, which is still on the stack
+		if result || lowestCycleDepth >= depth {
+			leadsToMatch[module] = result
+		}
+		return result, lowestCycleDepth
+	}
+
+	expanded := map[string]bool{}
+
+	var writeChildren func(module string, prefix string, depth int)
+	writeChildren = func(module string, prefix string, depth int) {
+		// children of children will be shown
+		canExpand := maxDepth <= 0 || depth < maxDepth
+
+		children := []string{}
+		for _, child := range graph.Children[module] {
+			if isMatching, _ := isOnMatchingPath(child, map[string]int{}); isMatching {
+				children = append(children, child)
+			}
+		}
+
+		for i, child := range children {
+			isLast := i == len(children)-1
+
+			branch := "├── "
+			childPrefix := prefix + "│   "
+			if isLast {
+				branch = "└── "
+				childPrefix = prefix + "    "
+			}
+
+			hasChildren := len(graph.Children[child]) > 0
+			if canExpand && expanded[child] && hasChildren {
+				fmt.Fprintf(w, "%v%v%v (*)%v", prefix, branch, child, fmt.Sprintln())
+				continue
+			}
+
+			fmt.Fprintf(w, "%v%v%v%v", prefix, branch, child, fmt.Sprintln())
+
+			if canExpand {
+				expanded[child] = true
+				writeChildren(child, childPrefix, depth+1)
+			}
+		}
+	}
+
+	fmt.Fprintln(w, graph.Root)
+	expanded[graph.Root] = true
+	writeChildren(graph.Root, "", 1)
+}
+
+func init_deps_tree_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var depth int
+
+	var treeCmd = &cobra.Command{
+		Use:   "tree [module filter]",
+		Short: "Show dependency tree",
+		Long:  `Shows the dependencies of the current project as tree, optionally only paths leading to a module.`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			filter := ""
+			if len(args) > 0 {
+				filter = args[0]
+			}
+
+			graph, err := app.GetGoModuleGraph()
+			utils.CheckForError(err)
+
+			if graph.Root == "" {
+				graph.Root = app.GetName()
+			}
+
+			write_dependency_tree(app.Out, graph, depth, filter)
+		},
+	}
+
+	treeCmd.Flags().IntVarP(&depth, "depth", "", 0, "maximum depth, 0 for unlimited")
+
+	parentCmd.AddCommand(
+		treeCmd,
+	)
+}
+
+func Init_Deps_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var depsCmd = &cobra.Command{
+		Use:   "deps [resource]",
+		Short: "Handle dependencies",
+		Long:  `Handles dependencies of the current project.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	init_deps_tree_command(depsCmd, app)
+
+	parentCmd.AddCommand(
+		depsCmd,
+	)
+}
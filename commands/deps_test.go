@@ -0,0 +1,90 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+)
+
+func TestWriteDependencyTree(t *testing.T) {
+	graph, err := types.ParseGoModuleGraph(strings.Join([]string{
+		"example.com/root example.com/a@v1.0.0",
+		"example.com/root example.com/b@v1.0.0",
+		"example.com/a@v1.0.0 example.com/c@v1.0.0",
+		"example.com/b@v1.0.0 example.com/c@v1.0.0",
+	}, "\n"))
+	if err != nil {
+		t.Fatalf("failed to parse graph: %v", err)
+	}
+
+	var buf bytes.Buffer
+	write_dependency_tree(&buf, graph, 0, "")
+
+	expected := strings.Join([]string{
+		"example.com/root",
+		"├── example.com/a@v1.0.0",
+		"│   └── example.com/c@v1.0.0",
+		"└── example.com/b@v1.0.0",
+		"    └── example.com/c@v1.0.0",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestWriteDependencyTreeWithFilterAndCycle(t *testing.T) {
+	// "a" is checked first and its path through "b" back to "a"
+	// is cut, but "b" still reaches "target" through "a"
+	graph, err := types.ParseGoModuleGraph(strings.Join([]string{
+		"example.com/root example.com/a@v1.0.0",
+		"example.com/root example.com/b@v1.0.0",
+		"example.com/a@v1.0.0 example.com/b@v1.0.0",
+		"example.com/a@v1.0.0 example.com/target@v1.0.0",
+		"example.com/b@v1.0.0 example.com/a@v1.0.0",
+		"example.com/root example.com/other@v1.0.0",
+	}, "\n"))
+	if err != nil {
+		t.Fatalf("failed to parse graph: %v", err)
+	}
+
+	var buf bytes.Buffer
+	write_dependency_tree(&buf, graph, 0, "Target")
+
+	output := buf.String()
+	for _, line := range []string{
+		"├── example.com/a@v1.0.0\n",
+		"├── example.com/b@v1.0.0",
+	} {
+		if !strings.Contains(output, line) {
+			t.Errorf("expected line %q in output\n%s", line, output)
+		}
+	}
+	if strings.Contains(output, "example.com/other") {
+		t.Errorf("unexpected non-matching module in output\n%s", output)
+	}
+}
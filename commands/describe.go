@@ -23,6 +23,7 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -38,11 +39,19 @@ import (
 )
 
 func Init_Describe_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var checksum string
 	var customLanguage string
 	var customMessage string
+	var headers []string
+	var multi bool
 	var prettyOutput bool
+	var progress bool
+	var rawOutput bool
 	var simple bool
+	var stripMetadata bool
 	var temperature float32
+	var token string
+	var userAgent string
 	var yamlOutput bool
 
 	var describeCmd = &cobra.Command{
@@ -51,16 +60,56 @@ func Init_Describe_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		Short:   "Describe data",
 		Long:    `Describes the data, like images, with AI.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			allInputs, err := app.ReadAllInputs(args...)
-			utils.CheckForError(err)
+			// checksum is verified for all inputs at once
+			setup_download_options(app, "", headers, progress, token, userAgent)
+
+			var inputs [][]byte
+			if multi {
+				// each argument is an own image
+				if len(args) == 0 {
+					utils.CloseWithError(fmt.Errorf("no images defined"))
+				}
+
+				for _, a := range args {
+					data, err := app.LoadDataFrom(a)
+					utils.CheckForError(err)
+
+					inputs = append(inputs, data)
+				}
+			} else {
+				allInputs, err := app.ReadAllInputs(args...)
+				utils.CheckForError(err)
+
+				inputs = append(inputs, allInputs)
+			}
+
+			if strings.TrimSpace(checksum) != "" {
+				err := utils.VerifySHA256(bytes.Join(inputs, []byte{}), checksum)
+				utils.CheckForError(err)
+			}
 
 			consoleFormatter := utils.GetBestChromaFormatterName()
 			consoleStyle := utils.GetBestChromaStyleName()
 
-			contentType := strings.ToLower(http.DetectContentType(allInputs))
-			if !strings.HasPrefix(contentType, "image/") {
-				// current only images are supported
-				utils.CheckForError(fmt.Errorf("content type %s is not supported", contentType))
+			if stripMetadata {
+				for i, input := range inputs {
+					strippedInput, removedBytes, err := utils.StripImageMetadata(input)
+					utils.CheckForError(err)
+
+					app.Debug(fmt.Sprintf("Removed %v bytes of metadata from image #%v", removedBytes, i+1))
+					inputs[i] = strippedInput
+				}
+			}
+
+			var contentTypes []string
+			for _, input := range inputs {
+				contentType := strings.ToLower(http.DetectContentType(input))
+				if !strings.HasPrefix(contentType, "image/") {
+					// current only images are supported
+					utils.CheckForError(fmt.Errorf("content type %s is not supported", contentType))
+				}
+
+				contentTypes = append(contentTypes, contentType)
 			}
 
 			systemPrompt := ""
@@ -76,6 +125,9 @@ func Init_Describe_Command(parentCmd *cobra.Command, app *types.AppContext) {
 			utils.CheckForError(err)
 
 			model := strings.TrimSpace(app.Model)
+			if model == "" {
+				model = app.GetDefaultAIChatModel(api.GetProvider())
+			}
 			if model == "" {
 				app.Debug("Setting up default model ...")
 
@@ -104,37 +156,60 @@ func Init_Describe_Command(parentCmd *cobra.Command, app *types.AppContext) {
 
 			message := strings.TrimSpace(customMessage)
 			if message == "" {
-				message = fmt.Sprintf("Describe what is in the image and answer in %v", language)
+				if multi {
+					message = fmt.Sprintf("Describe what is in each image individually and answer in %v", language)
+				} else {
+					message = fmt.Sprintf("Describe what is in the image and answer in %v", language)
+				}
 			}
 
 			app.Debug(fmt.Sprintf("Provider: %s", api.GetProvider()))
 			app.Debug(fmt.Sprintf("Model: %s", api.GetModel()))
 			app.Debug(fmt.Sprintf("Temperature: %v", currentTemperature))
 			app.Debug(fmt.Sprintf("Message: %v", message))
-			app.Debug(fmt.Sprintf("Content type: %v", contentType))
+			app.Debug(fmt.Sprintf("Content types: %v", strings.Join(contentTypes, ", ")))
+
+			var dataURIs []string
+			for i, input := range inputs {
+				dataURIs = append(
+					dataURIs,
+					fmt.Sprintf("data:%s;base64,%s", contentTypes[i], base64.StdEncoding.EncodeToString(input)),
+				)
+			}
 
-			var base64InputData strings.Builder
-			encoder := base64.NewEncoder(base64.StdEncoding, &base64InputData)
-			encoder.Write(allInputs)
-			utils.CheckForError(err)
+			var imageDescription interface{}
+			var rawDescription string
+			if multi {
+				imageDescriptions, err := api.DescribeImages(message, dataURIs)
+				utils.CheckForError(err)
 
-			dataURI := fmt.Sprintf("data:%s;base64,%s", contentType, base64InputData.String())
+				if len(imageDescriptions) > 0 {
+					rawDescription = imageDescriptions[0].Raw
+				}
+				imageDescription = imageDescriptions
+			} else {
+				singleImageDescription, err := api.DescribeImage(message, dataURIs[0])
+				utils.CheckForError(err)
 
-			imageDescription, err := api.DescribeImage(message, dataURI)
-			utils.CheckForError(err)
+				rawDescription = singleImageDescription.Raw
+				imageDescription = singleImageDescription
+			}
 
 			outputData := func(data []byte, syntax string) {
 				if prettyOutput {
 					err = quick.Highlight(app.Out, string(data), syntax, consoleFormatter, consoleStyle)
 					if err != nil {
-						fmt.Print(string(data))
+						fmt.Fprint(app.Out, string(data))
 					}
 				} else {
-					fmt.Print(string(data))
+					fmt.Fprint(app.Out, string(data))
 				}
 			}
 
-			if yamlOutput {
+			if rawOutput {
+				// exactly as returned by model
+				fmt.Fprint(app.Out, rawDescription)
+			} else if yamlOutput {
 				yamlData, err := yaml.Marshal(&imageDescription)
 				utils.CheckForError(err)
 
@@ -155,11 +230,19 @@ func Init_Describe_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		},
 	}
 
+	describeCmd.Flags().StringVarP(&checksum, "checksum", "", "", "expected SHA256 checksum of all input data")
+	describeCmd.Flags().StringArrayVarP(&headers, "header", "H", []string{}, "one or more custom HTTP headers for downloads, like 'Name: value'")
 	describeCmd.Flags().StringVarP(&customLanguage, "language", "", "", "custom response language")
 	describeCmd.Flags().StringVarP(&customMessage, "message", "", "", "custom AI model")
+	describeCmd.Flags().BoolVarP(&multi, "multi", "", false, "describe each file as an own image in one request")
 	describeCmd.Flags().BoolVarP(&prettyOutput, "pretty", "", false, "pretty output")
+	describeCmd.Flags().BoolVarP(&progress, "progress", "", false, "show progress of downloads")
+	describeCmd.Flags().BoolVarP(&rawOutput, "raw", "", false, "output raw JSON response of AI model")
 	describeCmd.Flags().BoolVarP(&simple, "simple", "", simple, "use simple language")
-	describeCmd.Flags().Float32VarP(&temperature, "temperature", "", utils.GetAIChatTemperature(0.3), "custom temperature value")
+	describeCmd.Flags().BoolVarP(&stripMetadata, "strip-metadata", "", false, "remove metadata like EXIF and GPS from JPEG and PNG images before sending them")
+	describeCmd.Flags().Float32VarP(&temperature, "temperature", "", app.GetAIChatTemperature(0.3), "custom temperature value")
+	describeCmd.Flags().StringVarP(&token, "token", "", "", "bearer token for downloads (default from GPM_DOWNLOAD_TOKEN)")
+	describeCmd.Flags().StringVarP(&userAgent, "user-agent", "", "", "custom user agent for downloads")
 	describeCmd.Flags().BoolVarP(&yamlOutput, "yaml", "", false, "use YAML instead of JSON")
 
 	parentCmd.AddCommand(
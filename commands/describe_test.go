@@ -0,0 +1,108 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+func TestDescribeCommandWithMockAI(t *testing.T) {
+	t.Setenv("GPM_AI_CHAT_MODEL", "")
+	t.Setenv("GPM_AI_CHAT_TEMPERATURE", "")
+
+	dir := t.TempDir()
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	var imageData bytes.Buffer
+	err := png.Encode(&imageData, img)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imageFile := filepath.Join(dir, "image.png")
+	err = os.WriteFile(imageFile, imageData.Bytes(), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockResponse := `{"aria_attributes":{"aria_description":"A red pixel.","aria_label":"Red pixel"}}`
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{}, `{"description":"A red pixel.","label":"Red pixel"}`},
+		{[]string{"--raw"}, mockResponse},
+		{[]string{"--yaml"}, "description: A red pixel.\nlabel: Red pixel\n"},
+	}
+
+	for _, test := range tests {
+		set_test_stdin(t, nil)
+
+		mock := &types.MockAIChat{
+			Responses: []string{mockResponse},
+		}
+
+		var out bytes.Buffer
+
+		app := &types.AppContext{
+			AIChatFactory: func(options ...types.CreateAIChatOptions) (types.ChatAI, error) {
+				return mock, nil
+			},
+			Cwd: dir,
+			Out: &out,
+		}
+
+		rootCmd := &cobra.Command{Use: "gpm"}
+		Init_Describe_Command(rootCmd, app)
+
+		rootCmd.SetArgs(append([]string{"describe", imageFile, "--temperature=0.5", "--language=german"}, test.args...))
+		err := rootCmd.Execute()
+		if err != nil {
+			t.Fatalf("%v: %v", test.args, err)
+		}
+
+		if out.String() != test.expected {
+			t.Errorf("%v: expected %q, got %q", test.args, test.expected, out.String())
+		}
+
+		if len(mock.Requests) != 1 || !strings.Contains(mock.Requests[0], "answer in german") {
+			t.Errorf("%v: unexpected requests %v", test.args, mock.Requests)
+		}
+		if mock.Temperature != 0.5 {
+			t.Errorf("%v: expected temperature 0.5, got %v", test.args, mock.Temperature)
+		}
+	}
+}
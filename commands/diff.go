@@ -24,10 +24,13 @@ package commands
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"strings"
 
 	"github.com/alecthomas/chroma/quick"
+	"github.com/charmbracelet/glamour"
+	"github.com/fatih/color"
 	"github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
 
@@ -35,44 +38,177 @@ import (
 	"github.com/mkloubert/go-package-manager/utils"
 )
 
+const diffAISummaryMapPrompt = `Summarize the following part of a git diff in a human readable way.
+Describe what has been changed and why it might matter.
+Only output the summary as Markdown without any introduction.
+
+Diff:
+` + types.AISummarizerTextPlaceholder
+
+const diffAISummaryReducePrompt = `The following text contains partial summaries of a larger git diff.
+Combine them to one coherent Markdown summary of what has been changed and why it might matter.
+Do not repeat information and only output the summary without any introduction.
+
+Partial summaries:
+` + types.AISummarizerTextPlaceholder
+
+// get_diff_git_ref() - returns a git reference from a command line argument,
+// where versions like `1.2.3` are mapped to tags like `v1.2.3`
+func get_diff_git_ref(arg string) string {
+	ref := strings.TrimSpace(arg)
+
+	v, err := version.NewVersion(ref)
+	if err == nil {
+		return "v" + v.String()
+	}
+
+	return ref
+}
+
+// summarize_diff_with_ai() - summarizes a git diff with the help of an AI chat
+func summarize_diff_with_ai(app *types.AppContext, diff string, temperature float32) (string, error) {
+	systemPrompt := ""
+	if !app.NoSystemPrompt {
+		systemPrompt = app.GetSystemAIPrompt("You are a helpful assistant who explains code changes to software developers.")
+	}
+
+	aiChat, err := app.CreateAIChat()
+	if err != nil {
+		return "", err
+	}
+
+	aiChat.UpdateSystem(systemPrompt)
+	aiChat.UpdateTemperature(temperature)
+
+	app.Debug(fmt.Sprintf("Provider: %v", aiChat.GetProvider()))
+	app.Debug(fmt.Sprintf("Model: %v", aiChat.GetModel()))
+	app.Debug(fmt.Sprintf("Temperature: %v", temperature))
+
+	s := app.NewSpinner()
+	s.Suffix = " Summarizing changes ..."
+	s.Start()
+	defer s.Stop()
+
+	mapPrompt := diffAISummaryMapPrompt
+	reducePrompt := diffAISummaryReducePrompt
+
+	return app.NewAISummarizer(aiChat).Summarize(diff, types.AISummarizeOptions{
+		MapPrompt: &mapPrompt,
+		OnProgress: func(phase string, i, n int) {
+			s.Suffix = fmt.Sprintf(" Summarizing changes (%v %v/%v) ...", phase, i, n)
+		},
+		ReducePrompt: &reducePrompt,
+	})
+}
+
 func Init_Diff_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var maxSize int
+	var noColor bool
+	var plain bool
+	var prettyOutput bool
+	var summarize bool
+	var temperature float32
+
 	var diffCmd = &cobra.Command{
-		Use:     "diff [resource]",
+		Use:     "diff [ref1|file1] [ref2|file2]",
 		Aliases: []string{"df"},
 		Short:   "Diff resources",
-		Long:    `Compares two resources.`,
+		Long:    `Compares two resources, like git references, versions or files.`,
+		Args:    cobra.RangeArgs(1, 2),
 		Run: func(cmd *cobra.Command, args []string) {
+			if plain && summarize {
+				utils.CloseWithError(fmt.Errorf("--plain and --ai cannot be used together"))
+			}
+
 			consoleFormatter := utils.GetBestChromaFormatterName()
 			consoleStyle := utils.GetBestChromaStyleName()
 
-			version1, err := version.NewVersion(strings.TrimSpace(args[0]))
-			utils.CheckForError(err)
+			outputDiff := func(diff string) {
+				if noColor || color.NoColor {
+					fmt.Fprint(app.Out, diff)
+					return
+				}
+
+				err := quick.Highlight(app.Out, diff, "diff", consoleFormatter, consoleStyle)
+				if err != nil {
+					fmt.Fprint(app.Out, diff)
+				}
+			}
 
-			tag1 := "v" + version1.String()
-			var tag2 string
+			if plain && len(args) == 2 {
+				file1 := app.GetFullPathOrDefault(args[0], "")
+				file2 := app.GetFullPathOrDefault(args[1], "")
 
-			if len(args) == 1 {
-				tag2 = "HEAD"
-			} else {
-				version2, err := version.NewVersion(strings.TrimSpace(args[1]))
-				utils.CheckForError(err)
+				isFile1, _ := utils.IsFileExisting(file1)
+				isFile2, _ := utils.IsFileExisting(file2)
+				if isFile1 && isFile2 {
+					// compare local files without git
+					app.Debug(fmt.Sprintf("Comparing files '%v' and '%v' ...", file1, file2))
 
-				tag2 = "v" + version2.String()
+					data1, err := os.ReadFile(file1)
+					utils.CheckForError(err)
+					data2, err := os.ReadFile(file2)
+					utils.CheckForError(err)
+
+					diff, err := utils.UnifiedDiff(args[0], string(data1), args[1], string(data2))
+					utils.CheckForError(err)
+
+					outputDiff(diff)
+					return
+				}
 			}
 
-			p := exec.Command("git", "diff", tag1, tag2)
+			ref1 := get_diff_git_ref(args[0])
+			ref2 := "HEAD"
+			if len(args) > 1 {
+				ref2 = get_diff_git_ref(args[1])
+			}
+
+			p := exec.Command("git", "diff", ref1, ref2)
 			p.Dir = app.Cwd
 
 			diff, err := p.Output()
 			utils.CheckForError(err)
 
-			err = quick.Highlight(app.Out, string(diff), "diff", consoleFormatter, consoleStyle)
-			if err != nil {
-				fmt.Print(string(diff))
+			if !summarize {
+				outputDiff(string(diff))
+				return
+			}
+
+			diffText := string(diff)
+			if strings.TrimSpace(diffText) == "" {
+				fmt.Fprintf(app.Out, "No changes between '%v' and '%v'%v", ref1, ref2, fmt.Sprintln())
+				return
+			}
+
+			if maxSize > 0 && len(diffText) > maxSize {
+				app.Warn(fmt.Sprintf("Diff has %v characters and will be truncated to %v for the summary", len(diffText), maxSize))
+
+				diffText = diffText[:maxSize]
+			}
+
+			summary, err := summarize_diff_with_ai(app, diffText, temperature)
+			utils.CheckForError(err)
+
+			if prettyOutput {
+				out, err := glamour.Render(summary, "dark")
+				if err == nil {
+					summary = out
+				}
 			}
+
+			fmt.Fprint(app.Out, summary)
+			fmt.Fprintln(app.Out)
 		},
 	}
 
+	diffCmd.Flags().BoolVarP(&summarize, "ai", "", false, "summarize changes with AI")
+	diffCmd.Flags().IntVarP(&maxSize, "max-size", "", 200000, "maximum number of characters of a diff, which are summarized by AI")
+	diffCmd.Flags().BoolVarP(&noColor, "no-color", "", false, "do not highlight output")
+	diffCmd.Flags().BoolVarP(&plain, "plain", "", false, "show unified diff without AI, which can also compare two local files")
+	diffCmd.Flags().BoolVarP(&prettyOutput, "pretty", "", false, "pretty output of AI summary")
+	diffCmd.Flags().Float32VarP(&temperature, "temperature", "", app.GetAIChatTemperature(0.3), "custom temperature value")
+
 	parentCmd.AddCommand(
 		diffCmd,
 	)
@@ -0,0 +1,104 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+func TestDiffTemperatureFlag(t *testing.T) {
+	t.Setenv("GPM_AI_CHAT_TEMPERATURE", "")
+
+	settingsTemperature := float32(0.7)
+
+	app := &types.AppContext{}
+	app.SettingsFile.AI.Temperature = &settingsTemperature
+
+	rootCmd := &cobra.Command{Use: "gpm"}
+	Init_Diff_Command(rootCmd, app)
+
+	diffCmd, _, err := rootCmd.Find([]string{"diff"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// settings file is the default of the flag
+	temperature, err := diffCmd.Flags().GetFloat32("temperature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if temperature != 0.7 {
+		t.Errorf("expected value 0.7 from settings, got %v", temperature)
+	}
+
+	// flag before settings file
+	err = diffCmd.Flags().Parse([]string{"--temperature=0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	temperature, err = diffCmd.Flags().GetFloat32("temperature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if temperature != 0.1 {
+		t.Errorf("expected value 0.1 from flag, got %v", temperature)
+	}
+}
+
+func TestDiffPlainWritesToAppOut(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{"a.txt": "a\nb\n", "b.txt": "a\nc\n"} {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	app := &types.AppContext{
+		Cwd: dir,
+		Out: &out,
+	}
+
+	rootCmd := &cobra.Command{Use: "gpm"}
+	Init_Diff_Command(rootCmd, app)
+
+	rootCmd.SetArgs([]string{"diff", "--plain", "--no-color", "a.txt", "b.txt"})
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "--- a.txt\n+++ b.txt\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n"
+	if out.String() != expected {
+		t.Errorf("expected %q, got %q", expected, out.String())
+	}
+}
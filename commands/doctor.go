@@ -23,6 +23,7 @@
 package commands
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -34,7 +35,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
 	"github.com/hashicorp/go-version"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -65,7 +65,149 @@ type GoProxyModuleInfo struct {
 	Version string `json:"Version,omitempty"`
 }
 
+type doctorOutdatedModule struct {
+	latestVersion string
+	path          string
+}
+
+// check_reachable_vulnerabilities() - runs `govulncheck`, if installed, and
+// outputs the issues, which are reachable from code of the current project
+func check_reachable_vulnerabilities(app *types.AppContext, knownIds map[string]bool) {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	_, err := exec.LookPath("govulncheck")
+	if err != nil {
+		fmt.Printf("\t[%s] 'govulncheck' not found, install it with 'go install golang.org/x/vuln/cmd/govulncheck@latest'%s", yellow("⚠️"), fmt.Sprintln())
+		return
+	}
+
+	stopGovulncheckTiming := app.StartTiming("doctor: govulncheck")
+	defer stopGovulncheckTiming()
+
+	s := app.NewSpinner()
+	s.Prefix = "\t["
+	s.Suffix = "] Running 'govulncheck' ..."
+	s.Start()
+
+	result, err := run_govulncheck(app)
+
+	s.Stop()
+
+	if err != nil {
+		fmt.Printf("\t[%s] %s%s", red("!"), err.Error(), fmt.Sprintln())
+		return
+	}
+
+	reachableIds := result.GetReachableIds()
+	sort.Strings(reachableIds)
+
+	if len(reachableIds) == 0 {
+		fmt.Printf("\t[%s] No reachable security issues found%s", green("✓"), fmt.Sprintln())
+		return
+	}
+
+	for _, id := range reachableIds {
+		fmt.Printf("\t[%s] [REACHABLE] %s%s", red("!"), format_reachable_vulnerability(result, id, knownIds), fmt.Sprintln())
+	}
+}
+
+// check_bin_folder_in_path() - outputs if the bin folder, where `make`
+// installs executables, is part of the `PATH`
+func check_bin_folder_in_path(app *types.AppContext) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	binPath, err := app.GetBinFolderPath()
+	if err != nil {
+		fmt.Printf("\t[%s] Could not detect bin folder: %s%s", yellow("⚠️"), err.Error(), fmt.Sprintln())
+		return
+	}
+
+	if utils.IsInPathEnv(binPath) {
+		fmt.Printf("\t[%s] '%s' is part of PATH%s", green("✓"), binPath, fmt.Sprintln())
+	} else {
+		fmt.Printf("\t[%s] '%s' is not part of PATH, run 'gpm setup path' to add it%s", yellow("⚠️"), binPath, fmt.Sprintln())
+	}
+}
+
+// check_git_working_tree() - outputs if the git working tree of the current
+// project is clean and in sync with its upstream
+func check_git_working_tree(app *types.AppContext) {
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	status, err := app.GetGitStatus()
+	if err != nil {
+		fmt.Printf("\t[%s] No Git repository found%s", yellow("⚠️"), fmt.Sprintln())
+		return
+	}
+
+	if status.IsClean() {
+		fmt.Printf("\t[%s] Working tree is clean%s", green("✓"), fmt.Sprintln())
+	} else {
+		uncommittedCount := status.CountUncommitted()
+		if uncommittedCount > 0 {
+			fmt.Printf("\t[%s] %v file(s) with uncommitted changes%s", yellow("⚠️"), uncommittedCount, fmt.Sprintln())
+		}
+
+		untrackedCount := status.CountUntracked()
+		if untrackedCount > 0 {
+			fmt.Printf("\t[%s] %v untracked file(s)%s", yellow("⚠️"), untrackedCount, fmt.Sprintln())
+		}
+	}
+
+	branch, err := app.GetCurrentGitBranch()
+	if err != nil {
+		fmt.Printf("\t[%s] No current branch, HEAD is detached%s", yellow("⚠️"), fmt.Sprintln())
+		return
+	}
+
+	ahead, behind, err := app.GetGitAheadBehind()
+	if err != nil {
+		fmt.Printf("\t[%s] Branch '%s' has no upstream%s", yellow("⚠️"), branch, fmt.Sprintln())
+		return
+	}
+
+	if ahead == 0 && behind == 0 {
+		fmt.Printf("\t[%s] Branch '%s' is up-to-date with its upstream%s", green("✓"), branch, fmt.Sprintln())
+		return
+	}
+	if ahead > 0 {
+		fmt.Printf("\t[%s] Branch '%s' is %v commit(s) ahead of its upstream%s", yellow("⚠️"), branch, ahead, fmt.Sprintln())
+	}
+	if behind > 0 {
+		fmt.Printf("\t[%s] Branch '%s' is %v commit(s) behind its upstream%s", yellow("⚠️"), branch, behind, fmt.Sprintln())
+	}
+}
+
+// confirm_doctor_fix() - asks the user if a fix should be applied
+func confirm_doctor_fix(reader *bufio.Reader, question string) bool {
+	for {
+		fmt.Printf("\t%v (Y/n)? ", question)
+		userInput, err := reader.ReadString('\n')
+		if err != nil && userInput == "" {
+			fmt.Println()
+			return false
+		}
+
+		switch strings.TrimSpace(strings.ToLower(userInput)) {
+		case "", "y", "yes":
+			return true
+		case "n", "no":
+			return false
+		}
+	}
+}
+
 func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var fix bool
+	var fixUpdates bool
+	var useGovulncheck bool
+	var maxAge int
+	var yes bool
+
 	var doctorCmd = &cobra.Command{
 		Use:   "doctor",
 		Short: "Checks preconditions and audits",
@@ -76,6 +218,13 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 			tHeadColor := color.New(color.FgWhite, color.Bold).SprintFunc()
 			yellow := color.New(color.FgYellow).SprintFunc()
 
+			// findings which can be fixed by --fix
+			outdatedModules := []doctorOutdatedModule{}
+			unusedModules := []string{}
+
+			// IDs and aliases of issues found by osv.dev
+			knownVulnerabilityIds := map[string]bool{}
+
 			goModFile := app.GetFullPathOrDefault("go.mod", "")
 			if goModFile != "" {
 				doesGoModFileExist, err := utils.IsFileExisting(goModFile)
@@ -85,7 +234,9 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 
 						fmt.Println("Checking go.mod file ...")
 
-						s := spinner.New(spinner.CharSets[24], 100*time.Millisecond)
+						stopGoModTiming := app.StartTiming("doctor: go.mod check")
+
+						s := app.NewSpinner()
 						s.Prefix = "\t["
 						s.Suffix = "] Validating file ..."
 						s.Start()
@@ -98,6 +249,7 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 						output, err := p.Output()
 
 						s.Stop()
+						stopGoModTiming()
 
 						if err == nil {
 							var goMod GoModFile
@@ -127,15 +279,16 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 
 								if len(allItems) > 0 {
 									fmt.Println("Checking dependencies for up-to-dateness ...")
+									stopUpToDateTiming := app.StartTiming("doctor: up-to-dateness")
 									for i, item := range allItems {
-										s := spinner.New(spinner.CharSets[24], 100*time.Millisecond)
+										s := app.NewSpinner()
 										s.Prefix = "\t["
 										s.Suffix = fmt.Sprintf("] Checking '%s' (%v/%v) ...", item.Path, i+1, len(allItems))
 										s.Start()
 
 										thisVersion, err := version.NewVersion(strings.TrimSpace(item.Version))
 										if err == nil {
-											url := fmt.Sprintf("https://proxy.golang.org/%s/@latest", strings.ToLower(item.Path))
+											url := fmt.Sprintf("https://proxy.golang.org/%s/@latest", to_go_proxy_path(item.Path))
 											req, err := http.NewRequest("GET", url, bytes.NewBuffer([]byte{}))
 											if err == nil {
 												client := &http.Client{}
@@ -150,14 +303,36 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 															err := json.Unmarshal(responseData, &infoFromProxy)
 
 															if err == nil {
-																otherVersion, err := version.NewVersion(strings.TrimSpace(item.Version))
+																otherVersion, err := version.NewVersion(strings.TrimSpace(infoFromProxy.Version))
 																if err == nil {
+																	// age of installed version of direct dependencies
+																	var publishedAt *time.Time
+																	if item.Indirect == nil || !*item.Indirect {
+																		publishedAt = get_go_module_version_time(item.Path, item.Version)
+																	}
+
 																	s.Stop()
 
+																	ageInfo := ""
+																	if publishedAt != nil {
+																		ageInfo = fmt.Sprintf(" (%s old)", format_module_age(time.Since(*publishedAt)))
+																	}
+
 																	if otherVersion.LessThanOrEqual(thisVersion) {
-																		fmt.Printf("\t[%s] '%s' is up-to-date%s", green("✓"), item.Path, fmt.Sprintln())
+																		fmt.Printf("\t[%s] '%s' is up-to-date%s%s", green("✓"), item.Path, ageInfo, fmt.Sprintln())
 																	} else {
-																		fmt.Printf("\t[%s] '%s' is outdated: %s < %s%s", yellow("⚠️"), item.Path, thisVersion.String(), otherVersion.String(), fmt.Sprintln())
+																		fmt.Printf("\t[%s] '%s' is outdated: %s < %s%s%s", yellow("⚠️"), item.Path, thisVersion.String(), otherVersion.String(), ageInfo, fmt.Sprintln())
+
+																		if item.Indirect == nil || !*item.Indirect {
+																			outdatedModules = append(outdatedModules, doctorOutdatedModule{
+																				latestVersion: strings.TrimSpace(infoFromProxy.Version),
+																				path:          item.Path,
+																			})
+																		}
+																	}
+
+																	if publishedAt != nil && maxAge > 0 && time.Since(*publishedAt) > time.Duration(maxAge)*24*time.Hour {
+																		fmt.Printf("\t[%s] '%s' %s was published more than %v days ago%s", yellow("⚠️"), item.Path, thisVersion.String(), maxAge, fmt.Sprintln())
 																	}
 																} else {
 																	s.Stop()
@@ -196,11 +371,13 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 											fmt.Printf("\t[%s] Version of '%s' is invalid: %s%s", red("!"), item.Path, err.Error(), fmt.Sprintln())
 										}
 									}
+									stopUpToDateTiming()
 									fmt.Println()
 
 									fmt.Println("Checking for unsed dependencies ...")
+									stopUnusedTiming := app.StartTiming("doctor: unused dependencies")
 									for i, item := range allItems {
-										s := spinner.New(spinner.CharSets[24], 100*time.Millisecond)
+										s := app.NewSpinner()
 										s.Prefix = "\t["
 										s.Suffix = fmt.Sprintf("] Checking '%s' (%v/%v) ...", item.Path, i+1, len(allItems))
 										s.Start()
@@ -218,6 +395,8 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 											strOutput := string(output)
 											if strings.Contains(strOutput, fmt.Sprintf("module does not need module %s)", item.Path)) {
 												fmt.Printf("\t[%s] Module '%s' is not used, run 'gpm uninstall %s' or a single 'gpm tidy' to fix this%s", red("!"), item.Path, item.Path, fmt.Sprintln())
+
+												unusedModules = append(unusedModules, item.Path)
 											} else {
 												fmt.Printf("\t[%s] '%s' has no known issues%s", green("✓"), item.Path, fmt.Sprintln())
 											}
@@ -225,179 +404,142 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 											fmt.Printf("\t[%s] Check failed for '%s':%s%s", red("!"), item.Path, err.Error(), fmt.Sprintln())
 										}
 									}
+									stopUnusedTiming()
 									fmt.Println()
 
 									fmt.Println("Checking all dependencies for security issues ...")
+									stopSecurityTiming := app.StartTiming("doctor: security issues")
 									for i, item := range allItems {
-										s := spinner.New(spinner.CharSets[24], 100*time.Millisecond)
+										s := app.NewSpinner()
 										s.Prefix = "\t["
 										s.Suffix = fmt.Sprintf("] Checking '%s' (%v/%v) ...", item.Path, i+1, len(allItems))
 										s.Start()
 
-										url := "https://api.osv.dev/v1/query"
-										body := map[string]interface{}{
-											"version": item.Version,
-											"package": map[string]interface{}{
-												"name":      item.Path,
-												"ecosystem": "Go",
-											},
-										}
-
-										jsonData, err := json.Marshal(&body)
+										osvResponse, err := query_osv_dev(item.Path, item.Version)
 										if err == nil {
-											req, err := http.NewRequest("POST", url, bytes.NewBuffer([]byte(jsonData)))
-											if err == nil {
-												req.Header.Set("Content-Type", "application/json")
-												// ... and finally send the JSON data
-												client := &http.Client{}
-												resp, err := client.Do(req)
-												if err == nil {
-													defer resp.Body.Close()
-
-													if resp.StatusCode == 200 {
-														responseData, err := io.ReadAll(resp.Body)
-														if err == nil {
-															var osvResponse types.OsvDevResponse
-															err = json.Unmarshal(responseData, &osvResponse)
-															if err == nil {
-																reportNoIssues := func() {
-																	s.Stop()
-
-																	fmt.Printf("\t[%s] '%s' has no known issues%s", green("✓"), item.Path, fmt.Sprintln())
-																}
-
-																if osvResponse.Vulnerabilities != nil {
-																	vulnerabilities := []types.OsvDevResponseVulnerabilityItem{}
-																	vulnerabilities = append(vulnerabilities, *osvResponse.Vulnerabilities...)
-																	vulnerabilitiesCount := len(vulnerabilities)
-
-																	if vulnerabilitiesCount > 0 {
-																		s.Stop()
+											reportNoIssues := func() {
+												s.Stop()
 
-																		fmt.Printf("\t[%s] Found %v known security issues in '%s':%s", red("!"), vulnerabilitiesCount, url, fmt.Sprintln())
+												fmt.Printf("\t[%s] '%s' has no known issues%s", green("✓"), item.Path, fmt.Sprintln())
+											}
 
-																		sort.Slice(vulnerabilities, func(x int, y int) bool {
-																			vulnX := vulnerabilities[x]
-																			vulnY := vulnerabilities[y]
+											if osvResponse.Vulnerabilities != nil {
+												vulnerabilities := []types.OsvDevResponseVulnerabilityItem{}
+												vulnerabilities = append(vulnerabilities, *osvResponse.Vulnerabilities...)
+												vulnerabilitiesCount := len(vulnerabilities)
 
-																			_, compX := vulnX.GetSeverityDisplayValues()
-																			_, compY := vulnY.GetSeverityDisplayValues()
-																			if compX != compY {
-																				return compX > compY
-																			}
+												if vulnerabilitiesCount > 0 {
+													s.Stop()
 
-																			return false
-																		})
+													fmt.Printf("\t[%s] Found %v known security issues in '%s':%s", red("!"), vulnerabilitiesCount, item.Path, fmt.Sprintln())
 
-																		var tBuffer bytes.Buffer
+													for _, v := range vulnerabilities {
+														knownVulnerabilityIds[v.Id] = true
+														if v.Aliases != nil {
+															for _, a := range *v.Aliases {
+																knownVulnerabilityIds[a] = true
+															}
+														}
+													}
 
-																		// output in buffer first
-																		t := table.NewWriter()
-																		t.SetOutputMirror(&tBuffer)
+													sort.Slice(vulnerabilities, func(x int, y int) bool {
+														vulnX := vulnerabilities[x]
+														vulnY := vulnerabilities[y]
 
-																		// header
-																		t.AppendHeader(table.Row{tHeadColor("#"), tHeadColor("Severity"), tHeadColor("ID"), tHeadColor("Summary")})
-																		for vi, v := range vulnerabilities {
-																			if vi > 0 {
-																				// add separator at top
-																				t.AppendSeparator()
-																			}
+														_, compX := vulnX.GetSeverityDisplayValues()
+														_, compY := vulnY.GetSeverityDisplayValues()
+														if compX != compY {
+															return compX > compY
+														}
 
-																			severity, _ := v.GetSeverityDisplayValues()
+														return false
+													})
 
-																			// output basic issue info
-																			t.AppendRow(table.Row{vi + 1, severity, v.Id, v.Summary})
+													var tBuffer bytes.Buffer
 
-																			if v.References != nil {
-																				// add references
+													// output in buffer first
+													t := table.NewWriter()
+													t.SetOutputMirror(&tBuffer)
 
-																				references := []types.OsvDevResponseVulnerabilityItemReference{}
-																				references = append(references, *v.References...)
+													// header
+													t.AppendHeader(table.Row{tHeadColor("#"), tHeadColor("Severity"), tHeadColor("ID"), tHeadColor("Summary")})
+													for vi, v := range vulnerabilities {
+														if vi > 0 {
+															// add separator at top
+															t.AppendSeparator()
+														}
 
-																				// sort references by type, then by URL
-																				sort.Slice(references, func(x int, y int) bool {
-																					refX := references[x]
-																					refY := references[y]
+														severity, _ := v.GetSeverityDisplayValues()
 
-																					typeX := strings.TrimSpace(strings.ToLower(refX.Type))
-																					typeY := strings.TrimSpace(strings.ToLower(refY.Type))
-																					if typeX != typeY {
-																						return typeX < typeY
-																					}
+														// output basic issue info
+														t.AppendRow(table.Row{vi + 1, severity, v.Id, v.Summary})
 
-																					urlX := strings.TrimSpace(strings.ToLower(refX.Url))
-																					urlY := strings.TrimSpace(strings.ToLower(refY.Url))
+														if v.References != nil {
+															// add references
 
-																					return urlX < urlY
-																				})
+															references := []types.OsvDevResponseVulnerabilityItemReference{}
+															references = append(references, *v.References...)
 
-																				if len(references) > 0 {
-																					// build reference list
+															// sort references by type, then by URL
+															sort.Slice(references, func(x int, y int) bool {
+																refX := references[x]
+																refY := references[y]
 
-																					t.AppendSeparator()
+																typeX := strings.TrimSpace(strings.ToLower(refX.Type))
+																typeY := strings.TrimSpace(strings.ToLower(refY.Type))
+																if typeX != typeY {
+																	return typeX < typeY
+																}
 
-																					for ri, r := range references {
-																						refCol := ""
-																						if ri == 0 {
-																							refCol = tHeadColor("References:")
-																						}
+																urlX := strings.TrimSpace(strings.ToLower(refX.Url))
+																urlY := strings.TrimSpace(strings.ToLower(refY.Url))
 
-																						t.AppendRow(table.Row{"", refCol, r.Type, r.Url})
-																					}
+																return urlX < urlY
+															})
 
-																					t.AppendSeparator()
-																				}
-																			}
-																		}
+															if len(references) > 0 {
+																// build reference list
 
-																		// render final table
-																		t.Render()
+																t.AppendSeparator()
 
-																		// output final table with prefix
-																		prefix := "  "
-																		output := tBuffer.String()
-																		for _, line := range strings.Split(output, fmt.Sprintln()) {
-																			if len(line) > 0 {
-																				fmt.Printf("%v%s%v", prefix, line, fmt.Sprintln())
-																			}
-																		}
-																	} else {
-																		reportNoIssues()
+																for ri, r := range references {
+																	refCol := ""
+																	if ri == 0 {
+																		refCol = tHeadColor("References:")
 																	}
-																} else {
-																	reportNoIssues()
+
+																	t.AppendRow(table.Row{"", refCol, r.Type, r.Url})
 																}
-															} else {
-																s.Stop()
 
-																fmt.Printf("\t[%s] Invalid JSON from '%s': %s%s", red("!"), url, err.Error(), fmt.Sprintln())
+																t.AppendSeparator()
 															}
-														} else {
-															s.Stop()
-
-															fmt.Printf("\t[%s] Could not read response from '%s': %s%s", red("!"), url, err.Error(), fmt.Sprintln())
 														}
-													} else {
-														s.Stop()
+													}
 
-														fmt.Printf("\t[%s] Unexpected response from '%s': %v%s", red("!"), url, resp.Status, fmt.Sprintln())
+													// render final table
+													t.Render()
+
+													// output final table with prefix
+													prefix := "  "
+													output := tBuffer.String()
+													for _, line := range strings.Split(output, fmt.Sprintln()) {
+														if len(line) > 0 {
+															fmt.Printf("%v%s%v", prefix, line, fmt.Sprintln())
+														}
 													}
 												} else {
-													s.Stop()
-
-													fmt.Printf("\t[%s] Could not do request to '%s':%s%s", red("!"), url, err.Error(), fmt.Sprintln())
+													reportNoIssues()
 												}
 											} else {
-												s.Stop()
-
-												fmt.Printf("\t[%s] Could not prepare request for '%s':%s%s", red("!"), url, err.Error(), fmt.Sprintln())
+												reportNoIssues()
 											}
 										} else {
 											s.Stop()
 
-											fmt.Printf("\t[%s] JSON is for '%s' cannot be created:%s%s", red("!"), url, err.Error(), fmt.Sprintln())
+											fmt.Printf("\t[%s] Could not check '%s': %s%s", red("!"), item.Path, err.Error(), fmt.Sprintln())
 										}
 									}
+									stopSecurityTiming()
 									fmt.Println()
 								}
 							} else {
@@ -415,6 +557,20 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 				}
 			}
 
+			if useGovulncheck && goModFile != "" {
+				fmt.Println("Checking reachability of security issues ...")
+				check_reachable_vulnerabilities(app, knownVulnerabilityIds)
+				fmt.Println()
+			}
+
+			fmt.Println("Checking Git repository ...")
+			check_git_working_tree(app)
+			fmt.Println()
+
+			fmt.Println("Checking bin folder ...")
+			check_bin_folder_in_path(app)
+			fmt.Println()
+
 			fmt.Println("Environment variables ...")
 			{
 				vars := make([]string, 0)
@@ -429,10 +585,119 @@ func Init_Doctor_Command(parentCmd *cobra.Command, app *types.AppContext) {
 					}
 				}
 			}
+
+			if fix || fixUpdates {
+				// security issues are never fixed automatically
+				fmt.Println()
+				fmt.Println("Fixing issues ...")
+
+				reader := bufio.NewReader(app.In)
+				changes := []string{}
+
+				shouldFix := func(question string) bool {
+					return yes || confirm_doctor_fix(reader, question)
+				}
+
+				if fix && len(unusedModules) > 0 {
+					if shouldFix(fmt.Sprintf("Remove %v unused module(s) by running 'go mod tidy'", len(unusedModules))) {
+						app.RunShellCommandByArgs("go", "mod", "tidy")
+
+						for _, m := range unusedModules {
+							changes = append(changes, fmt.Sprintf("removed unused module '%s'", m))
+						}
+					}
+				}
+
+				if fixUpdates {
+					for _, m := range outdatedModules {
+						moduleWithVersion := fmt.Sprintf("%s@%s", m.path, m.latestVersion)
+
+						if shouldFix(fmt.Sprintf("Update '%s'", moduleWithVersion)) {
+							app.RunShellCommandByArgs("go", "get", moduleWithVersion)
+
+							changes = append(changes, fmt.Sprintf("updated '%s' to %s", m.path, m.latestVersion))
+						}
+					}
+				}
+
+				if len(changes) > 0 {
+					fmt.Println()
+					fmt.Println("Summary of changes:")
+					for _, c := range changes {
+						fmt.Printf("\t[%s] %s%s", green("✓"), c, fmt.Sprintln())
+					}
+				} else {
+					fmt.Printf("\t[%s] Nothing changed%s", green("✓"), fmt.Sprintln())
+				}
+			}
 		},
 	}
 
+	doctorCmd.Flags().BoolVarP(&fix, "fix", "", false, "fix safe issues like unused dependencies")
+	doctorCmd.Flags().BoolVarP(&fixUpdates, "fix-updates", "", false, "update outdated direct dependencies to their latest versions")
+	doctorCmd.Flags().BoolVarP(&useGovulncheck, "govulncheck", "", false, "check reachability of security issues with govulncheck, if installed")
+	doctorCmd.Flags().IntVarP(&maxAge, "max-age", "", 365, "maximum age of direct dependencies in days before they are flagged, 0 to disable")
+	doctorCmd.Flags().BoolVarP(&yes, "yes", "y", false, "auto select 'yes'")
+
 	parentCmd.AddCommand(
 		doctorCmd,
 	)
 }
+
+func format_module_age(age time.Duration) string {
+	days := int(age.Hours() / 24)
+
+	if days >= 730 {
+		return fmt.Sprintf("%v years", days/365)
+	}
+	if days >= 60 {
+		return fmt.Sprintf("%v months", days/30)
+	}
+	if days == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%v days", days)
+}
+
+func get_go_module_version_time(modulePath string, moduleVersion string) *time.Time {
+	url := fmt.Sprintf("https://proxy.golang.org/%s/@v/%s.info", to_go_proxy_path(modulePath), moduleVersion)
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil
+	}
+
+	var info GoProxyModuleInfo
+	err = json.NewDecoder(resp.Body).Decode(&info)
+	if err != nil {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(info.Time))
+	if err != nil {
+		return nil
+	}
+
+	return &t
+}
+
+// to_go_proxy_path() - escapes upper case letters of a module path
+// as described in https://go.dev/ref/mod#goproxy-protocol
+func to_go_proxy_path(modulePath string) string {
+	var escapedPath strings.Builder
+	for _, r := range strings.TrimSpace(modulePath) {
+		if r >= 'A' && r <= 'Z' {
+			escapedPath.WriteRune('!')
+			escapedPath.WriteRune(r + ('a' - 'A'))
+		} else {
+			escapedPath.WriteRune(r)
+		}
+	}
+
+	return escapedPath.String()
+}
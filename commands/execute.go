@@ -27,7 +27,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"os"
+	"regexp"
 	"runtime"
 	"strings"
 	"syscall"
@@ -38,11 +38,28 @@ import (
 	"github.com/spf13/cobra"
 )
 
+func find_blocked_execute_pattern(command string, patterns []string) (string, error) {
+	for _, pattern := range patterns {
+		rx, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid blocklist pattern '%v': %w", pattern, err)
+		}
+
+		if rx.MatchString(command) {
+			return pattern, nil
+		}
+	}
+
+	return "", nil
+}
+
 func Init_Exec_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	var customTemperature float32
 	var errorCode int
+	var explain bool
 	var force bool
 	var noStdin bool
+	var strict bool
 	var successCode int
 	var withExitCode bool
 
@@ -97,6 +114,10 @@ You can always expect that the user has installed all required software.`,
 				chat.UpdateSystem(systemPrompt)
 			}
 
+			if customTemperature == -1 {
+				// environment variable or settings file
+				customTemperature = app.GetAIChatTemperature(-1)
+			}
 			if customTemperature != -1 {
 				app.Debug(fmt.Sprintf("Temperature: %v", customTemperature))
 				chat.UpdateTemperature(customTemperature)
@@ -162,7 +183,34 @@ Your shell command without Markdown which can directly executed (if multiple ste
 			tryAgain("")
 			utils.CheckForError(generateAnswer())
 
+			getBlockedPattern := func() string {
+				pattern, err := find_blocked_execute_pattern(answer, app.SettingsFile.GetExecuteBlocklist())
+				utils.CheckForError(err)
+
+				return pattern
+			}
+
 			executeCommand := func() {
+				blockedPattern := getBlockedPattern()
+				if blockedPattern != "" {
+					if strict {
+						utils.CloseWithError(fmt.Errorf("command '%v' matches blocked pattern '%v'", answer, blockedPattern))
+					}
+
+					// require explicit confirmation
+					fmt.Printf("⚠️ '%v' matches blocked pattern '%v'!%v", answer, blockedPattern, fmt.Sprintln())
+					fmt.Print("Type 'yes' to execute it anyway: ")
+
+					reader := bufio.NewReader(app.In)
+					confirmation, err := reader.ReadString('\n')
+					utils.CheckForError(err)
+
+					if strings.TrimSpace(strings.ToLower(confirmation)) != "yes" {
+						fmt.Println("Aborted")
+						return
+					}
+				}
+
 				p := utils.CreateShellCommand(answer)
 				p.Dir = app.Cwd
 				p.Stdout = app.Out
@@ -173,12 +221,12 @@ Your shell command without Markdown which can directly executed (if multiple ste
 
 				if withExitCode {
 					if status, ok := p.ProcessState.Sys().(syscall.WaitStatus); ok {
-						os.Exit(status.ExitStatus())
+						utils.Exit(status.ExitStatus())
 					} else {
 						if err != nil {
-							os.Exit(errorCode)
+							utils.Exit(errorCode)
 						} else {
-							os.Exit(successCode)
+							utils.Exit(successCode)
 						}
 					}
 				} else {
@@ -186,13 +234,49 @@ Your shell command without Markdown which can directly executed (if multiple ste
 				}
 			}
 
+			explainAnswer := func() {
+				if !explain {
+					return
+				}
+
+				answerJSONData, err := json.Marshal(answer)
+				utils.CheckForError(err)
+
+				explainPrompt := fmt.Sprintf(
+					`Explain in 1 to 3 short sentences and without Markdown what the following shell command for "%v" on "%v" does and if it has any side effects: %v`,
+					shell, operatingSystem,
+					string(answerJSONData),
+				)
+
+				app.Debug(fmt.Sprintf("Explain prompt: %v", explainPrompt))
+
+				explanation, err := app.ChatWithAI(explainPrompt)
+				if err != nil {
+					log.Println("[ERROR]", err.Error())
+					return
+				}
+
+				fmt.Printf("%v%v", strings.TrimSpace(explanation), fmt.Sprintln())
+				fmt.Println()
+			}
+
 			if force {
+				explainAnswer()
 				executeCommand()
 			} else {
 				// ask before execute
 
 				showPrompt := func() {
+					explainAnswer()
+
 					fmt.Printf("Execute '%v'?%v", answer, fmt.Sprintln())
+					if getBlockedPattern() != "" {
+						if strict {
+							fmt.Printf("⚠️ This command matches a blocked pattern and will be refused!%v", fmt.Sprintln())
+						} else {
+							fmt.Printf("⚠️ This command matches a blocked pattern and requires an extra confirmation!%v", fmt.Sprintln())
+						}
+					}
 					fmt.Print("[E]xecute, [c]opy, [t]ry again, [a]bort ")
 				}
 				showPrompt()
@@ -245,8 +329,10 @@ Your shell command without Markdown which can directly executed (if multiple ste
 	}
 
 	execCmd.Flags().IntVarP(&errorCode, "error-code", "", 1, "custom error code")
+	execCmd.Flags().BoolVarP(&explain, "explain", "", false, "explain the command before execute")
 	execCmd.Flags().BoolVarP(&force, "force", "", false, "do not ask before execute")
 	execCmd.Flags().BoolVarP(&noStdin, "no-stdin", "", false, "do not load from STDIN")
+	execCmd.Flags().BoolVarP(&strict, "strict", "", false, "refuse commands which match a blocked pattern")
 	execCmd.Flags().IntVarP(&successCode, "success-code", "", 0, "custom success code")
 	execCmd.Flags().Float32VarP(&customTemperature, "temperature", "", -1, "custom temperature value")
 	execCmd.Flags().BoolVarP(&withExitCode, "with-exit-code", "", false, "also exit with code from execution")
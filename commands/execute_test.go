@@ -0,0 +1,89 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+)
+
+func TestFindBlockedExecutePattern(t *testing.T) {
+	tests := []struct {
+		command  string
+		expected bool
+	}{
+		{"rm -rf /", true},
+		{"rm -fr /", true},
+		{"rm -Rf /*", true},
+		{"rm -r -f /", true},
+		{"rm -f -r /", true},
+		{"rm -rf --no-preserve-root /", true},
+		{"rm --no-preserve-root -rf /", true},
+		{"rm --recursive --force /", true},
+		{"rm -rf -- /", true},
+		{"rm -rf ~", true},
+		{"rm -rf ~/", true},
+		{"rm -rf ~/*", true},
+		{"rm -rf $HOME", true},
+		{"rm -rf \"$HOME\"/*", true},
+		{"rm -rf ${HOME}/", true},
+		{"sudo rm -rf / && echo done", true},
+		{"rm -rf /; echo done", true},
+		{":(){ :|:& };:", true},
+		{"mkfs.ext4 /dev/sda1", true},
+		{"dd if=/dev/zero of=/dev/sda", true},
+		{"chmod -R 777 /", true},
+		{"shutdown -h now", true},
+		{"format c:", true},
+		{"rd /s /q C:\\", true},
+
+		{"rm -rf /tmp/x", false},
+		{"rm -rf ./build", false},
+		{"rm -rf ~/projects/x", false},
+		{"rm -rf $HOME/.cache/go-build", false},
+		{"rm -f /tmp/a.txt", false},
+		{"rm /", false},
+		{"rm -rf build/ dist/", false},
+		{"chmod 777 ./script.sh", false},
+		{"echo formatted", false},
+	}
+
+	for _, test := range tests {
+		pattern, err := find_blocked_execute_pattern(test.command, types.DefaultExecuteBlocklist)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if (pattern != "") != test.expected {
+			t.Errorf("'%v': expected blocked to be %v, matched '%v'", test.command, test.expected, pattern)
+		}
+	}
+}
+
+func TestFindBlockedExecutePatternWithInvalidPattern(t *testing.T) {
+	_, err := find_blocked_execute_pattern("ls", []string{"("})
+	if err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
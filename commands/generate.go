@@ -40,12 +40,135 @@ import (
 	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/glamour"
 	"github.com/google/uuid"
+	"github.com/mkloubert/go-package-manager/constants"
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 )
 
+// generateProjectApplySettings stores settings for
+// `apply_generate_project_response()` function
+type generateProjectApplySettings struct {
+	askUser    func(question string) bool // asks the user if a step should be executed
+	noGitInit  bool                       // do not initialize git repository
+	origin     string                     // custom git origin url
+	outDir     string                     // the output directory
+	projectUrl string                     // the URL / module name of the project
+	sshUrl     bool                       // use SSH url for git origin
+}
+
+// apply_generate_project_response() - executes all steps of an AI response
+// inside the output directory
+func apply_generate_project_response(app *types.AppContext, response *types.GenerateProjectStepsResponse, settings generateProjectApplySettings) error {
+	// git init
+	if !settings.noGitInit {
+		p := utils.CreateShellCommandByArgs("git", "init")
+		p.Dir = settings.outDir
+		p.Stdout = nil
+		p.Stderr = nil
+		app.Debug("Initializing git repository ...")
+		utils.RunCommand(p)
+
+		// repo URL for origin
+		originUrl := strings.TrimSpace(settings.origin)
+		if originUrl == "" {
+			originUrl = fmt.Sprintf("https://%s", settings.projectUrl)
+
+			if settings.sshUrl {
+				// convert to SSH
+
+				parsedURL, err := url.Parse(originUrl)
+				if err != nil {
+					return err
+				}
+
+				host := parsedURL.Hostname()
+				userRepoPath := parsedURL.Path[1:]
+
+				originUrl = fmt.Sprintf("git@%s:%s.git", host, userRepoPath)
+			}
+		}
+
+		p = utils.CreateShellCommandByArgs("git", "remote", "add", "origin", originUrl)
+		p.Dir = settings.outDir
+		p.Stdout = nil
+		p.Stderr = nil
+		app.Debug(fmt.Sprintf("Adding git remote '%s' as 'origin' ...", originUrl))
+		utils.RunCommand(p)
+	}
+
+	// cleanup project
+	p := utils.CreateShellCommandByArgs("go", "mod", "init", settings.projectUrl)
+	p.Dir = settings.outDir
+	p.Stdout = nil
+	p.Stderr = nil
+	app.Debug(fmt.Sprintf("Cleanup project '%s' ...", settings.projectUrl))
+	utils.RunCommand(p)
+
+	// run steps
+	for i, step := range response.Steps {
+		stepNr := i + 1
+
+		app.Debug(fmt.Sprintf("Step #%v (%s): %s", stepNr, step.Title, step.Description))
+
+		if step.Type == types.GenerateProjectStepTypeFile {
+			// create a file
+
+			relativeFilePath := step.RelativeFilePath
+			fullPath, err := utils.SafeJoin(settings.outDir, relativeFilePath)
+			if err != nil {
+				return err
+			}
+			content := step.Content
+
+			if !settings.askUser(fmt.Sprintf("Step #%v will create a file '%s'.", stepNr, relativeFilePath)) {
+				continue
+			}
+
+			err = os.MkdirAll(filepath.Dir(fullPath), constants.DefaultDirMode)
+			if err != nil {
+				return err
+			}
+
+			app.Debug(fmt.Sprintf("Creating file '%s' ...", fullPath))
+			err = os.WriteFile(fullPath, []byte(content), 0664)
+			if err != nil {
+				return err
+			}
+		} else if step.Type == types.GenerateProjectStepTypeInstallModule {
+			// install module
+
+			moduleUrl := step.ModuleUrl
+
+			if !settings.askUser(fmt.Sprintf("Step #%v will install a module from '%s'.", stepNr, moduleUrl)) {
+				continue
+			}
+
+			p := utils.CreateShellCommandByArgs("go", "get", moduleUrl)
+			p.Dir = settings.outDir
+			p.Stdout = nil
+			p.Stderr = nil
+			app.Debug(fmt.Sprintf("Installing module '%s' ...", moduleUrl))
+			utils.RunCommand(p)
+		} else {
+			app.Warn(fmt.Sprintf("Step #%v has unsupported type '%s' and is skipped", stepNr, step.Type))
+		}
+	}
+
+	// cleanup project
+	p = utils.CreateShellCommandByArgs("go", "mod", "tidy")
+	p.Dir = settings.outDir
+	app.Debug(fmt.Sprintf("Running '%v' ...", "go mod tidy"))
+	utils.RunCommand(p)
+
+	// output final summary
+	out, _ := glamour.Render(response.FinalSummary, "dark")
+	fmt.Println(out)
+
+	return nil
+}
+
 func init_generate_documentation_command(parentCmd *cobra.Command, app *types.AppContext) {
 	var man bool
 	var markdown bool
@@ -132,6 +255,63 @@ func init_generate_documentation_command(parentCmd *cobra.Command, app *types.Ap
 	)
 }
 
+func get_generate_project_steps_schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"final_summary", "steps"},
+		"properties": map[string]interface{}{
+			"final_summary": map[string]interface{}{
+				"type":        "string",
+				"description": "This is the Markdown text in pretty human readable format that will be displayed after all steps has been made and where you in details explain what you did and what the user finally has to do (the text must be written as if you had carried out the steps)",
+			},
+			"steps": map[string]interface{}{
+				"type":        "array",
+				"description": "The current and aggregated list of steps to do",
+				"items": map[string]interface{}{
+					// required fields of the different types are checked by
+					// `GenerateProjectStep.Validate()`, so unknown types can be skipped
+					"type": "object",
+					"required": []string{
+						"description",
+						"title",
+						"type",
+					},
+					"description": "Contains information for a specific step, like a file that is part of the project or a module to install",
+					"properties": map[string]interface{}{
+						"content": map[string]interface{}{
+							"type":        "string",
+							"description": "The content that is written to the file without any explanation (required for type 'file')",
+						},
+						"description": map[string]interface{}{
+							"type":        "string",
+							"description": "A description of the step",
+						},
+						"module_url": map[string]interface{}{
+							"type":        "string",
+							"description": "The URL to the module which can be used with 'go get <URL>' to install a module (required for type 'install_module')",
+							"examples":    []string{"github.com/foo/bar", "example.com/project-repo"},
+						},
+						"relative_file_path": map[string]interface{}{
+							"type":        "string",
+							"description": "The relative path and name of the file (required for type 'file')",
+							"examples":    []string{"foo/bar.txt", "foo/bar/buzz.tsx"},
+						},
+						"title": map[string]interface{}{
+							"type":        "string",
+							"description": "A (short) description of the step as title",
+						},
+						"type": map[string]interface{}{
+							"type":        "string",
+							"description": "The type of the step, which is 'file' or 'install_module'",
+							"examples":    []string{"file", "install_module"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func init_generate_password_command(parentCmd *cobra.Command, app *types.AppContext) {
 	var allBytes bool
 	var base64Output bool
@@ -262,9 +442,12 @@ func init_generate_password_command(parentCmd *cobra.Command, app *types.AppCont
 func init_generate_project_command(parentCmd *cobra.Command, app *types.AppContext) {
 	var alwaysYes bool
 	var force bool
+	var headless bool
 	var noGitInit bool
 	var origin string
+	var maxRetries int
 	var output string
+	var resume bool
 	var sshUrl bool
 	var temperature float32
 
@@ -301,6 +484,13 @@ Always return the current and complete state based on our current conversation.`
 
 			outDir := app.GetFullPathOrDefault(output, app.Cwd)
 
+			if force && resume {
+				utils.CloseWithError(errors.New("cannot use --force and --resume together"))
+			}
+			if headless && resume {
+				utils.CloseWithError(errors.New("cannot use --headless and --resume together"))
+			}
+
 			if force {
 				app.Debug(fmt.Sprintf("Checking if directory '%s' exists ...", outDir))
 				doesOutDirExist, err := utils.IsDirExisting(outDir)
@@ -328,6 +518,9 @@ Always return the current and complete state based on our current conversation.`
 			utils.CheckForError(err)
 
 			model := strings.TrimSpace(app.Model)
+			if model == "" {
+				model = app.GetDefaultAIChatModel(api.GetProvider())
+			}
 			if model == "" {
 				app.Debug("Setting up default model ...")
 
@@ -347,9 +540,62 @@ Always return the current and complete state based on our current conversation.`
 			app.Debug(fmt.Sprintf("Model: %s", api.GetModel()))
 			app.Debug(fmt.Sprintf("Temperature: %v", currentTemperature))
 
+			if headless {
+				// no UI: one request and apply all steps
+
+				userMessage := strings.TrimSpace(app.GetAIPrompt(""))
+				if userMessage == "" {
+					stdin, err := utils.LoadFromSTDINIfAvailable()
+					utils.CheckForError(err)
+
+					if stdin != nil {
+						userMessage = strings.TrimSpace(string(*stdin))
+					}
+				}
+
+				if userMessage == "" {
+					utils.CloseWithError(errors.New("no prompt defined, use --prompt or STDIN"))
+				}
+
+				response, err := send_generate_project_message(app, api, userMessage, maxRetries)
+				utils.CheckForError(err)
+
+				err = apply_generate_project_response(app, response, generateProjectApplySettings{
+					askUser: func(question string) bool {
+						return true // headless implies --y
+					},
+					noGitInit:  noGitInit,
+					origin:     origin,
+					outDir:     outDir,
+					projectUrl: projectUrl,
+					sshUrl:     sshUrl,
+				})
+				utils.CheckForError(err)
+
+				return
+			}
+
 			editor := types.NewAIEditor(app, projectUrl)
 
 			var lastResponse *types.GenerateProjectStepsResponse = nil
+			var userMessages []string
+
+			// keep session outside of project, so it does not get into its repository
+			sessionFilePath, err := app.GetGenerateProjectSessionFilePath(outDir)
+			utils.CheckForError(err)
+
+			saveSession := func() error {
+				session := types.GenerateProjectSession{
+					History:      api.ExportHistory(),
+					LastResponse: lastResponse,
+					ProjectUrl:   projectUrl,
+					UserMessages: userMessages,
+				}
+
+				app.Debug(fmt.Sprintf("Saving session to '%s' ...", sessionFilePath))
+				return session.SaveTo(sessionFilePath)
+			}
+
 			updateFileTree := func() {
 				files := make([]types.AIEditorFileItem, 0)
 
@@ -357,27 +603,16 @@ Always return the current and complete state based on our current conversation.`
 					modulesToInstall := map[string]bool{}
 
 					for _, step := range lastResponse.Steps {
-						stepType, ok := step["type"].(string)
-						if !ok {
-							continue
-						}
-
-						if stepType == "file" {
-							relativeFilePath := step["relative_file_path"].(string)
-							utils.CheckForError(err)
-
-							content, _ := step["content"].(string)
-
+						if step.Type == types.GenerateProjectStepTypeFile {
 							files = append(files, types.AIEditorFileItem{
-								Name:    relativeFilePath,
-								Content: []byte(content),
+								Name:    step.RelativeFilePath,
+								Content: []byte(step.Content),
 							})
-						} else if stepType == "install_module" {
+						} else if step.Type == types.GenerateProjectStepTypeInstallModule {
 							// install module
 
-							moduleUrl, ok := step["module_url"].(string)
-							if ok {
-								modulesToInstall[moduleUrl] = true
+							if step.ModuleUrl != "" {
+								modulesToInstall[step.ModuleUrl] = true
 							}
 						}
 					}
@@ -421,19 +656,6 @@ require (
 					return errors.New("no chat response available")
 				}
 
-				getFullOutputPath := func(p string) (string, error) {
-					dir := p
-					if !filepath.IsAbs(dir) {
-						dir = filepath.Join(outDir, dir)
-					}
-
-					if dir != outDir && !strings.HasPrefix(dir, fmt.Sprintf("%s%s", outDir, string(filepath.Separator))) {
-						return dir, errors.New("invalid directory")
-					}
-
-					return dir, nil
-				}
-
 				askUser := func(question string) bool {
 					if !alwaysYes {
 						reader := bufio.NewReader(app.In)
@@ -461,100 +683,24 @@ require (
 				}
 
 				return editor.StopWith(func() error {
-					// git init
-					if !noGitInit {
-						p := utils.CreateShellCommandByArgs("git", "init")
-						p.Dir = outDir
-						p.Stdout = nil
-						p.Stderr = nil
-						app.Debug("Initializing git repository ...")
-						utils.RunCommand(p)
-
-						// repo URL for origin
-						originUrl := strings.TrimSpace(origin)
-						if originUrl == "" {
-							originUrl = fmt.Sprintf("https://%s", projectUrl)
-
-							if sshUrl {
-								// convert to SSH
-
-								parsedURL, err := url.Parse(originUrl)
-								utils.CheckForError(err)
-
-								host := parsedURL.Hostname()
-								userRepoPath := parsedURL.Path[1:]
-
-								originUrl = fmt.Sprintf("git@%s:%s.git", host, userRepoPath)
-							}
-						}
-
-						p = utils.CreateShellCommandByArgs("git", "remote", "add", "origin", originUrl)
-						p.Dir = outDir
-						p.Stdout = nil
-						p.Stderr = nil
-						app.Debug(fmt.Sprintf("Adding git remote '%s' as 'origin' ...", originUrl))
-						utils.RunCommand(p)
+					err := apply_generate_project_response(app, lastResponse, generateProjectApplySettings{
+						askUser:    askUser,
+						noGitInit:  noGitInit,
+						origin:     origin,
+						outDir:     outDir,
+						projectUrl: projectUrl,
+						sshUrl:     sshUrl,
+					})
+					if err != nil {
+						return err
 					}
 
-					// cleanup project
-					p := utils.CreateShellCommandByArgs("go", "mod", "init", projectUrl)
-					p.Dir = outDir
-					p.Stdout = nil
-					p.Stderr = nil
-					app.Debug(fmt.Sprintf("Cleanup project '%s' ...", projectUrl))
-					utils.RunCommand(p)
-
-					// run steps
-					for i, step := range lastResponse.Steps {
-						stepNr := i + 1
-						stepDescription := step["description"].(string)
-						stepTitle := step["title"].(string)
-						stepType := step["type"].(string)
-
-						app.Debug(fmt.Sprintf("Step #%v (%s): %s", stepNr, stepTitle, stepDescription))
-
-						if stepType == "file" {
-							// create a file
-
-							relativeFilePath := step["relative_file_path"].(string)
-							fullPath, err := getFullOutputPath(relativeFilePath)
-							utils.CheckForError(err)
-							content := step["content"].(string)
-
-							if !askUser(fmt.Sprintf("Step #%v will create a file '%s'.", stepNr, relativeFilePath)) {
-								continue
-							}
-
-							app.Debug(fmt.Sprintf("Creating file '%s' ...", fullPath))
-							os.WriteFile(fullPath, []byte(content), 0664)
-						} else if stepType == "install_module" {
-							// install module
-
-							moduleUrl := step["module_url"].(string)
-
-							if !askUser(fmt.Sprintf("Step #%v will install a module from '%s'.", stepNr, moduleUrl)) {
-								continue
-							}
-
-							p := utils.CreateShellCommandByArgs("go", "get", moduleUrl)
-							p.Dir = outDir
-							p.Stdout = nil
-							p.Stderr = nil
-							app.Debug(fmt.Sprintf("Installing module '%s' ...", moduleUrl))
-							utils.RunCommand(p)
-						} else {
-							app.L.Println("[STOP]", fmt.Sprintf("Step of type '%s' is not supported", stepType))
-							os.Exit(666)
-						}
+					// project has been created, so session is not needed anymore
+					err = os.Remove(sessionFilePath)
+					if err != nil && !os.IsNotExist(err) {
+						return err
 					}
 
-					// cleanup project
-					app.TidyUp()
-
-					// output final summary
-					out, _ := glamour.Render(lastResponse.FinalSummary, "dark")
-					fmt.Println(out)
-
 					return nil
 				})
 			}
@@ -562,9 +708,16 @@ require (
 			editor.OnResetClick = func() error {
 				editor.ChatHistory.Clear()
 
+				api.ClearHistory()
 				lastResponse = nil
+				userMessages = nil
 				updateFromLastResponse()
 
+				err := os.Remove(sessionFilePath)
+				if err != nil && !os.IsNotExist(err) {
+					return err
+				}
+
 				editor.ChatEditor.SetText("", true)
 				editor.UI.SetFocus(editor.ChatEditor)
 
@@ -576,101 +729,7 @@ require (
 				now := time.Now()
 				formattedNow := now.Format("2006-01-02 15:04:05")
 
-				var schema = map[string]interface{}{
-					"type":     "object",
-					"required": []string{"final_summary", "steps"},
-					"properties": map[string]interface{}{
-						"final_summary": map[string]interface{}{
-							"type":        "string",
-							"description": "This is the Markdown text in pretty human readable format that will be displayed after all steps has been made and where you in details explain what you did and what the user finally has to do (the text must be written as if you had carried out the steps)",
-						},
-						"steps": map[string]interface{}{
-							"type":        "array",
-							"description": "The current and aggregated list of steps to do",
-							"items": map[string]interface{}{
-								"oneOf": []map[string]interface{}{
-									// file
-									{
-										"type": "object",
-										"required": []string{
-											"content",
-											"description",
-											"relative_file_path",
-											"title",
-											"type",
-										},
-										"description": "Contains information for a specific file of a list that is part of the project",
-										"properties": map[string]interface{}{
-											"content": map[string]interface{}{
-												"type":        "string",
-												"description": "The content that is written to the file without any explanation",
-											},
-											"description": map[string]interface{}{
-												"type":        "string",
-												"description": "A description of the file step",
-											},
-											"relative_file_path": map[string]interface{}{
-												"type":        "string",
-												"description": "The relative path and name of the file",
-												"examples":    []string{"foo/bar.txt", "foo/bar/buzz.tsx"},
-											},
-											"title": map[string]interface{}{
-												"type":        "string",
-												"description": "A (short) description of the file step as title",
-											},
-											"type": map[string]interface{}{
-												"type":        "string",
-												"description": "The type",
-												"enum":        []string{"file"},
-											},
-										},
-									},
-
-									// install_module
-									{
-										"type": "object",
-										"required": []string{
-											"module_url",
-											"description",
-											"title",
-											"type",
-										},
-										"description": "Contains information for creating a file",
-										"properties": map[string]interface{}{
-											"description": map[string]interface{}{
-												"type":        "string",
-												"description": "A description of the install module step",
-											},
-											"module_url": map[string]interface{}{
-												"type":        "string",
-												"description": "The URL to the module which can be used with 'go get <URL>' to install a module",
-												"examples":    []string{"github.com/foo/bar", "example.com/project-repo"},
-											},
-											"title": map[string]interface{}{
-												"type":        "string",
-												"description": "A (short) description of the install module step as title",
-											},
-											"type": map[string]interface{}{
-												"type":        "string",
-												"description": "The type",
-												"enum":        []string{"install_module"},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				}
-
-				var jsonAnswer string
-				api.WithJsonSchema(userMessage, "GenerateProjectStepsResponseSchema", schema, func(messageChunk string) error {
-					jsonAnswer += messageChunk
-					return nil
-				})
-
-				var response types.GenerateProjectStepsResponse
-				err = json.Unmarshal([]byte(jsonAnswer), &response)
+				response, err := send_generate_project_message(app, api, userMessage, maxRetries)
 				if err != nil {
 					return err
 				}
@@ -679,12 +738,18 @@ require (
 				nr := numberOfRequests
 
 				updateWithThisResponse := func() {
-					lastResponse = &response
+					lastResponse = response
 					updateFromLastResponse()
 				}
 
 				updateWithThisResponse()
 
+				userMessages = append(userMessages, userMessage)
+				err = saveSession()
+				if err != nil {
+					return err
+				}
+
 				editor.ChatEditor.SetText("", true)
 				editor.UI.SetFocus(editor.Tree)
 
@@ -698,6 +763,31 @@ require (
 				return nil
 			}
 
+			if resume {
+				app.Debug(fmt.Sprintf("Resuming session from '%s' ...", sessionFilePath))
+
+				session, err := types.LoadGenerateProjectSession(sessionFilePath)
+				utils.CheckForError(err)
+
+				if session.LastResponse != nil {
+					err = session.LastResponse.Validate()
+					utils.CheckForError(err)
+				}
+
+				api.ImportHistory(session.History)
+				lastResponse = session.LastResponse
+				userMessages = session.UserMessages
+
+				for range userMessages {
+					numberOfRequests = numberOfRequests + 1
+
+					itemText := fmt.Sprintf("#%s - resumed", fmt.Sprint(numberOfRequests))
+					editor.ChatHistory.InsertItem(0, itemText, "", 0, func() {
+						editor.ChatEditor.SetText("", true)
+					})
+				}
+			}
+
 			updateFromLastResponse()
 
 			err = editor.Run()
@@ -706,11 +796,14 @@ require (
 	}
 
 	projectCmd.Flags().BoolVarP(&force, "force", "f", false, "remove existing output directory before start")
+	projectCmd.Flags().BoolVarP(&headless, "headless", "", false, "run without UI, take prompt from --prompt or STDIN and apply all steps")
 	projectCmd.Flags().BoolVarP(&noGitInit, "no-git-init", "", false, "do not initialize git directory")
 	projectCmd.Flags().StringVarP(&origin, "origin", "", "", "custom git origin url")
 	projectCmd.Flags().StringVarP(&output, "output", "o", "", "custom output directory")
+	projectCmd.Flags().IntVarP(&maxRetries, "retries", "", 3, "maximum number of follow-up messages if AI response is invalid")
+	projectCmd.Flags().BoolVarP(&resume, "resume", "", false, "resume last session of output directory")
 	projectCmd.Flags().BoolVarP(&sshUrl, "ssh", "", false, "use SSH url for git repository instead HTTP")
-	projectCmd.Flags().Float32VarP(&temperature, "temperature", "", utils.GetAIChatTemperature(0.3), "custom temperature value")
+	projectCmd.Flags().Float32VarP(&temperature, "temperature", "", app.GetAIChatTemperature(0.3), "custom temperature value")
 	projectCmd.Flags().BoolVarP(&alwaysYes, "y", "", false, "do not ask user to execute each step")
 
 	parentCmd.AddCommand(
@@ -718,6 +811,34 @@ require (
 	)
 }
 
+// send_generate_project_message() - sends a message to the AI and returns the validated
+// steps of the response, where invalid responses are retried with corrective messages
+func send_generate_project_message(app *types.AppContext, api types.ChatAI, userMessage string, maxRetries int) (*types.GenerateProjectStepsResponse, error) {
+	var response types.GenerateProjectStepsResponse
+
+	_, err := types.ChatWithJsonSchema(api, userMessage, "GenerateProjectStepsResponseSchema", get_generate_project_steps_schema(), types.ChatWithJsonSchemaOptions{
+		MaxRetries: &maxRetries,
+		OnRetry: func(retry int, maxRetries int, err error) {
+			app.Debug(fmt.Sprintf("Retrying (%v/%v) because of invalid response: %v", retry, maxRetries, err))
+		},
+		Validate: func(jsonAnswer string) error {
+			response = types.GenerateProjectStepsResponse{}
+
+			err := json.Unmarshal([]byte(jsonAnswer), &response)
+			if err != nil {
+				return err
+			}
+
+			return response.Validate()
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
 func init_generate_uuid_command(parentCmd *cobra.Command, app *types.AppContext) {
 	var base64Output bool
 	var count uint16
@@ -0,0 +1,134 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package commands
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+)
+
+func TestApplyGenerateProjectResponseRejectsMaliciousPaths(t *testing.T) {
+	for _, relativeFilePath := range []string{"../../etc/evil", "/etc/evil", "sub/../../evil"} {
+		t.Run(relativeFilePath, func(t *testing.T) {
+			parentDir := t.TempDir()
+			outDir := filepath.Join(parentDir, "project")
+
+			err := os.MkdirAll(outDir, 0750)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			response := &types.GenerateProjectStepsResponse{
+				Steps: []types.GenerateProjectStep{
+					{
+						Content:          "package main",
+						RelativeFilePath: "main.go",
+						Type:             types.GenerateProjectStepTypeFile,
+					},
+					{
+						Content:          "evil",
+						RelativeFilePath: relativeFilePath,
+						Type:             types.GenerateProjectStepTypeFile,
+					},
+				},
+			}
+
+			err = apply_generate_project_response(&types.AppContext{}, response, generateProjectApplySettings{
+				askUser: func(question string) bool {
+					return true
+				},
+				noGitInit:  true,
+				outDir:     outDir,
+				projectUrl: "example.com/project",
+			})
+			if err == nil {
+				t.Fatalf("expected error for '%v'", relativeFilePath)
+			}
+
+			if _, err := os.Stat(filepath.Join(outDir, "main.go")); err != nil {
+				t.Errorf("valid file has not been created: %v", err)
+			}
+
+			entries, err := os.ReadDir(parentDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range entries {
+				if e.Name() != "project" {
+					t.Errorf("'%v' has been written outside of output directory", e.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateProjectSkipsUnknownStepTypes(t *testing.T) {
+	outDir := t.TempDir()
+
+	var logs bytes.Buffer
+	app := &types.AppContext{
+		L: log.New(&logs, "", 0),
+	}
+
+	mock := &types.MockAIChat{
+		Responses: []string{`{
+			"final_summary": "Done",
+			"steps": [
+				{"type": "file", "title": "Main", "description": "Main file", "relative_file_path": "main.go", "content": "package main"},
+				{"type": "run_command", "title": "Run", "description": "Runs something", "command": "go run ."}
+			]
+		}`},
+	}
+
+	response, err := send_generate_project_message(app, mock, "Create a project", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(response.Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %v", len(response.Steps))
+	}
+
+	err = apply_generate_project_response(app, response, generateProjectApplySettings{
+		askUser: func(question string) bool {
+			return true
+		},
+		noGitInit:  true,
+		outDir:     outDir,
+		projectUrl: "example.com/project",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(outDir, "main.go")); err != nil {
+		t.Errorf("file of known step has not been created: %v", err)
+	}
+	if !strings.Contains(logs.String(), "[WARN] Step #2 has unsupported type 'run_command' and is skipped") {
+		t.Errorf("expected warning for unknown step type, got %q", logs.String())
+	}
+}
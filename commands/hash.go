@@ -0,0 +1,160 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+// create_hasher() - creates a new hash instance by the name of an algorithm
+func create_hasher(algorithm string) (hash.Hash, error) {
+	switch strings.TrimSpace(strings.ToLower(algorithm)) {
+	case "", "sha256":
+		return sha256.New(), nil
+	case "crc32":
+		return crc32.NewIEEE(), nil
+	case "md5":
+		return md5.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "sha512":
+		return sha512.New(), nil
+	}
+
+	return nil, fmt.Errorf("algorithm '%v' not supported", algorithm)
+}
+
+// hash_file() - computes the digest of a file without buffering it
+func hash_file(hasher hash.Hash, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(hasher, f)
+	return err
+}
+
+func Init_Hash_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var algorithm string
+	var asBase64 bool
+	var check string
+	var perFile bool
+
+	var hashCmd = &cobra.Command{
+		Use:     "hash [files]",
+		Aliases: []string{"checksum", "digest"},
+		Short:   "Compute hash",
+		Long:    `Computes a hash of data from STDIN and/or files.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			encodeDigest := func(digest []byte) string {
+				if asBase64 {
+					return base64.StdEncoding.EncodeToString(digest)
+				}
+
+				return hex.EncodeToString(digest)
+			}
+
+			isMatching := func(digest string) bool {
+				expected := strings.TrimSpace(check)
+				if asBase64 {
+					return digest == expected
+				}
+
+				return strings.EqualFold(digest, expected)
+			}
+
+			if perFile {
+				if len(args) == 0 {
+					utils.CloseWithError(fmt.Errorf("no files defined"))
+				}
+
+				hasMismatch := false
+				for _, file := range args {
+					hasher, err := create_hasher(algorithm)
+					utils.CheckForError(err)
+
+					err = hash_file(hasher, app.GetFullPathOrDefault(file, ""))
+					utils.CheckForError(err)
+
+					digest := encodeDigest(hasher.Sum(nil))
+
+					if check == "" {
+						fmt.Fprintf(app.Out, "%v  %v%v", digest, file, fmt.Sprintln())
+					} else if isMatching(digest) {
+						fmt.Fprintf(app.Out, "%v: OK%v", file, fmt.Sprintln())
+					} else {
+						fmt.Fprintf(app.Out, "%v: FAILED%v", file, fmt.Sprintln())
+						hasMismatch = true
+					}
+				}
+
+				if hasMismatch {
+					utils.Exit(1)
+				}
+				return
+			}
+
+			hasher, err := create_hasher(algorithm)
+			utils.CheckForError(err)
+
+			written, err := app.WriteAllInputsTo(hasher, args...)
+			utils.CheckForError(err)
+
+			app.Debug(fmt.Sprintf("Bytes hashed: %v", written))
+
+			digest := encodeDigest(hasher.Sum(nil))
+
+			if check == "" {
+				fmt.Fprintln(app.Out, digest)
+			} else if !isMatching(digest) {
+				utils.CloseWithError(fmt.Errorf("digest mismatch: expected '%v', got '%v'", strings.TrimSpace(check), digest))
+			}
+		},
+	}
+
+	hashCmd.Flags().StringVarP(&algorithm, "algo", "a", "sha256", "algorithm like 'crc32', 'md5', 'sha1', 'sha256' or 'sha512'")
+	hashCmd.Flags().BoolVarP(&asBase64, "base64", "", false, "output digest as Base64 instead of hex")
+	hashCmd.Flags().StringVarP(&check, "check", "", "", "expected digest, exits with non-zero code on mismatch")
+	hashCmd.Flags().BoolVarP(&perFile, "files", "", false, "output one digest per file")
+
+	parentCmd.AddCommand(
+		hashCmd,
+	)
+}
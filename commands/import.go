@@ -33,8 +33,37 @@ import (
 	"github.com/mkloubert/go-package-manager/utils"
 )
 
+// setup_download_options() - sets up the options of the app for downloads
+// from CLI flags like `--checksum`, `--header`, `--progress`, `--token` and `--user-agent`
+func setup_download_options(app *types.AppContext, checksum string, headers []string, progress bool, token string, userAgent string) {
+	options := utils.DownloadOptions{
+		Checksum:  strings.TrimSpace(checksum),
+		Headers:   map[string]string{},
+		Progress:  progress,
+		Token:     strings.TrimSpace(token),
+		UserAgent: strings.TrimSpace(userAgent),
+	}
+
+	for _, h := range headers {
+		name, value, ok := strings.Cut(h, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			utils.CheckForError(fmt.Errorf("invalid header '%v', expected format is 'Name: value'", h))
+		}
+
+		options.Headers[name] = strings.TrimSpace(value)
+	}
+
+	app.DownloadOptions = options
+}
+
 func init_import_alias_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var checksum string
+	var headers []string
+	var progress bool
 	var reset bool
+	var token string
+	var userAgent string
 
 	var importAliasCmd = &cobra.Command{
 		Use:     "aliases [source]",
@@ -42,6 +71,8 @@ func init_import_alias_command(parentCmd *cobra.Command, app *types.AppContext)
 		Short:   "Import alias",
 		Long:    `Downloads alias files from external resources and merge them with local one.`,
 		Run: func(cmd *cobra.Command, args []string) {
+			setup_download_options(app, checksum, headers, progress, token, userAgent)
+
 			importFromYaml := func(yamlData []byte) {
 				var aliasFile types.AliasesFile
 				err := yaml.Unmarshal(yamlData, &aliasFile)
@@ -87,7 +118,12 @@ func init_import_alias_command(parentCmd *cobra.Command, app *types.AppContext)
 		},
 	}
 
+	importAliasCmd.Flags().StringVarP(&checksum, "checksum", "", "", "expected SHA256 checksum of the loaded data")
+	importAliasCmd.Flags().StringArrayVarP(&headers, "header", "H", []string{}, "one or more custom HTTP headers for downloads, like 'Name: value'")
+	importAliasCmd.Flags().BoolVarP(&progress, "progress", "", false, "show progress of downloads")
 	importAliasCmd.Flags().BoolVarP(&reset, "reset", "", false, "reset before import entries")
+	importAliasCmd.Flags().StringVarP(&token, "token", "", "", "bearer token for downloads (default from GPM_DOWNLOAD_TOKEN)")
+	importAliasCmd.Flags().StringVarP(&userAgent, "user-agent", "", "", "custom user agent for downloads")
 
 	parentCmd.AddCommand(
 		importAliasCmd,
@@ -95,7 +131,12 @@ func init_import_alias_command(parentCmd *cobra.Command, app *types.AppContext)
 }
 
 func init_import_project_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var checksum string
+	var headers []string
+	var progress bool
 	var reset bool
+	var token string
+	var userAgent string
 
 	var importProjectCmd = &cobra.Command{
 		Use:     "projects [source]",
@@ -103,6 +144,8 @@ func init_import_project_command(parentCmd *cobra.Command, app *types.AppContext
 		Short:   "Import project",
 		Long:    `Downloads project files from external resources and merge them with local one.`,
 		Run: func(cmd *cobra.Command, args []string) {
+			setup_download_options(app, checksum, headers, progress, token, userAgent)
+
 			importFromYaml := func(yamlData []byte) {
 				var projectFile types.ProjectsFile
 				err := yaml.Unmarshal(yamlData, &projectFile)
@@ -148,7 +191,12 @@ func init_import_project_command(parentCmd *cobra.Command, app *types.AppContext
 		},
 	}
 
+	importProjectCmd.Flags().StringVarP(&checksum, "checksum", "", "", "expected SHA256 checksum of the loaded data")
+	importProjectCmd.Flags().StringArrayVarP(&headers, "header", "H", []string{}, "one or more custom HTTP headers for downloads, like 'Name: value'")
+	importProjectCmd.Flags().BoolVarP(&progress, "progress", "", false, "show progress of downloads")
 	importProjectCmd.Flags().BoolVarP(&reset, "reset", "", false, "reset before import entries")
+	importProjectCmd.Flags().StringVarP(&token, "token", "", "", "bearer token for downloads (default from GPM_DOWNLOAD_TOKEN)")
+	importProjectCmd.Flags().StringVarP(&userAgent, "user-agent", "", "", "custom user agent for downloads")
 
 	parentCmd.AddCommand(
 		importProjectCmd,
@@ -23,9 +23,11 @@
 package commands
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/goccy/go-yaml"
 	"github.com/spf13/cobra"
@@ -35,8 +37,32 @@ import (
 	"github.com/mkloubert/go-package-manager/utils"
 )
 
+// ask_init_question() - asks the user for a value and returns
+// `defaultValue` if the input is empty
+func ask_init_question(reader *bufio.Reader, question string, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Printf("%v (%v): ", question, defaultValue)
+	} else {
+		fmt.Printf("%v: ", question)
+	}
+
+	userInput, err := reader.ReadString('\n')
+	if err != nil && userInput == "" {
+		fmt.Println()
+		return defaultValue
+	}
+
+	answer := strings.TrimSpace(userInput)
+	if answer == "" {
+		return defaultValue
+	}
+	return answer
+}
+
 func Init_Init_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	var force bool
+	var interactive bool
+	var yes bool
 
 	var initCmd = &cobra.Command{
 		Use:   "init [resource]",
@@ -63,11 +89,57 @@ func Init_Init_Command(parentCmd *cobra.Command, app *types.AppContext) {
 			app.Debug(fmt.Sprintf("Building content for '%v' file ...", gpmFileName))
 			initialGpmFile := types.GpmFile{
 				Files: []string{},
-				Scripts: map[string]string{
-					"test": "go test .",
+				Scripts: map[string]types.GpmFileScript{
+					testScriptName: {Run: "go test ."},
 				},
 			}
 
+			if interactive || yes {
+				projectName := path.Base(app.Cwd)
+				scriptCommands := map[string]string{
+					buildScriptName:           "go build .",
+					constants.StartScriptName: "go run .",
+					testScriptName:            "go test ./...",
+				}
+				filePatterns := ""
+
+				if !yes {
+					reader := bufio.NewReader(app.In)
+
+					fmt.Println("Press ENTER to accept a default value, use '-' for no value.")
+
+					projectName = ask_init_question(reader, "Project name", projectName)
+					for _, scriptName := range []string{buildScriptName, testScriptName, constants.StartScriptName} {
+						scriptCommands[scriptName] = ask_init_question(
+							reader,
+							fmt.Sprintf("Command of '%v' script", scriptName),
+							scriptCommands[scriptName],
+						)
+					}
+					filePatterns = ask_init_question(reader, "Comma-separated file patterns for pack command", filePatterns)
+				}
+
+				initialGpmFile.Name = ""
+				if projectName != "-" {
+					initialGpmFile.Name = strings.TrimSpace(projectName)
+				}
+
+				initialGpmFile.Scripts = map[string]types.GpmFileScript{}
+				for scriptName, scriptCommand := range scriptCommands {
+					scriptCommand = strings.TrimSpace(scriptCommand)
+					if scriptCommand != "" && scriptCommand != "-" {
+						initialGpmFile.Scripts[scriptName] = types.GpmFileScript{Run: scriptCommand}
+					}
+				}
+
+				for _, pattern := range strings.Split(filePatterns, ",") {
+					pattern = strings.TrimSpace(pattern)
+					if pattern != "" && pattern != "-" {
+						initialGpmFile.Files = append(initialGpmFile.Files, pattern)
+					}
+				}
+			}
+
 			app.Debug(fmt.Sprintf("Serializing content of '%v' file to YAML ...", gpmFileName))
 			yamlData, err := yaml.Marshal(&initialGpmFile)
 			utils.CheckForError(err)
@@ -81,6 +153,8 @@ func Init_Init_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	}
 
 	initCmd.PersistentFlags().BoolVarP(&force, "force", "f", false, "overwrite existing resource")
+	initCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "ask for values of gpm.yaml")
+	initCmd.Flags().BoolVarP(&yes, "yes", "y", false, "accept default values of interactive mode")
 
 	parentCmd.AddCommand(
 		initCmd,
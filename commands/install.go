@@ -23,13 +23,122 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	"github.com/mkloubert/go-package-manager/constants"
 	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
 )
 
+// installModule - a module to install by `install` command
+type installModule struct {
+	err  error  // the error, if installation failed
+	name string // the name or alias from the arguments
+	url  string // the resolved module URL
+}
+
+// download_modules_parallel() - downloads modules with `go mod download`
+// by a pool of `jobs` workers and stores errors in the items of `modules`
+func download_modules_parallel(app *types.AppContext, modules []*installModule, jobs int) {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	downloadBar := app.NewProgressBar(
+		len(modules),
+		fmt.Sprintf("Downloading %v module(s) ...", len(modules)),
+	)
+
+	queue := make(chan *installModule)
+	var barMutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < jobs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for m := range queue {
+				moduleWithVersion := m.url
+				if !strings.Contains(moduleWithVersion, "@") {
+					moduleWithVersion += "@latest"
+				}
+
+				p := exec.Command("go", "mod", "download", moduleWithVersion)
+				p.Dir = app.Cwd
+
+				output, err := p.CombinedOutput()
+				if err != nil {
+					m.err = fmt.Errorf("%v: %v", err, strings.TrimSpace(string(output)))
+				}
+
+				barMutex.Lock()
+				downloadBar.Add(1)
+				barMutex.Unlock()
+			}
+		}()
+	}
+
+	for _, m := range modules {
+		queue <- m
+	}
+	close(queue)
+
+	wg.Wait()
+
+	if !app.Quiet {
+		fmt.Println()
+	}
+}
+
+// get_install_hook() - returns the name of the post-install script
+// of a module, if defined in `hooks` section of gpm.yaml
+func get_install_hook(app *types.AppContext, moduleName string, moduleUrl string) (string, bool) {
+	modulePath, _, _ := strings.Cut(moduleUrl, "@")
+
+	for _, key := range []string{modulePath, moduleUrl, strings.TrimSpace(moduleName)} {
+		scriptName, ok := app.GpmFile.Hooks[key]
+		if ok && strings.TrimSpace(scriptName) != "" {
+			return strings.TrimSpace(scriptName), true
+		}
+	}
+
+	return "", false
+}
+
+// install_gpm_tools() - installs development tools like
+// `golang.org/x/tools/cmd/stringer@latest` into the bin folder via `go install`
+func install_gpm_tools(app *types.AppContext, tools []string) {
+	binPath, err := app.GetBinFolderPath()
+	utils.CheckForError(err)
+
+	err = os.MkdirAll(binPath, constants.DefaultDirMode)
+	utils.CheckForError(err)
+
+	for _, t := range tools {
+		tool := strings.TrimSpace(t)
+		if tool == "" {
+			continue
+		}
+		if !strings.Contains(tool, "@") {
+			tool += "@latest"
+		}
+
+		app.Debug(fmt.Sprintf("Installing tool '%v' to '%v' ...", tool, binPath))
+		app.RunShellCommandByArgsWithEnv([]string{"GOBIN=" + binPath}, "go", "install", tool)
+	}
+}
+
 func Init_Install_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var jobs int
+	var noHooks bool
 	var noPostScript bool
 	var noPreScript bool
 	var noTidyScript bool
@@ -41,30 +150,107 @@ func Init_Install_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		Use:     "install [module name or url]",
 		Aliases: []string{"i", "inst"},
 		Short:   "Installs one or more modules",
-		Long:    `Gets and installs one or more modules by a short name or a valid URL to a git repository.`,
-		Args:    cobra.MinimumNArgs(1),
+		Long:    `Gets and installs one or more modules by a short name or a valid URL to a git repository, or all tools from gpm.yaml if no module is defined.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if !noPreScript {
-				_, ok := app.GpmFile.Scripts[constants.PreInstallScriptName]
+				ok := app.HasScript(constants.PreInstallScriptName)
 				if ok {
 					app.RunScript(constants.PreInstallScriptName)
 				}
 			}
 
+			if len(args) == 0 {
+				if len(app.GpmFile.Tools) == 0 {
+					app.Warn("No tools defined in gpm.yaml")
+				} else {
+					install_gpm_tools(app, app.GpmFile.Tools)
+				}
+			}
+
+			// resolve aliases
+			modules := []*installModule{}
 			for _, moduleName := range args {
-				urls := app.GetModuleUrls(moduleName)
+				for _, u := range app.GetModuleUrls(moduleName) {
+					modules = append(modules, &installModule{
+						name: moduleName,
+						url:  u,
+					})
+				}
+			}
+
+			if !noHooks {
+				// check before anything is installed
+				for _, m := range modules {
+					scriptName, ok := get_install_hook(app, m.name, m.url)
+					if ok && !app.HasScript(scriptName) {
+						utils.CloseWithError(fmt.Errorf("script '%v' of hook for module '%v' not found", scriptName, m.url))
+					}
+				}
+			}
+
+			runHook := func(m *installModule) {
+				if !noHooks {
+					scriptName, ok := get_install_hook(app, m.name, m.url)
+					if ok {
+						app.Info(fmt.Sprintf("Running hook '%v' for module '%v' ...", scriptName, m.url))
+						app.RunScript(scriptName)
+					}
+				}
+			}
+
+			getArgs := []string{"get"}
+			if !noUpdate {
+				getArgs = append(getArgs, "-u")
+			}
+
+			if len(modules) == 1 || app.DryRun {
+				for _, m := range modules {
+					app.RunShellCommandByArgs("go", append(getArgs, m.url)...)
+					runHook(m)
+				}
+			} else if len(modules) > 1 {
+				// download in parallel first ...
+				download_modules_parallel(app, modules, jobs)
+
+				// ... then update go.mod one after another
+				for _, m := range modules {
+					if m.err != nil {
+						continue
+					}
+
+					app.Debug(fmt.Sprintf("Running 'go %v %v' ...", strings.Join(getArgs, " "), m.url))
+					p := exec.Command("go", append(getArgs, m.url)...)
+					p.Dir = app.Cwd
 
-				for _, u := range urls {
-					if noUpdate {
-						app.RunShellCommandByArgs("go", "get", u)
+					output, err := p.CombinedOutput()
+					if err != nil {
+						m.err = fmt.Errorf("%v: %v", err, strings.TrimSpace(string(output)))
+						continue
+					}
+
+					runHook(m)
+				}
+
+				green := color.New(color.FgGreen).SprintFunc()
+				red := color.New(color.FgRed).SprintFunc()
+
+				failedCount := 0
+				for _, m := range modules {
+					if m.err == nil {
+						fmt.Printf("\t[%s] %v%s", green("✓"), m.url, fmt.Sprintln())
 					} else {
-						app.RunShellCommandByArgs("go", "get", "-u", u)
+						failedCount++
+						fmt.Printf("\t[%s] %v: %v%s", red("!"), m.url, m.err, fmt.Sprintln())
 					}
 				}
+
+				if failedCount > 0 {
+					utils.CloseWithError(fmt.Errorf("%v of %v module(s) could not be installed", failedCount, len(modules)))
+				}
 			}
 
 			if !noPostScript {
-				_, ok := app.GpmFile.Scripts[constants.PostInstallScriptName]
+				ok := app.HasScript(constants.PostInstallScriptName)
 				if ok {
 					app.RunScript(constants.PostInstallScriptName)
 				}
@@ -77,8 +263,11 @@ func Init_Install_Command(parentCmd *cobra.Command, app *types.AppContext) {
 				})
 			}
 		},
+		ValidArgsFunction: utils.CreateCompletionFunc(app.GetAliasNames),
 	}
 
+	installCmd.Flags().IntVarP(&jobs, "jobs", "j", 4, "maximum number of parallel downloads")
+	installCmd.Flags().BoolVarP(&noHooks, "no-hooks", "", false, "do not run post-install hooks of modules")
 	installCmd.Flags().BoolVarP(&noPostScript, "no-post-script", "", false, "do not handle '"+constants.PostInstallScriptName+"' script")
 	installCmd.Flags().BoolVarP(&noPreScript, "no-pre-script", "", false, "do not handle '"+constants.PreInstallScriptName+"' script")
 	installCmd.Flags().BoolVarP(&noPreScript, "no-tidy-script", "", false, "do not handle '"+constants.TidyScriptName+"' script")
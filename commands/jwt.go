@@ -0,0 +1,264 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/chroma/quick"
+	"github.com/fatih/color"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+// decode_jwt_part() - decodes the Base64 URL encoded header or payload of a JWT
+func decode_jwt_part(part string) (map[string]interface{}, error) {
+	data, err := decode_base64_any(part)
+	if err != nil {
+		return nil, err
+	}
+
+	var value map[string]interface{}
+	err = json.Unmarshal(data, &value)
+
+	return value, err
+}
+
+// get_jwt_hash() - returns the hash function of a JWT algorithm like `HS256`
+func get_jwt_hash(alg string) (crypto.Hash, error) {
+	if len(alg) == 5 {
+		switch alg[2:] {
+		case "256":
+			return crypto.SHA256, nil
+		case "384":
+			return crypto.SHA384, nil
+		case "512":
+			return crypto.SHA512, nil
+		}
+	}
+
+	return 0, fmt.Errorf("algorithm '%v' not supported", alg)
+}
+
+// load_jwt_public_key() - loads a public key from PEM data
+// of a public key or certificate
+func load_jwt_public_key(pemData []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("no supported public key or certificate found")
+	}
+
+	return cert.PublicKey, nil
+}
+
+// verify_jwt_signature() - verifies the signature of a JWT with a
+// secret (HS*) or a public key (RS*, PS*, ES*)
+func verify_jwt_signature(alg string, signingInput string, signature []byte, secret string, key crypto.PublicKey) error {
+	hashFunc, err := get_jwt_hash(alg)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(alg, "HS") {
+		if secret == "" {
+			return fmt.Errorf("--secret required for '%v'", alg)
+		}
+
+		mac := hmac.New(hashFunc.New, []byte(secret))
+		mac.Write([]byte(signingInput))
+
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	}
+
+	if key == nil {
+		return fmt.Errorf("--key required for '%v'", alg)
+	}
+
+	hasher := hashFunc.New()
+	hasher.Write([]byte(signingInput))
+	hashed := hasher.Sum(nil)
+
+	switch alg[:2] {
+	case "RS", "PS":
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("RSA public key required for '%v'", alg)
+		}
+
+		if alg[:2] == "PS" {
+			return rsa.VerifyPSS(rsaKey, hashFunc, hashed, signature, &rsa.PSSOptions{
+				SaltLength: rsa.PSSSaltLengthEqualsHash,
+			})
+		}
+		return rsa.VerifyPKCS1v15(rsaKey, hashFunc, hashed, signature)
+	case "ES":
+		ecdsaKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("ECDSA public key required for '%v'", alg)
+		}
+
+		// signature is R and S with fixed sizes
+		keySize := (ecdsaKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*keySize {
+			return fmt.Errorf("invalid signature size")
+		}
+
+		r := new(big.Int).SetBytes(signature[:keySize])
+		s := new(big.Int).SetBytes(signature[keySize:])
+		if !ecdsa.Verify(ecdsaKey, hashed, r, s) {
+			return fmt.Errorf("invalid signature")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("algorithm '%v' not supported", alg)
+}
+
+func Init_Jwt_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var keyFile string
+	var secret string
+
+	var jwtCmd = &cobra.Command{
+		Use:   "jwt [token]",
+		Short: "Inspect JWT",
+		Long:  `Decodes header and payload of a JSON Web Token from argument or STDIN and optionally verifies its signature.`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			token := ""
+			if len(args) > 0 {
+				token = args[0]
+			} else {
+				stdin, err := utils.LoadFromSTDINIfAvailable()
+				utils.CheckForError(err)
+
+				if stdin != nil {
+					token = string(*stdin)
+				}
+			}
+			token = strings.TrimPrefix(strings.TrimSpace(token), "Bearer ")
+
+			parts := strings.Split(token, ".")
+			if len(parts) != 3 {
+				utils.CloseWithError(fmt.Errorf("no valid JWT"))
+			}
+
+			header, err := decode_jwt_part(parts[0])
+			utils.CheckForError(err)
+
+			payload, err := decode_jwt_part(parts[1])
+			utils.CheckForError(err)
+
+			jsonData, err := json.MarshalIndent(map[string]interface{}{
+				"header":  header,
+				"payload": payload,
+			}, "", "  ")
+			utils.CheckForError(err)
+
+			jsonStr := string(jsonData)
+			if utils.IsTerminal(app.Out) {
+				err = quick.Highlight(app.Out, jsonStr, "json", utils.GetBestChromaFormatterName(), utils.GetBestChromaStyleName())
+				if err != nil {
+					fmt.Fprint(app.Out, jsonStr)
+				}
+			} else {
+				fmt.Fprint(app.Out, jsonStr)
+			}
+			fmt.Fprintln(app.Out)
+
+			now := app.Now()
+			if exp, ok := payload["exp"].(float64); ok {
+				expiresAt := time.Unix(int64(exp), 0)
+				if now.After(expiresAt) {
+					app.Warn(fmt.Sprintf("Token expired at %v", expiresAt.UTC().Format(time.RFC3339)))
+				}
+			}
+			if nbf, ok := payload["nbf"].(float64); ok {
+				notBefore := time.Unix(int64(nbf), 0)
+				if now.Before(notBefore) {
+					app.Warn(fmt.Sprintf("Token not valid before %v", notBefore.UTC().Format(time.RFC3339)))
+				}
+			}
+
+			keyFile = strings.TrimSpace(keyFile)
+			if secret == "" && keyFile == "" {
+				return // no verification
+			}
+
+			var key crypto.PublicKey
+			if keyFile != "" {
+				pemData, err := os.ReadFile(app.GetFullPathOrDefault(keyFile, ""))
+				utils.CheckForError(err)
+
+				key, err = load_jwt_public_key(pemData)
+				utils.CheckForError(err)
+			}
+
+			signature, err := decode_base64_any(parts[2])
+			utils.CheckForError(err)
+
+			alg, _ := header["alg"].(string)
+
+			err = verify_jwt_signature(alg, parts[0]+"."+parts[1], signature, secret, key)
+			if err != nil {
+				fmt.Fprintf(app.ErrorOut, "[%s] Signature: %v%s", color.New(color.FgRed).Sprint("✗"), err, fmt.Sprintln())
+				utils.Exit(1)
+			}
+
+			fmt.Fprintf(app.ErrorOut, "[%s] Signature valid (%v)%s", color.New(color.FgGreen).Sprint("✓"), alg, fmt.Sprintln())
+		},
+	}
+
+	jwtCmd.Flags().StringVarP(&keyFile, "key", "", "", "PEM file with public key or certificate for RS*, PS* or ES* algorithms")
+	jwtCmd.Flags().StringVarP(&secret, "secret", "", "", "secret for HS* algorithms")
+
+	parentCmd.AddCommand(
+		jwtCmd,
+	)
+}
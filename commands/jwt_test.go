@@ -0,0 +1,71 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+func TestJwtCommandWithSecret(t *testing.T) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"gpm"}`))
+
+	mac := hmac.New(sha256.New, []byte("my-secret"))
+	mac.Write([]byte(header + "." + payload))
+	token := header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	var out bytes.Buffer
+	var errorOut bytes.Buffer
+
+	app := &types.AppContext{
+		ErrorOut: &errorOut,
+		Out:      &out,
+	}
+
+	rootCmd := &cobra.Command{Use: "gpm"}
+	Init_Jwt_Command(rootCmd, app)
+
+	rootCmd.SetArgs([]string{"jwt", token, "--secret=my-secret"})
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), `"sub": "gpm"`) {
+		t.Errorf("expected payload in output, got %q", out.String())
+	}
+	if strings.Contains(out.String(), "Signature") {
+		t.Errorf("signature result should not be part of output, got %q", out.String())
+	}
+	if !strings.Contains(errorOut.String(), "Signature valid (HS256)") {
+		t.Errorf("expected signature result in error output, got %q", errorOut.String())
+	}
+}
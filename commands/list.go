@@ -23,27 +23,96 @@
 package commands
 
 import (
+	"debug/buildinfo"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
+	"github.com/goccy/go-yaml"
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
-func init_list_aliases_command(parentCmd *cobra.Command, app *types.AppContext) {
+// listSettings stores the settings of `list` command,
+// which are shared by all sub commands
+type listSettings struct {
+	filter string // substring filter
+	json   bool   // output as JSON
+	yaml   bool   // output as YAML
+}
+
+func (s *listSettings) matches(values ...string) bool {
+	filter := strings.TrimSpace(strings.ToLower(s.filter))
+	if filter == "" {
+		return true
+	}
+
+	for _, v := range values {
+		if strings.Contains(strings.ToLower(v), filter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// outputStructured() - outputs items as JSON or YAML and returns `true`
+// if one of these formats has been selected
+func (s *listSettings) outputStructured(items interface{}) bool {
+	if s.json {
+		jsonData, err := json.MarshalIndent(items, "", "  ")
+		utils.CheckForError(err)
+
+		fmt.Println(string(jsonData))
+		return true
+	}
+	if s.yaml {
+		yamlData, err := yaml.Marshal(items)
+		utils.CheckForError(err)
+
+		fmt.Print(string(yamlData))
+		return true
+	}
+
+	return false
+}
+
+func init_list_aliases_command(parentCmd *cobra.Command, app *types.AppContext, settings *listSettings) {
+	type aliasItem struct {
+		Name    string   `json:"name" yaml:"name"`
+		Sources []string `json:"sources" yaml:"sources"`
+	}
+
 	var listAliasesCmd = &cobra.Command{
 		Use:     "aliases",
 		Aliases: []string{"a", "alias"},
 		Short:   "List package aliases",
 		Long:    `Lists (all) aliases.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			for alias, sources := range app.AliasesFile.Aliases {
-				fmt.Printf("%v%v", alias, fmt.Sprintln())
+			items := []aliasItem{}
+			for _, alias := range app.GetAliasNames() {
+				sources := app.AliasesFile.Aliases[alias]
+
+				if settings.matches(append([]string{alias}, sources...)...) {
+					items = append(items, aliasItem{
+						Name:    alias,
+						Sources: sources,
+					})
+				}
+			}
+
+			if settings.outputStructured(items) {
+				return
+			}
+
+			for _, item := range items {
+				fmt.Printf("%v%v", item.Name, fmt.Sprintln())
 
-				for _, s := range sources {
+				for _, s := range item.Sources {
 					fmt.Printf("\t%v%v", s, fmt.Sprintln())
 				}
 			}
@@ -55,7 +124,16 @@ func init_list_aliases_command(parentCmd *cobra.Command, app *types.AppContext)
 	)
 }
 
-func init_list_binaries_command(parentCmd *cobra.Command, app *types.AppContext) {
+func init_list_binaries_command(parentCmd *cobra.Command, app *types.AppContext, settings *listSettings) {
+	type binaryItem struct {
+		Module  string `json:"module,omitempty" yaml:"module,omitempty"`
+		Name    string `json:"name" yaml:"name"`
+		Package string `json:"package,omitempty" yaml:"package,omitempty"`
+		Path    string `json:"path" yaml:"path"`
+		Size    int64  `json:"size" yaml:"size"`
+		Version string `json:"version,omitempty" yaml:"version,omitempty"`
+	}
+
 	var listAliasesCmd = &cobra.Command{
 		Use:     "binaries",
 		Aliases: []string{"b", "bin", "binary", "bin"},
@@ -68,7 +146,9 @@ func init_list_binaries_command(parentCmd *cobra.Command, app *types.AppContext)
 			isBinPathExisting, err := utils.IsDirExisting(binPath)
 			utils.CheckForError(err)
 
+			items := []binaryItem{}
 			if !isBinPathExisting {
+				settings.outputStructured(items)
 				return
 			}
 
@@ -79,14 +159,48 @@ func init_list_binaries_command(parentCmd *cobra.Command, app *types.AppContext)
 				return strings.ToLower(binEntries[indexX].Name()) < strings.ToLower(binEntries[indexY].Name())
 			})
 
-			fmt.Println(binPath)
-
 			for _, entry := range binEntries {
 				if entry.IsDir() {
 					continue
 				}
 
-				fmt.Printf("\t%v%v", entry.Name(), fmt.Sprintln())
+				item := binaryItem{
+					Name: entry.Name(),
+					Path: filepath.Join(binPath, entry.Name()),
+				}
+
+				info, err := entry.Info()
+				if err == nil {
+					item.Size = info.Size()
+				}
+
+				// source of Go executables, like the ones built by `make`
+				buildInfo, err := buildinfo.ReadFile(item.Path)
+				if err == nil {
+					item.Module = buildInfo.Main.Path
+					item.Package = buildInfo.Path
+					item.Version = buildInfo.Main.Version
+				} else {
+					app.Debug(fmt.Sprintf("Could not read build info of '%v': %v", item.Path, err))
+				}
+
+				if settings.matches(item.Name, item.Module, item.Package) {
+					items = append(items, item)
+				}
+			}
+
+			if settings.outputStructured(items) {
+				return
+			}
+
+			fmt.Println(binPath)
+
+			for _, item := range items {
+				fmt.Printf("\t%v (%v)%v", item.Name, utils.FormatByteSize(item.Size), fmt.Sprintln())
+
+				if item.Module != "" {
+					fmt.Printf("\t\t%v %v%v", item.Module, item.Version, fmt.Sprintln())
+				}
 			}
 		},
 	}
@@ -96,16 +210,91 @@ func init_list_binaries_command(parentCmd *cobra.Command, app *types.AppContext)
 	)
 }
 
-func init_list_projects_command(parentCmd *cobra.Command, app *types.AppContext) {
+func init_list_modules_command(parentCmd *cobra.Command, app *types.AppContext, settings *listSettings) {
+	type moduleItem struct {
+		Direct   bool   `json:"direct" yaml:"direct"`
+		Indirect bool   `json:"indirect" yaml:"indirect"`
+		Path     string `json:"path" yaml:"path"`
+		Version  string `json:"version" yaml:"version"`
+	}
+
+	var listModulesCmd = &cobra.Command{
+		Use:     "modules",
+		Aliases: []string{"m", "mod", "mods", "module"},
+		Short:   "List modules",
+		Long:    `Lists (all) modules the current project depends on.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			modules, err := app.GetGoModules()
+			utils.CheckForError(err)
+
+			items := []moduleItem{}
+			for _, m := range modules {
+				if m.Path == nil || m.Version == nil {
+					continue // main module
+				}
+
+				isIndirect := m.Indirect != nil && *m.Indirect
+
+				if settings.matches(*m.Path, *m.Version) {
+					items = append(items, moduleItem{
+						Direct:   !isIndirect,
+						Indirect: isIndirect,
+						Path:     *m.Path,
+						Version:  *m.Version,
+					})
+				}
+			}
+
+			if settings.outputStructured(items) {
+				return
+			}
+
+			for _, item := range items {
+				if item.Indirect {
+					fmt.Printf("%v %v (indirect)%v", item.Path, item.Version, fmt.Sprintln())
+				} else {
+					fmt.Printf("%v %v%v", item.Path, item.Version, fmt.Sprintln())
+				}
+			}
+		},
+	}
+
+	parentCmd.AddCommand(
+		listModulesCmd,
+	)
+}
+
+func init_list_projects_command(parentCmd *cobra.Command, app *types.AppContext, settings *listSettings) {
+	type projectItem struct {
+		Name   string `json:"name" yaml:"name"`
+		Source string `json:"source" yaml:"source"`
+	}
+
 	var listProjectsCmd = &cobra.Command{
 		Use:     "projects",
 		Aliases: []string{"p", "prj", "project", "prjs"},
 		Short:   "List projects",
 		Long:    `Lists (all) projects with their Git resources.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			for alias, gitResource := range app.ProjectsFile.Projects {
-				fmt.Printf("%v%v", alias, fmt.Sprintln())
-				fmt.Printf("\t%v%v", gitResource, fmt.Sprintln())
+			items := []projectItem{}
+			for _, alias := range app.GetProjectNames() {
+				gitResource := app.ProjectsFile.Projects[alias]
+
+				if settings.matches(alias, gitResource) {
+					items = append(items, projectItem{
+						Name:   alias,
+						Source: gitResource,
+					})
+				}
+			}
+
+			if settings.outputStructured(items) {
+				return
+			}
+
+			for _, item := range items {
+				fmt.Printf("%v%v", item.Name, fmt.Sprintln())
+				fmt.Printf("\t%v%v", item.Source, fmt.Sprintln())
 			}
 		},
 	}
@@ -115,7 +304,62 @@ func init_list_projects_command(parentCmd *cobra.Command, app *types.AppContext)
 	)
 }
 
+func init_list_scripts_command(parentCmd *cobra.Command, app *types.AppContext, settings *listSettings) {
+	type scriptItem struct {
+		Name  string   `json:"name" yaml:"name"`
+		Needs []string `json:"needs" yaml:"needs"`
+		Run   string   `json:"run" yaml:"run"`
+	}
+
+	var listScriptsCmd = &cobra.Command{
+		Use:     "scripts",
+		Aliases: []string{"s", "script"},
+		Short:   "List scripts",
+		Long:    `Lists (all) scripts of the gpm.yaml file.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			names := make([]string, 0, len(app.GpmFile.Scripts))
+			for name := range app.GpmFile.Scripts {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			items := []scriptItem{}
+			for _, name := range names {
+				script := app.GpmFile.Scripts[name]
+
+				needs := script.Needs
+				if needs == nil {
+					needs = []string{}
+				}
+
+				if settings.matches(name, script.Run) {
+					items = append(items, scriptItem{
+						Name:  name,
+						Needs: needs,
+						Run:   script.Run,
+					})
+				}
+			}
+
+			if settings.outputStructured(items) {
+				return
+			}
+
+			for _, item := range items {
+				fmt.Printf("%v%v", item.Name, fmt.Sprintln())
+				fmt.Printf("\t%v%v", item.Run, fmt.Sprintln())
+			}
+		},
+	}
+
+	parentCmd.AddCommand(
+		listScriptsCmd,
+	)
+}
+
 func Init_List_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var settings listSettings
+
 	var listCmd = &cobra.Command{
 		Use:     "list [resource]",
 		Aliases: []string{"l", "lst"},
@@ -126,9 +370,15 @@ func Init_List_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		},
 	}
 
-	init_list_aliases_command(listCmd, app)
-	init_list_binaries_command(listCmd, app)
-	init_list_projects_command(listCmd, app)
+	listCmd.PersistentFlags().StringVarP(&settings.filter, "filter", "", "", "only list items containing this text")
+	listCmd.PersistentFlags().BoolVarP(&settings.json, "json", "", false, "output as JSON")
+	listCmd.PersistentFlags().BoolVarP(&settings.yaml, "yaml", "", false, "output as YAML")
+
+	init_list_aliases_command(listCmd, app, &settings)
+	init_list_binaries_command(listCmd, app, &settings)
+	init_list_modules_command(listCmd, app, &settings)
+	init_list_projects_command(listCmd, app, &settings)
+	init_list_scripts_command(listCmd, app, &settings)
 
 	parentCmd.AddCommand(
 		listCmd,
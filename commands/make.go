@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/mkloubert/go-package-manager/constants"
@@ -34,135 +35,228 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// split_make_git_resource() - splits a `module@ref` argument into the
+// Git resource and the optional ref, like a branch, tag or commit
+func split_make_git_resource(projectNameOrUrl string) (string, string) {
+	atIndex := strings.LastIndex(projectNameOrUrl, "@")
+	if atIndex < 1 {
+		return projectNameOrUrl, ""
+	}
+
+	ref := projectNameOrUrl[atIndex+1:]
+	if ref == "" || strings.ContainsAny(ref, "/:") {
+		// something like `git@github.com:user/repo.git`
+		// or `https://user@example.com/repo.git`
+		return projectNameOrUrl, ""
+	}
+
+	return projectNameOrUrl[:atIndex], ref
+}
+
+// find_make_executable() - returns the path of the executable, which has been
+// built in `tempDir`, by the project name or the name of `tempDir`
+func find_make_executable(tempDir string, projectName string, name string, noAutoExt bool) (string, error) {
+	tempDirName := path.Base(tempDir)
+
+	// define possible executable file names
+	outExecutableFilenameByProject := strings.TrimSpace(name)
+	outExecutableFilenameByTempDir := tempDirName
+	if outExecutableFilenameByProject == "" {
+		outExecutableFilenameByProject = projectName
+	}
+	if !noAutoExt && utils.IsWindows() {
+		// Windows uses .exe
+
+		outExecutableFilenameByProject += constants.WindowsExecutableExt
+		outExecutableFilenameByTempDir += constants.WindowsExecutableExt
+	}
+
+	outExecutableFilePathByProject := path.Join(tempDir, outExecutableFilenameByProject)
+	outExecutableFilePathByTempDir := path.Join(tempDir, outExecutableFilenameByTempDir)
+
+	isOutExecutableFileByProjectExisting, err := utils.IsFileExisting(outExecutableFilePathByProject)
+	if err != nil {
+		return "", err
+	}
+	if isOutExecutableFileByProjectExisting {
+		// found executable file in repo
+		return outExecutableFilePathByProject, nil
+	}
+
+	// try to find executable by name of temp directory instead
+
+	isOutExecutableFileByTempDirNameExisting, err := utils.IsFileExisting(outExecutableFilePathByTempDir)
+	if err != nil {
+		return "", err
+	}
+	if isOutExecutableFileByTempDirNameExisting {
+		return outExecutableFilePathByTempDir, nil
+	}
+
+	return "", fmt.Errorf("no matching executable file found. use --executable flag to specify")
+}
+
+// make_project() - clones a Git resource into a temp folder, builds it and moves
+// the executable into bin folder; returns the name of the file in bin folder
+func make_project(app *types.AppContext, gitResource string, ref string, additionalBuildArgs []string, name string, executable string, noAutoExt bool) string {
+	app.Debug(fmt.Sprintf("Will make project from '%v' ...", gitResource))
+
+	// get `<GPM-ROOT>/bin` folder
+	binPath, err := app.EnsureBinFolder()
+	utils.CheckForError(err)
+
+	// current executable path
+	selfPath, err := os.Executable()
+	utils.CheckForError(err)
+
+	// get project name from git resource
+	projectName := strings.TrimSuffix(
+		path.Base(gitResource), ".git",
+	)
+
+	// create temp folder where to clone
+	// git repo to
+	tempDir, err := os.MkdirTemp("", "*-"+projectName)
+	utils.CheckForError(err)
+	defer func() {
+		app.Debug(fmt.Sprintf("Removing folder '%v' ...", tempDir))
+		os.RemoveAll(tempDir)
+	}()
+
+	// clone repo
+	app.CloneGitRepositoryAt(gitResource, tempDir, ref)
+
+	buildArgs := []string{selfPath, "build"}
+	buildArgs = append(buildArgs, additionalBuildArgs...)
+
+	p := utils.CreateShellCommandByArgs(buildArgs[0], buildArgs[1:]...)
+	p.Dir = tempDir
+	// run `gpm build` in cloned repository
+	app.Debug(fmt.Sprintf("Running '%v' in '%v' ...", strings.Join(buildArgs, " "), p.Dir))
+	utils.RunCommand(p)
+
+	buildExecutableFilePath, err := find_make_executable(tempDir, projectName, name, noAutoExt)
+	utils.CheckForError(err)
+
+	executableNameInBinFolder := strings.TrimSpace(executable)
+	if executableNameInBinFolder == "" {
+		// use project name as default for the
+		// name of the final executable file in
+		// <GPM-ROOT>/bin folder
+		executableNameInBinFolder = projectName
+	}
+
+	executableFileInBinFolder := path.Join(binPath, executableNameInBinFolder)
+
+	isExecutableFileInBinFolderExisting, err := utils.IsFileExisting(executableFileInBinFolder)
+	utils.CheckForError(err)
+
+	if isExecutableFileInBinFolderExisting {
+		app.Debug(fmt.Sprintf("Removing executable '%v' ...", executableFileInBinFolder))
+		os.Remove(executableFileInBinFolder)
+	}
+
+	// move build executable to <GPM-ROOT>/bin folder
+	app.Debug(fmt.Sprintf("Moving build executable '%v' to '%v' ...", buildExecutableFilePath, executableFileInBinFolder))
+	err = os.Rename(buildExecutableFilePath, executableFileInBinFolder)
+	utils.CheckForError(err)
+
+	// make file in <GPM-ROOT>/bin folder executable
+	app.Debug(fmt.Sprintf("Setting up permissions for '%v' executable ...", executableFileInBinFolder))
+	err = os.Chmod(executableFileInBinFolder, constants.DefaultDirMode)
+	utils.CheckForError(err)
+
+	return executableNameInBinFolder
+}
+
 func Init_Make_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	var executable string
 	var name string
 	var noAutoExt bool
+	var ref string
+	var update bool
 
 	var makeCmd = &cobra.Command{
-		Use:     "make [git resource]",
+		Use:     "make [git resource[@ref] | executable]",
 		Aliases: []string{"m", "mk"},
 		Short:   "Make project",
-		Long:    `Downloads a Git repository and build it.`,
+		Long:    `Downloads a Git repository and build it or rebuilds installed executables from their sources.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			for _, projectNameOrUrl := range args {
-				gitResource, ok := app.ProjectsFile.Projects[projectNameOrUrl]
-				if !ok {
-					gitResource = projectNameOrUrl
-				}
+			manifest, err := app.LoadMakeManifestFile()
+			utils.CheckForError(err)
 
-				func() {
-					app.Debug(fmt.Sprintf("Will make project from '%v' ...", gitResource))
-
-					// get `<GPM-ROOT>/bin` folder
-					binPath, err := app.EnsureBinFolder()
-					utils.CheckForError(err)
-
-					// current executable path
-					selfPath, err := os.Executable()
-					utils.CheckForError(err)
-
-					// get project name from git resource
-					projectName := strings.TrimSuffix(
-						path.Base(gitResource), ".git",
-					)
-
-					// create temp folder where to clone
-					// git repo to
-					tempDir, err := os.MkdirTemp("", "*-"+projectName)
-					utils.CheckForError(err)
-					defer func() {
-						app.Debug(fmt.Sprintf("Removing folder '%v' ...", tempDir))
-						os.RemoveAll(tempDir)
-					}()
-
-					tempDirName := path.Base(tempDir)
-
-					// clone repo
-					app.Debug(fmt.Sprintf("Cloning '%v' to '%v' ...", gitResource, tempDir))
-					app.RunShellCommandByArgs("git", "clone", "--depth", "1", gitResource, tempDir)
-
-					buildArgs := []string{selfPath, "build"}
-					buildArgs = append(buildArgs, args[1:]...)
-
-					p := utils.CreateShellCommandByArgs(buildArgs[0], buildArgs[1:]...)
-					p.Dir = tempDir
-					// run `gpm build` in cloned repository
-					app.Debug(fmt.Sprintf("Running '%v' in '%v' ...", strings.Join(buildArgs, " "), p.Dir))
-					utils.RunCommand(p)
-
-					// define possible executable file names
-					outExecutableFilenameByProject := strings.TrimSpace(name)
-					outExecutableFilenameByTempDir := tempDirName
-					if outExecutableFilenameByProject == "" {
-						outExecutableFilenameByProject = projectName
-					}
-					if !noAutoExt && utils.IsWindows() {
-						// Windows uses .exe
+			if update || len(args) == 0 {
+				// rebuild from recorded sources
 
-						outExecutableFilenameByProject += constants.WindowsExecutableExt
-						outExecutableFilenameByTempDir += constants.WindowsExecutableExt
+				executableNames := args
+				if len(executableNames) == 0 {
+					for executableName := range manifest.Executables {
+						executableNames = append(executableNames, executableName)
 					}
+					sort.Strings(executableNames)
+				}
 
-					outExecutableFilePathByProject := path.Join(tempDir, outExecutableFilenameByProject)
-					outExecutableFilePathByTempDir := path.Join(tempDir, outExecutableFilenameByTempDir)
-
-					isOutExecutableFileByProjectExisting, err := utils.IsFileExisting(outExecutableFilePathByProject)
-					utils.CheckForError(err)
+				if len(executableNames) == 0 {
+					fmt.Println("No executables installed by make command found")
+					return
+				}
 
-					var buildExecutableFilePath string
+				for _, executableName := range executableNames {
+					entry, ok := manifest.Executables[executableName]
+					if !ok {
+						utils.CloseWithError(fmt.Errorf("no source found for executable '%v'", executableName))
+					}
 
-					if isOutExecutableFileByProjectExisting {
-						// found executable file in repo
-						buildExecutableFilePath = outExecutableFilePathByProject
-					} else {
-						// try to find executable by name of temp directory instead
+					entryRef := strings.TrimSpace(ref)
+					if entryRef == "" {
+						entryRef = entry.Ref
+					}
 
-						isOutExecutableFileByTempDirNameExisting, err := utils.IsFileExisting(outExecutableFilenameByTempDir)
-						utils.CheckForError(err)
+					fmt.Printf("Updating '%v' from '%v' ...%v", executableName, entry.Source, fmt.Sprintln())
+					make_project(app, entry.Source, entryRef, []string{}, entry.Name, executableName, entry.NoAutoExtension)
 
-						if isOutExecutableFileByTempDirNameExisting {
-							buildExecutableFilePath = outExecutableFilePathByTempDir
-						} else {
-							utils.CloseWithError(fmt.Errorf("no matching executable file found. use --executable flag to specify"))
-						}
-					}
+					entry.Ref = entryRef
+					manifest.Executables[executableName] = entry
+				}
 
-					executableNameInBinFolder := strings.TrimSpace(executable)
-					if executableNameInBinFolder == "" {
-						// use project name as default for the
-						// name of the final executable file in
-						// <GPM-ROOT>/bin folder
-						executableNameInBinFolder = projectName
-					}
+				err = app.UpdateMakeManifestFile(manifest)
+				utils.CheckForError(err)
 
-					executableFileInBinFolder := path.Join(binPath, executableNameInBinFolder)
+				return
+			}
 
-					isExecutableFileInBinFolderExisting, err := utils.IsFileExisting(executableFileInBinFolder)
-					utils.CheckForError(err)
+			for _, arg := range args {
+				projectNameOrUrl, projectRef := split_make_git_resource(arg)
+				if projectRef == "" {
+					projectRef = strings.TrimSpace(ref)
+				}
 
-					if isExecutableFileInBinFolderExisting {
-						app.Debug(fmt.Sprintf("Removing executable '%v' ...", executableFileInBinFolder))
-						os.Remove(executableFileInBinFolder)
-					}
+				gitResource, ok := app.ProjectsFile.Projects[projectNameOrUrl]
+				if !ok {
+					gitResource = projectNameOrUrl
+				}
 
-					// move build executable to <GPM-ROOT>/bin folder
-					app.Debug(fmt.Sprintf("Moving build executable '%v' to '%v' ...", buildExecutableFilePath, executableFileInBinFolder))
-					err = os.Rename(buildExecutableFilePath, executableFileInBinFolder)
-					utils.CheckForError(err)
+				executableName := make_project(app, gitResource, projectRef, args[1:], name, executable, noAutoExt)
 
-					// make file in <GPM-ROOT>/bin folder executable
-					app.Debug(fmt.Sprintf("Setting up permissions for '%v' executable ...", executableFileInBinFolder))
-					err = os.Chmod(executableFileInBinFolder, constants.DefaultDirMode)
-					utils.CheckForError(err)
-				}()
+				manifest.Executables[executableName] = types.MakeManifestEntry{
+					Name:            strings.TrimSpace(name),
+					NoAutoExtension: noAutoExt,
+					Ref:             projectRef,
+					Source:          gitResource,
+				}
 			}
+
+			err = app.UpdateMakeManifestFile(manifest)
+			utils.CheckForError(err)
 		},
+		ValidArgsFunction: utils.CreateCompletionFunc(app.GetProjectNames),
 	}
 
+	makeCmd.Flags().StringVarP(&executable, "executable", "", "", "custom name of executable file in bin folder")
 	makeCmd.Flags().StringVarP(&name, "name", "", "", "custom name of output executable file")
 	makeCmd.Flags().BoolVarP(&noAutoExt, "no-auto-extension", "", false, "do not add file extension automatically")
-	makeCmd.Flags().StringVarP(&name, "executable", "", "", "custom name of executable file in bin folder")
+	makeCmd.Flags().StringVarP(&ref, "ref", "", "", "branch, tag or commit to checkout instead of default branch")
+	makeCmd.Flags().BoolVarP(&update, "update", "u", false, "rebuild all or specific executables in bin folder from their recorded sources")
 
 	parentCmd.AddCommand(
 		makeCmd,
@@ -0,0 +1,92 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindMakeExecutable(t *testing.T) {
+	createExecutable := func(dir string, name string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("#!/bin/sh\n"), 0750); err != nil {
+			t.Fatal(err)
+		}
+		return path.Join(dir, name)
+	}
+
+	t.Run("by project name", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expected := createExecutable(tempDir, "my-tool")
+		createExecutable(tempDir, path.Base(tempDir))
+
+		executablePath, err := find_make_executable(tempDir, "my-tool", "", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if executablePath != expected {
+			t.Errorf("expected '%v', got '%v'", expected, executablePath)
+		}
+	})
+
+	t.Run("by custom name", func(t *testing.T) {
+		tempDir := t.TempDir()
+		expected := createExecutable(tempDir, "custom")
+		createExecutable(tempDir, "my-tool")
+
+		executablePath, err := find_make_executable(tempDir, "my-tool", " custom ", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if executablePath != expected {
+			t.Errorf("expected '%v', got '%v'", expected, executablePath)
+		}
+	})
+
+	t.Run("by name of temp directory", func(t *testing.T) {
+		// `go build` names the executable after the directory,
+		// if there is no module path
+		tempDir := t.TempDir()
+		expected := createExecutable(tempDir, path.Base(tempDir))
+
+		executablePath, err := find_make_executable(tempDir, "my-tool", "", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if executablePath != expected {
+			t.Errorf("expected '%v', got '%v'", expected, executablePath)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		tempDir := t.TempDir()
+
+		_, err := find_make_executable(tempDir, "my-tool", "", true)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -23,31 +23,172 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/mkloubert/go-package-manager/constants"
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
+func is_git_template_source(source string) bool {
+	return strings.Contains(source, "://") ||
+		strings.HasPrefix(source, "git@") ||
+		strings.HasSuffix(source, ".git")
+}
+
+func new_project_from_template(app *types.AppContext, templateName string, args []string, noInit bool) {
+	source, ok := app.TemplatesFile.Templates[templateName]
+	if !ok {
+		utils.CloseWithError(fmt.Errorf("template '%v' not found", templateName))
+	}
+	source = strings.TrimSpace(source)
+
+	moduleName := utils.CleanupModuleName(args[0])
+	if moduleName == "" {
+		utils.CloseWithError(errors.New("no module name defined"))
+	}
+
+	outDir := path.Base(moduleName)
+	if len(args) > 1 {
+		outDir = strings.TrimSpace(args[1])
+	}
+	outDir = app.GetFullPathOrDefault(outDir, app.Cwd)
+
+	isOutDirExisting, err := utils.IsDirExisting(outDir)
+	utils.CheckForError(err)
+	if isOutDirExisting {
+		utils.CloseWithError(fmt.Errorf("directory '%v' already exists", outDir))
+	}
+
+	if is_git_template_source(source) {
+		app.CloneGitRepository(source, outDir)
+
+		gitDir := filepath.Join(outDir, ".git")
+
+		app.Debug(fmt.Sprintf("Removing '%v' folder ...", gitDir))
+		err = os.RemoveAll(gitDir)
+		utils.CheckForError(err)
+	} else {
+		srcDir := source
+		if !filepath.IsAbs(srcDir) {
+			// relative to templates.yaml file
+			templatesFilePath, err := app.GetTemplatesFilePath()
+			utils.CheckForError(err)
+
+			srcDir = filepath.Join(filepath.Dir(templatesFilePath), srcDir)
+		}
+
+		app.Debug(fmt.Sprintf("Copying '%v' to '%v' ...", srcDir, outDir))
+		err = utils.CopyDir(srcDir, outDir)
+		utils.CheckForError(err)
+	}
+
+	app.Debug(fmt.Sprintf("Replacing '%v' with '%v' ...", constants.TemplateModuleNamePlaceholder, moduleName))
+	err = replace_module_name_placeholder_in(outDir, moduleName)
+	utils.CheckForError(err)
+
+	goModFilePath := filepath.Join(outDir, "go.mod")
+	isGoModExisting, err := utils.IsFileExisting(goModFilePath)
+	utils.CheckForError(err)
+
+	if !isGoModExisting {
+		p := utils.CreateShellCommandByArgs("go", "mod", "init", moduleName)
+		p.Dir = outDir
+
+		app.Debug(fmt.Sprintf("Initializing module '%v' in '%v' ...", moduleName, outDir))
+		utils.RunCommand(p)
+	}
+
+	if !noInit {
+		p := utils.CreateShellCommandByArgs("git", "init")
+		p.Dir = outDir
+
+		app.Debug(fmt.Sprintf("Initializing git in '%v' folder ...", outDir))
+		utils.RunCommand(p)
+	}
+}
+
+func replace_module_name_placeholder_in(dir string, moduleName string) error {
+	placeholder := []byte(constants.TemplateModuleNamePlaceholder)
+
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		if !strings.Contains(string(data), string(placeholder)) {
+			return nil
+		}
+
+		newData := strings.ReplaceAll(string(data), string(placeholder), moduleName)
+
+		return os.WriteFile(p, []byte(newData), info.Mode().Perm())
+	})
+}
+
 func Init_New_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var list bool
 	var noInit bool
+	var template string
 
 	var newCmd = &cobra.Command{
 		Use:     "new [project name]",
 		Aliases: []string{"n", "nw"},
 		Short:   "New project",
-		Long:    `Initializes one project as defined in projects.yaml file.`,
-		Args:    cobra.MinimumNArgs(1),
+		Long:    `Initializes one project as defined in projects.yaml file or from a template defined in templates.yaml file.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if list {
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		Run: func(cmd *cobra.Command, args []string) {
+			if list {
+				names := make([]string, 0, len(app.TemplatesFile.Templates))
+				for name := range app.TemplatesFile.Templates {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+
+				for _, name := range names {
+					fmt.Printf("%s\t%s%s", name, app.TemplatesFile.Templates[name], fmt.Sprintln())
+				}
+				return
+			}
+
+			templateName := strings.TrimSpace(template)
+			if templateName != "" {
+				new_project_from_template(app, templateName, args, noInit)
+				return
+			}
+
 			projectName := strings.TrimSpace(args[0])
 
 			gitResource, ok := app.ProjectsFile.Projects[projectName]
 			if !ok {
-				utils.CloseWithError(fmt.Errorf("project '%v' not found", gitResource))
+				utils.CloseWithError(fmt.Errorf("project '%v' not found", projectName))
 			}
 
 			var gitDir string
@@ -78,7 +219,9 @@ func Init_New_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		},
 	}
 
+	newCmd.Flags().BoolVarP(&list, "list", "", false, "list all registered templates")
 	newCmd.Flags().BoolVarP(&noInit, "no-init", "n", false, "do not initialize git project")
+	newCmd.Flags().StringVarP(&template, "template", "t", "", "name of the template from templates.yaml file")
 
 	parentCmd.AddCommand(
 		newCmd,
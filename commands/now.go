@@ -24,41 +24,100 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
+// format_now_time() - formats a time with a Go layout or an alias
+// like `iso`, `rfc3339` or `unix`
+func format_now_time(t time.Time, format string) string {
+	switch strings.TrimSpace(strings.ToLower(format)) {
+	case "date":
+		return t.Format(time.DateOnly)
+	case "iso":
+		return t.Format("2006-01-02T15:04:05.000Z07:00")
+	case "kitchen":
+		return t.Format(time.Kitchen)
+	case "rfc1123":
+		return t.Format(time.RFC1123Z)
+	case "rfc3339":
+		return t.Format(time.RFC3339)
+	case "rfc3339nano":
+		return t.Format(time.RFC3339Nano)
+	case "rfc822":
+		return t.Format(time.RFC822Z)
+	case "time":
+		return t.Format(time.TimeOnly)
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unixmilli":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	case "unixnano":
+		return strconv.FormatInt(t.UnixNano(), 10)
+	}
+
+	return t.Format(format)
+}
+
 func Init_Now_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	var format string
 	var local bool
+	var timezone string
+	var utc bool
 
 	var nowCmd = &cobra.Command{
 		Use:   "now",
 		Short: "Output time",
 		Long:  `Outputs current time.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			now := time.Now()
-			if !local {
+			timezone = strings.TrimSpace(timezone)
+
+			usedFlags := 0
+			for _, isUsed := range []bool{local, timezone != "", utc} {
+				if isUsed {
+					usedFlags++
+				}
+			}
+			if usedFlags > 1 {
+				utils.CloseWithError(fmt.Errorf("--local, --tz and --utc cannot be combined"))
+			}
+
+			now := app.Now()
+			isUTC := false
+			if local {
+				now = now.Local()
+			} else if timezone != "" {
+				location, err := time.LoadLocation(timezone)
+				utils.CheckForError(err)
+
+				now = now.In(location)
+			} else {
 				now = now.UTC()
+				isUTC = true
 			}
 
 			outputFormat := format
 			if outputFormat == "" {
-				if local {
-					outputFormat = "2006-01-02T15:04:05.000"
-				} else {
+				if isUTC {
 					outputFormat = "2006-01-02T15:04:05.000Z"
+				} else {
+					outputFormat = "2006-01-02T15:04:05.000"
 				}
 			}
 
-			fmt.Print(now.Format(outputFormat))
+			fmt.Fprint(app.Out, format_now_time(now, outputFormat))
 		},
 	}
 
-	nowCmd.Flags().StringVarP(&format, "format", "", "", "custom output format")
+	nowCmd.Flags().StringVarP(&format, "format", "", "", "custom output format as Go layout or alias like 'iso', 'rfc3339' or 'unix'")
 	nowCmd.Flags().BoolVarP(&local, "local", "", false, "use local time")
+	nowCmd.Flags().StringVarP(&timezone, "tz", "", "", "use IANA timezone like 'Europe/Berlin'")
+	nowCmd.Flags().BoolVarP(&utc, "utc", "", false, "use UTC time (default)")
 
 	parentCmd.AddCommand(
 		nowCmd,
@@ -0,0 +1,100 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+var testNowTime = time.Date(2024, time.July, 15, 20, 30, 45, 123456789, time.UTC)
+
+func TestFormatNowTime(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"date", "2024-07-15"},
+		{"iso", "2024-07-15T20:30:45.123Z"},
+		{" ISO ", "2024-07-15T20:30:45.123Z"},
+		{"kitchen", "8:30PM"},
+		{"rfc1123", "Mon, 15 Jul 2024 20:30:45 +0000"},
+		{"rfc3339", "2024-07-15T20:30:45Z"},
+		{"rfc3339nano", "2024-07-15T20:30:45.123456789Z"},
+		{"rfc822", "15 Jul 24 20:30 +0000"},
+		{"time", "20:30:45"},
+		{"unix", "1721075445"},
+		{"unixmilli", "1721075445123"},
+		{"unixnano", "1721075445123456789"},
+		{"02.01.2006 15:04", "15.07.2024 20:30"},
+	}
+
+	for _, test := range tests {
+		formatted := format_now_time(testNowTime, test.format)
+		if formatted != test.expected {
+			t.Errorf("'%v': expected '%v', got '%v'", test.format, test.expected, formatted)
+		}
+	}
+}
+
+func TestNowCommand(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{}, "2024-07-15T20:30:45.123Z"},
+		{[]string{"--utc"}, "2024-07-15T20:30:45.123Z"},
+		{[]string{"--tz=Europe/Berlin"}, "2024-07-15T22:30:45.123"},
+		{[]string{"--tz=America/New_York", "--format=rfc3339"}, "2024-07-15T16:30:45-04:00"},
+		{[]string{"--tz=Asia/Tokyo", "--format=unix"}, "1721075445"},
+		{[]string{"--format=date"}, "2024-07-15"},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+
+		app := &types.AppContext{
+			Clock: func() time.Time {
+				return testNowTime
+			},
+			Out: &out,
+		}
+
+		rootCmd := &cobra.Command{Use: "gpm"}
+		Init_Now_Command(rootCmd, app)
+
+		rootCmd.SetArgs(append([]string{"now"}, test.args...))
+		err := rootCmd.Execute()
+		if err != nil {
+			t.Fatalf("%v: %v", test.args, err)
+		}
+
+		if out.String() != test.expected {
+			t.Errorf("%v: expected '%v', got '%v'", test.args, test.expected, out.String())
+		}
+	}
+}
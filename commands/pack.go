@@ -29,11 +29,11 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 
 	ver "github.com/hashicorp/go-version"
@@ -53,6 +53,7 @@ func Init_Pack_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	var noPostScript bool
 	var noPreScript bool
 	var noTag bool
+	var reproducible bool
 	var version string
 
 	var packCmd = &cobra.Command{
@@ -64,7 +65,7 @@ func Init_Pack_Command(parentCmd *cobra.Command, app *types.AppContext) {
 			pvm := app.NewVersionManager()
 
 			if !noPreScript {
-				_, ok := app.GpmFile.Scripts[constants.PrePackScriptName]
+				ok := app.HasScript(constants.PrePackScriptName)
 				if ok {
 					app.RunScript(constants.PrePackScriptName)
 				}
@@ -92,19 +93,10 @@ func Init_Pack_Command(parentCmd *cobra.Command, app *types.AppContext) {
 			app.Debug(fmt.Sprintf("Will use version '%v'", latestVersion.String()))
 
 			if all || len(args) > 0 {
-				app.Debug(fmt.Sprintf("Running '%v' ...", "go tool dist list"))
-				output, err := exec.Command("go", "tool", "dist", "list").Output()
+				// collect all possible targets
+				allSupportedArchitecture, err := get_supported_go_targets(app)
 				utils.CheckForError(err)
 
-				// collect all possible targets from output
-				var allSupportedArchitecture []string
-				for _, l := range strings.Split(string(output), "\n") {
-					supportedArchitecture := strings.TrimSpace(l)
-					if supportedArchitecture != "" {
-						allSupportedArchitecture = append(allSupportedArchitecture, supportedArchitecture)
-					}
-				}
-
 				if all {
 					outputFormats = append(outputFormats, allSupportedArchitecture...)
 				} else {
@@ -168,6 +160,32 @@ func Init_Pack_Command(parentCmd *cobra.Command, app *types.AppContext) {
 					zipFilePath := path.Join(app.Cwd, zipFileName)
 					app.Debug(fmt.Sprintf("Will pack to '%v' ...", zipFilePath))
 
+					executableFilename := strings.TrimSpace(name)
+					if executableFilename == "" {
+						executableFilename = projectName
+					}
+					if goos == "windows" {
+						executableFilename += constants.WindowsExecutableExt
+					}
+
+					buildArgs := []string{"build", "-o", executableFilename}
+					if reproducible {
+						buildArgs = append(buildArgs, "-trimpath", "-buildvcs=false", "-ldflags=-buildid=")
+					}
+					buildArgs = append(buildArgs, ".")
+
+					app.Debug(
+						fmt.Sprintf(
+							"Running to '%v' for '%v/%v' ...",
+							"go "+strings.Join(buildArgs, " "),
+							goos, goarch,
+						),
+					)
+					app.RunShellCommandByArgsWithEnv([]string{"GOOS=" + goos, "GOARCH=" + goarch}, "go", buildArgs...)
+					if app.DryRun {
+						return
+					}
+
 					zipFile, err := os.Create(zipFilePath)
 					utils.CheckForError(err)
 					defer func() {
@@ -190,31 +208,14 @@ func Init_Pack_Command(parentCmd *cobra.Command, app *types.AppContext) {
 					err = zipWriter.Flush()
 					utils.CheckForError(err)
 
-					executableFilename := strings.TrimSpace(name)
-					if executableFilename == "" {
-						executableFilename = projectName
-					}
-					if goos == "windows" {
-						executableFilename += constants.WindowsExecutableExt
-					}
-
-					app.Debug(
-						fmt.Sprintf(
-							"Running to '%v' for '%v/%v' ...",
-							fmt.Sprintf("go build -o %v .", executableFilename),
-							goos, goarch,
-						),
-					)
-					p := utils.CreateShellCommandByArgs("go", "build", "-o", executableFilename, ".")
-					p.Dir = app.Cwd
-					p.Env = append(p.Env, "GOOS="+goos, "GOARCH="+goarch)
-
-					utils.RunCommand(p)
-
 					filesToPack, err := app.ListFiles()
 					utils.CheckForError(err)
+					if reproducible {
+						// same order for same input
+						sort.Strings(filesToPack)
+					}
 
-					packBar := utils.CreateProgressBar(
+					packBar := app.NewProgressBar(
 						len(filesToPack),
 						fmt.Sprintf(
 							"[cyan][%v/%v][reset] Packing file for '%v/%v' ...",
@@ -223,40 +224,28 @@ func Init_Pack_Command(parentCmd *cobra.Command, app *types.AppContext) {
 						),
 					)
 					for _, f := range filesToPack {
-						func() {
-							fileReader, err := os.Open(f)
-							utils.CheckForError(err)
-							defer fileReader.Close()
-
-							fileInfo, err := os.Stat(f)
-							utils.CheckForError(err)
-
-							relPath, err := filepath.Rel(app.Cwd, f)
-							if err != nil {
-								relPath = f
-							}
-							app.Debug(fmt.Sprintf("Packing file '%v' into '%v' ...", relPath, zipFilePath))
-
-							header, err := zip.FileInfoHeader(fileInfo)
-							utils.CheckForError(err)
-							header.Name = relPath
-							header.Modified = fileInfo.ModTime()
-
-							fileWriter, err := zipWriter.CreateHeader(header)
-							utils.CheckForError(err)
+						relPath, err := filepath.Rel(app.Cwd, f)
+						if err != nil {
+							relPath = f
+						}
+						app.Debug(fmt.Sprintf("Packing file '%v' into '%v' ...", relPath, zipFilePath))
 
-							io.Copy(fileWriter, fileReader)
-						}()
+						err = utils.AddFileToZip(zipWriter, f, relPath, utils.AddFileToArchiveOptions{
+							Reproducible: reproducible,
+						})
+						utils.CheckForError(err)
 
 						packBar.Add(1)
 					}
-					fmt.Println()
+					if !app.Quiet {
+						fmt.Println()
+					}
 
 					if !noChecksum {
 						checksumFilePath := path.Join(app.Cwd, checksumFileName)
 						app.Debug(fmt.Sprintf("Will hash to '%v' ...", checksumFilePath))
 
-						checksumBar := utils.CreateProgressBar(
+						checksumBar := app.NewProgressBar(
 							1,
 							fmt.Sprintf(
 								"[cyan][%v/%v][reset] Creating checksum of packed file for '%v/%v' ...",
@@ -283,13 +272,15 @@ func Init_Pack_Command(parentCmd *cobra.Command, app *types.AppContext) {
 
 						checksumBar.Add(1)
 
-						fmt.Println()
+						if !app.Quiet {
+							fmt.Println()
+						}
 					}
 				}()
 			}
 
 			if !noPostScript {
-				_, ok := app.GpmFile.Scripts[constants.PostPackScriptName]
+				ok := app.HasScript(constants.PostPackScriptName)
 				if ok {
 					app.RunScript(constants.PostPackScriptName)
 				}
@@ -306,6 +297,7 @@ func Init_Pack_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	packCmd.Flags().BoolVarP(&noPostScript, "no-post-script", "", false, "do not handle '"+constants.PostPackScriptName+"' script")
 	packCmd.Flags().BoolVarP(&noPreScript, "no-pre-script", "", false, "do not handle '"+constants.PrePackScriptName+"' script")
 	packCmd.Flags().BoolVarP(&noTag, "no-tag", "", false, "do not add tag to output file")
+	packCmd.Flags().BoolVarP(&reproducible, "reproducible", "", false, "create reproducible build and zip file")
 	packCmd.Flags().StringVarP(&version, "version", "", "", "custom version number")
 
 	parentCmd.AddCommand(
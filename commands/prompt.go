@@ -23,19 +23,78 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
+	"text/template"
 
+	"github.com/charmbracelet/glamour"
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
+// load_prompt_template() - loads a prompt template from STDIN (`-`),
+// a named template inside `settings.yaml` or a file / URL
+func load_prompt_template(app *types.AppContext, source string) (string, error) {
+	source = strings.TrimSpace(source)
+
+	if source != "-" {
+		namedTemplate, ok := app.SettingsFile.GetPromptTemplate(source)
+		if ok {
+			app.Debug(fmt.Sprintf("Using prompt template '%v' from settings ...", source))
+			return namedTemplate, nil
+		}
+	}
+
+	data, err := app.LoadDataFrom(source)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// render_prompt_template() - fills `{{.Var}}` placeholders of a template
+// with values from `--var key=value` flags
+func render_prompt_template(templateText string, vars []string, input string) (string, error) {
+	data := map[string]string{
+		"Input": input,
+	}
+	for _, v := range vars {
+		key, value, ok := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return "", fmt.Errorf("invalid variable '%v', expected format is 'key=value'", v)
+		}
+
+		data[key] = value
+	}
+
+	t, err := template.New("prompt").Option("missingkey=error").Parse(templateText)
+	if err != nil {
+		return "", err
+	}
+
+	var prompt strings.Builder
+	err = t.Execute(&prompt, data)
+	if err != nil {
+		return "", err
+	}
+
+	return prompt.String(), nil
+}
+
 func Init_Prompt_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	var assistantMessages []string
 	var customTemperature float32
 	var isChat bool
+	var markdown bool
+	var maxRetries int
+	var promptTemplate string
+	var schemaSource string
 	var userMessages []string
+	var vars []string
 
 	var promptCmd = &cobra.Command{
 		Use:   "prompt",
@@ -59,17 +118,25 @@ func Init_Prompt_Command(parentCmd *cobra.Command, app *types.AppContext) {
 				systemPrompt = app.GetSystemAIPrompt("")
 			}
 
-			model := strings.TrimSpace(app.Model)
-			if model == "" {
-				model = utils.GetDefaultAIChatModel()
+			promptTemplate = strings.TrimSpace(promptTemplate)
+
+			newUserMessage := strings.Join(args, " ")
+			if promptTemplate != "-" {
+				// STDIN is not used by template
+				stdin, err := utils.LoadFromSTDINIfAvailable()
+				utils.CheckForError(err)
+
+				if stdin != nil {
+					newUserMessage += string(*stdin)
+				}
 			}
 
-			stdin, err := utils.LoadFromSTDINIfAvailable()
-			utils.CheckForError(err)
+			if promptTemplate != "" {
+				templateText, err := load_prompt_template(app, promptTemplate)
+				utils.CheckForError(err)
 
-			newUserMessage := strings.Join(args, " ")
-			if stdin != nil {
-				newUserMessage += string(*stdin)
+				newUserMessage, err = render_prompt_template(templateText, vars, newUserMessage)
+				utils.CheckForError(err)
 			}
 
 			aiChat, err := app.CreateAIChat()
@@ -77,15 +144,14 @@ func Init_Prompt_Command(parentCmd *cobra.Command, app *types.AppContext) {
 
 			isChatConversation := isChat || assistantMessageCount > 0
 
-			aiChat.UpdateModel(model)
 			aiChat.UpdateSystem(systemPrompt)
 
 			var temperature float32
 			if customTemperature < 0 {
 				if isChatConversation {
-					temperature = utils.GetAIChatTemperature(0.3)
+					temperature = app.GetAIChatTemperature(0.3)
 				} else {
-					temperature = utils.GetAIChatTemperature(0)
+					temperature = app.GetAIChatTemperature(0)
 				}
 			} else {
 				temperature = customTemperature
@@ -98,6 +164,31 @@ func Init_Prompt_Command(parentCmd *cobra.Command, app *types.AppContext) {
 			app.Debug(fmt.Sprintf("Temperature: %v", temperature))
 			app.Debug(fmt.Sprintf("System prompt: %v", systemPrompt))
 
+			if strings.TrimSpace(schemaSource) != "" {
+				// structured output
+
+				schemaData, err := app.LoadDataFrom(schemaSource)
+				utils.CheckForError(err)
+
+				var schema map[string]interface{}
+				err = json.Unmarshal(schemaData, &schema)
+				utils.CheckForError(err)
+
+				app.Debug(fmt.Sprintf("Type: %v", "JSON schema"))
+				app.Debug(fmt.Sprintf("Prompt: %v", newUserMessage))
+
+				jsonAnswer, err := types.ChatWithJsonSchema(aiChat, newUserMessage, "PromptResponseSchema", schema, types.ChatWithJsonSchemaOptions{
+					MaxRetries: &maxRetries,
+					OnRetry: func(retry int, maxRetries int, err error) {
+						app.Debug(fmt.Sprintf("Re-prompting (%v/%v) because of invalid response: %v", retry, maxRetries, err))
+					},
+				})
+				utils.CheckForError(err)
+
+				fmt.Println(jsonAnswer)
+				return
+			}
+
 			answer := ""
 			onMessageUpdate := func(messageChunk string) error {
 				answer += messageChunk
@@ -122,14 +213,27 @@ func Init_Prompt_Command(parentCmd *cobra.Command, app *types.AppContext) {
 				utils.CheckForError(err)
 			}
 
+			if markdown {
+				out, err := glamour.Render(answer, "dark")
+				if err == nil {
+					fmt.Print(out)
+					return
+				}
+			}
+
 			fmt.Print(answer)
 		},
 	}
 
 	promptCmd.Flags().StringArrayVarP(&assistantMessages, "assistant", "", []string{}, "assistant messages")
 	promptCmd.Flags().BoolVarP(&isChat, "chat", "", false, "is chat conversation and no completion operation")
+	promptCmd.Flags().BoolVarP(&markdown, "markdown", "", false, "render answer as Markdown")
+	promptCmd.Flags().IntVarP(&maxRetries, "retries", "", 3, "maximum number of re-prompts if response does not match JSON schema")
+	promptCmd.Flags().StringVarP(&schemaSource, "schema", "", "", "file or URL of a JSON schema for structured output")
 	promptCmd.Flags().Float32VarP(&customTemperature, "temperature", "", -1, "custom temperature value")
+	promptCmd.Flags().StringVarP(&promptTemplate, "template", "t", "", "prompt template from a file, URL, named template in settings or '-' for STDIN")
 	promptCmd.Flags().StringArrayVarP(&userMessages, "user", "", []string{}, "user messages")
+	promptCmd.Flags().StringArrayVarP(&vars, "var", "", []string{}, "one or more variables for template, like 'key=value'")
 
 	parentCmd.AddCommand(
 		promptCmd,
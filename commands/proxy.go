@@ -0,0 +1,218 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+// maximum size of a request body in bytes
+const maxProxyRequestBodySize = 10 * 1024 * 1024
+
+// proxyChatCompletionRequest is the body of an OpenAI
+// compatible chat completion request
+type proxyChatCompletionRequest struct {
+	Messages    []types.ChatAIMessage `json:"messages"`
+	Model       string                `json:"model,omitempty"`
+	Stream      bool                  `json:"stream,omitempty"`
+	Temperature *float32              `json:"temperature,omitempty"`
+}
+
+// write_proxy_error() - writes an OpenAI compatible error response
+func write_proxy_error(w http.ResponseWriter, statusCode int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"message": err.Error(),
+			"type":    "gpm_proxy_error",
+		},
+	})
+}
+
+// handle_proxy_chat_completion() - answers a chat completion request
+// with the configured AI provider; returns the used model
+func handle_proxy_chat_completion(app *types.AppContext, w http.ResponseWriter, r *http.Request) string {
+	if r.Method != http.MethodPost {
+		write_proxy_error(w, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method))
+		return ""
+	}
+
+	var request proxyChatCompletionRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxProxyRequestBodySize)).Decode(&request)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			write_proxy_error(w, http.StatusRequestEntityTooLarge, err)
+		} else {
+			write_proxy_error(w, http.StatusBadRequest, err)
+		}
+		return ""
+	}
+
+	if request.Stream {
+		write_proxy_error(w, http.StatusBadRequest, fmt.Errorf("streaming is not supported"))
+		return ""
+	}
+
+	if len(request.Messages) == 0 || request.Messages[len(request.Messages)-1].Role != "user" {
+		write_proxy_error(w, http.StatusBadRequest, fmt.Errorf("last message must be a user message"))
+		return ""
+	}
+
+	options := types.CreateAIChatOptions{}
+	if model := strings.TrimSpace(request.Model); model != "" {
+		options.Model = &model
+	}
+
+	chat, err := app.CreateAIChat(options)
+	if err != nil {
+		write_proxy_error(w, http.StatusInternalServerError, err)
+		return ""
+	}
+
+	temperature := app.GetAIChatTemperature(0.3)
+	if request.Temperature != nil {
+		temperature = *request.Temperature
+	}
+	chat.UpdateTemperature(temperature)
+
+	lastMessage := request.Messages[len(request.Messages)-1]
+	chat.ImportHistory(request.Messages[:len(request.Messages)-1])
+
+	answer := ""
+	err = chat.SendMessage(lastMessage.Content, func(messageChunk string) error {
+		answer += messageChunk
+		return nil
+	})
+	if err != nil {
+		write_proxy_error(w, http.StatusBadGateway, err)
+		return chat.GetModel()
+	}
+
+	now := app.Now()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"id":      fmt.Sprintf("chatcmpl-gpm-%v", now.UnixNano()),
+		"object":  "chat.completion",
+		"created": now.Unix(),
+		"model":   chat.GetModel(),
+		"choices": []map[string]interface{}{
+			{
+				"index": 0,
+				"message": types.ChatAIMessage{
+					Content: answer,
+					Role:    "assistant",
+				},
+				"finish_reason": "stop",
+			},
+		},
+	})
+
+	return chat.GetModel()
+}
+
+func Init_Proxy_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var host string
+	var logRequests bool
+	var port int
+
+	var proxyCmd = &cobra.Command{
+		Use:   "proxy",
+		Short: "Proxy for AI providers",
+		Long:  `Provides an OpenAI compatible chat completion endpoint, which forwards requests to the configured AI provider.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+				startTime := time.Now()
+
+				model := handle_proxy_chat_completion(app, w, r)
+
+				if logRequests {
+					fmt.Fprintf(
+						app.Out,
+						"%v %v %v (%v) %v%v",
+						startTime.Format(time.RFC3339), r.Method, r.URL.Path, model,
+						time.Since(startTime).Round(time.Millisecond), fmt.Sprintln(),
+					)
+				}
+			})
+
+			listener, err := net.Listen("tcp", fmt.Sprintf("%v:%v", host, port))
+			utils.CheckForError(err)
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			server := &http.Server{
+				Handler: mux,
+			}
+
+			serverErrors := make(chan error, 1)
+			go func() {
+				serverErrors <- server.Serve(listener)
+			}()
+
+			fmt.Fprintf(app.Out, "Proxy listening at http://%v/v1/chat/completions%v", listener.Addr().String(), fmt.Sprintln())
+
+			select {
+			case err := <-serverErrors:
+				if !errors.Is(err, http.ErrServerClosed) {
+					utils.CheckForError(err)
+				}
+			case <-ctx.Done():
+				app.Debug("Shutting down proxy ...")
+
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+
+				err := server.Shutdown(shutdownCtx)
+				utils.CheckForError(err)
+			}
+		},
+	}
+
+	proxyCmd.Flags().StringVarP(&host, "host", "", "localhost", "host address to bind to")
+	proxyCmd.Flags().BoolVarP(&logRequests, "log", "", false, "output a line for each request")
+	proxyCmd.Flags().IntVarP(&port, "port", "p", 8787, "port to listen on")
+
+	parentCmd.AddCommand(
+		proxyCmd,
+	)
+}
@@ -0,0 +1,108 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+)
+
+func TestHandleProxyChatCompletion(t *testing.T) {
+	mock := &types.MockAIChat{
+		Model:     "mock-model",
+		Responses: []string{"Hello from mock"},
+	}
+
+	app := &types.AppContext{
+		AIChatFactory: func(options ...types.CreateAIChatOptions) (types.ChatAI, error) {
+			return mock, nil
+		},
+	}
+
+	body := `{"messages":[{"role":"system","content":"be nice"},{"role":"user","content":"Hi"}],"temperature":0.5}`
+	r := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	model := handle_proxy_chat_completion(app, w, r)
+	if model != "mock-model" {
+		t.Errorf("expected model 'mock-model', got '%v'", model)
+	}
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %v: %v", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Choices []struct {
+			Message types.ChatAIMessage `json:"message"`
+		} `json:"choices"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Choices) != 1 || response.Choices[0].Message.Content != "Hello from mock" {
+		t.Errorf("unexpected response: %v", w.Body.String())
+	}
+	if len(mock.Requests) != 1 || mock.Requests[0] != "Hi" || mock.Temperature != 0.5 {
+		t.Errorf("unexpected request to AI: %v (%v)", mock.Requests, mock.Temperature)
+	}
+}
+
+func TestHandleProxyChatCompletionWithInvalidRequests(t *testing.T) {
+	app := &types.AppContext{
+		AIChatFactory: func(options ...types.CreateAIChatOptions) (types.ChatAI, error) {
+			t.Error("no AI chat should be created")
+			return &types.MockAIChat{}, nil
+		},
+	}
+
+	tooLarge := `{"messages":[{"role":"user","content":"` + strings.Repeat("x", maxProxyRequestBodySize) + `"}]}`
+
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		expected int
+	}{
+		{"method", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"invalid JSON", http.MethodPost, "{", http.StatusBadRequest},
+		{"streaming", http.MethodPost, `{"messages":[{"role":"user","content":"Hi"}],"stream":true}`, http.StatusBadRequest},
+		{"no user message", http.MethodPost, `{"messages":[{"role":"system","content":"Hi"}]}`, http.StatusBadRequest},
+		{"body too large", http.MethodPost, tooLarge, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(test.method, "/v1/chat/completions", strings.NewReader(test.body))
+		w := httptest.NewRecorder()
+
+		handle_proxy_chat_completion(app, w, r)
+		if w.Code != test.expected {
+			t.Errorf("%v: expected status %v, got %v", test.name, test.expected, w.Code)
+		}
+	}
+}
@@ -24,54 +24,105 @@ package commands
 
 import (
 	"fmt"
+	"os/exec"
+	"path"
+	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
+var publishTargets = []string{"container", "git", "goproxy", "release"}
+
+// find_git_tag_of_version() - returns the name of the git tag, which
+// represents a specific version, or an empty string if not found
+func find_git_tag_of_version(app *types.AppContext, v *version.Version) (string, error) {
+	tags, err := app.GetGitTags()
+	if err != nil {
+		return "", err
+	}
+
+	for _, t := range tags {
+		tagVersion, err := version.NewVersion(strings.TrimSpace(t))
+		if err == nil && tagVersion.Equal(v) {
+			return strings.TrimSpace(t), nil
+		}
+	}
+
+	return "", nil
+}
+
+// get_main_module_path() - returns the path of the main module of the current project
+func get_main_module_path(app *types.AppContext) (string, error) {
+	p := exec.Command("go", "list", "-m")
+	p.Dir = app.Cwd
+
+	output, err := p.Output()
+	if err != nil {
+		return "", err
+	}
+
+	modulePath := strings.TrimSpace(strings.Split(strings.TrimSpace(string(output)), "\n")[0])
+	if modulePath == "" || modulePath == "command-line-arguments" {
+		return "", fmt.Errorf("no Go module found in '%v'", app.Cwd)
+	}
+
+	return modulePath, nil
+}
+
 func Init_Publish_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var allowDirty bool
 	var breaking bool
 	var defaultRemoteOnly bool
 	var feature bool
 	var fix bool
 	var force bool
+	var image string
 	var major int64
 	var minor int64
 	var message string
 	var noBump bool
 	var patch int64
+	var provider string
+	var proxy string
+	var target string
 
 	var publishCmd = &cobra.Command{
 		Use:     "publish [remotes]",
 		Aliases: []string{"pub"},
 		Short:   "Publish version",
-		Long:    `Bumps the version of the current project and pushes it to all remote repositories.`,
+		Long:    `Bumps the version of the current project, pushes it to all remote repositories and optionally publishes it to a target.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			currentBranchName, _ := app.GetCurrentGitBranch()
-
-			if !noBump {
-				pvm := app.NewVersionManager()
+			targetName := strings.TrimSpace(strings.ToLower(target))
+			if targetName == "" {
+				targetName = "git"
+			}
 
-				bumpOptions := types.BumpProjectVersionOptions{
-					Breaking: &breaking,
-					Feature:  &feature,
-					Fix:      &fix,
-					Force:    &force,
-					Major:    &major,
-					Message:  &message,
-					Minor:    &minor,
-					Patch:    &patch,
+			isValidTarget := false
+			for _, t := range publishTargets {
+				if t == targetName {
+					isValidTarget = true
+					break
 				}
+			}
+			if !isValidTarget {
+				utils.CloseWithError(fmt.Errorf("invalid target '%v', possible values are %v", targetName, strings.Join(publishTargets, ", ")))
+			}
 
-				newVersion, err := pvm.Bump(bumpOptions)
+			// prerequisites
+			if !allowDirty {
+				isDirty, err := app.GetGitDirty()
 				utils.CheckForError(err)
 
-				if newVersion != nil {
-					fmt.Printf("v%s%s", newVersion.String(), fmt.Sprintln())
+				if isDirty {
+					utils.CloseWithError(fmt.Errorf("working tree is not clean, commit your changes or use --allow-dirty"))
 				}
 			}
 
+			currentBranchName, _ := app.GetCurrentGitBranch()
+
 			var remotes []string
 			if len(args) == 0 {
 				listOfRemotes, err := app.GetGitRemotes()
@@ -91,6 +142,124 @@ func Init_Publish_Command(parentCmd *cobra.Command, app *types.AppContext) {
 				remotes = []string{remotes[0]}
 			}
 
+			pvm := app.NewVersionManager()
+
+			var publishVersion *version.Version
+			tagName := ""
+			if noBump {
+				latestVersion, err := pvm.GetLatestVersion()
+				utils.CheckForError(err)
+
+				if latestVersion == nil {
+					utils.CloseWithError(fmt.Errorf("no version found in git tags"))
+				}
+
+				tagName, err = find_git_tag_of_version(app, latestVersion)
+				utils.CheckForError(err)
+				if tagName == "" {
+					utils.CloseWithError(fmt.Errorf("no tag found for version %v", latestVersion.String()))
+				}
+
+				publishVersion = latestVersion
+			} else {
+				// only calculate new version, tag is created later
+				checkOnly := true
+
+				bumpOptions := types.BumpProjectVersionOptions{
+					Breaking: &breaking,
+					DryRun:   &checkOnly,
+					Feature:  &feature,
+					Fix:      &fix,
+					Force:    &force,
+					Major:    &major,
+					Message:  &message,
+					Minor:    &minor,
+					Patch:    &patch,
+				}
+
+				newVersion, err := pvm.Bump(bumpOptions)
+				utils.CheckForError(err)
+
+				publishVersion = newVersion
+				tagName = fmt.Sprintf("v%v", newVersion.String())
+			}
+
+			modulePath := ""
+			imageName := strings.TrimSpace(image)
+			var releaseProvider types.ReleaseProvider
+			switch targetName {
+			case "container":
+				if imageName == "" {
+					utils.CloseWithError(fmt.Errorf("no image name defined, use --image"))
+				}
+
+				isFile, err := utils.IsFileExisting(path.Join(app.Cwd, "Dockerfile"))
+				utils.CheckForError(err)
+				if !isFile {
+					utils.CloseWithError(fmt.Errorf("no Dockerfile found in '%v'", app.Cwd))
+				}
+
+				_, err = exec.LookPath("docker")
+				utils.CheckForError(err)
+			case "goproxy":
+				if !strings.HasPrefix(tagName, "v") {
+					utils.CloseWithError(fmt.Errorf("tag '%v' is no valid Go module version, it must start with 'v'", tagName))
+				}
+
+				var err error
+				modulePath, err = get_main_module_path(app)
+				utils.CheckForError(err)
+			case "release":
+				var err error
+				releaseProvider, err = app.NewReleaseProvider(provider, fmt.Sprintf("gpm/%v", cmd.Root().Version))
+				utils.CheckForError(err)
+			}
+
+			proxyUrl := strings.TrimSpace(proxy)
+			imageWithTag := fmt.Sprintf("%v:%v", imageName, publishVersion.String())
+
+			if app.DryRun {
+				dryRunPrintln := func(a ...interface{}) {
+					fmt.Fprintf(app.Out, "[DRY-RUN] %v%v", fmt.Sprint(a...), fmt.Sprintln())
+				}
+
+				if !noBump {
+					dryRunPrintln(fmt.Sprintf("Would create tag '%v'", tagName))
+				}
+				for _, r := range remotes {
+					dryRunPrintln(fmt.Sprintf("Would push branch '%v' and tags to '%v'", currentBranchName, r))
+				}
+
+				switch targetName {
+				case "container":
+					dryRunPrintln(fmt.Sprintf("Would build and push container image '%v'", imageWithTag))
+				case "goproxy":
+					dryRunPrintln(fmt.Sprintf("Would request '%v@%v' from '%v'", modulePath, tagName, proxyUrl))
+				case "release":
+					dryRunPrintln(fmt.Sprintf("Would create %v release for '%v' in '%v'", releaseProvider.GetName(), tagName, releaseProvider.GetRepository()))
+				}
+
+				return
+			}
+
+			if !noBump {
+				newVersion, err := pvm.Bump(types.BumpProjectVersionOptions{
+					Breaking: &breaking,
+					Feature:  &feature,
+					Fix:      &fix,
+					Force:    &force,
+					Major:    &major,
+					Message:  &message,
+					Minor:    &minor,
+					Patch:    &patch,
+				})
+				utils.CheckForError(err)
+
+				if newVersion != nil {
+					fmt.Printf("v%s%s", newVersion.String(), fmt.Sprintln())
+				}
+			}
+
 			for _, r := range remotes {
 				// first push code
 				{
@@ -106,19 +275,46 @@ func Init_Publish_Command(parentCmd *cobra.Command, app *types.AppContext) {
 					app.RunShellCommandByArgs(cmdArgs[0], cmdArgs[1:]...)
 				}
 			}
+
+			switch targetName {
+			case "container":
+				app.RunShellCommandByArgs("docker", "build", "-t", imageWithTag, ".")
+				app.RunShellCommandByArgs("docker", "push", imageWithTag)
+			case "goproxy":
+				// let the proxy fetch and cache the new version
+				app.RunShellCommandByArgsWithEnv(
+					[]string{"GOPROXY=" + proxyUrl},
+					"go", "list", "-m", fmt.Sprintf("%v@%v", modulePath, tagName),
+				)
+			case "release":
+				release, err := releaseProvider.CreateRelease(types.CreateReleaseOptions{
+					Body:    strings.TrimSpace(message),
+					TagName: tagName,
+				})
+				utils.CheckForError(err)
+
+				if release.Url != "" {
+					fmt.Fprintln(app.Out, release.Url)
+				}
+			}
 		},
 	}
 
+	publishCmd.Flags().BoolVarP(&allowDirty, "allow-dirty", "", false, "do not abort if working tree is not clean")
 	publishCmd.Flags().BoolVarP(&breaking, "breaking", "", false, "increase major part by 1")
 	publishCmd.Flags().BoolVarP(&defaultRemoteOnly, "default", "d", false, "default / first remote only")
 	publishCmd.Flags().BoolVarP(&feature, "feature", "", false, "increase minor part by 1")
 	publishCmd.Flags().BoolVarP(&fix, "fix", "", false, "increase patch part by 1")
 	publishCmd.Flags().BoolVarP(&force, "force", "", false, "ignore value of previous version")
+	publishCmd.Flags().StringVarP(&image, "image", "", "", "name of the container image for 'container' target")
 	publishCmd.Flags().Int64VarP(&major, "major", "", -1, "set major part")
 	publishCmd.Flags().StringVarP(&message, "message", "", "", "custom git message")
 	publishCmd.Flags().Int64VarP(&minor, "minor", "", -1, "set minor part")
 	publishCmd.Flags().BoolVarP(&noBump, "no-bump", "", false, "do not bump version")
 	publishCmd.Flags().Int64VarP(&patch, "patch", "", -1, "set patch part")
+	publishCmd.Flags().StringVarP(&provider, "provider", "", "", "release provider for 'release' target, default is detected from origin")
+	publishCmd.Flags().StringVarP(&proxy, "proxy", "", "https://proxy.golang.org", "module proxy for 'goproxy' target")
+	publishCmd.Flags().StringVarP(&target, "target", "", "git", "target like 'container', 'git', 'goproxy' or 'release'")
 
 	parentCmd.AddCommand(
 		publishCmd,
@@ -0,0 +1,362 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+
+	"github.com/mkloubert/go-package-manager/constants"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+// create_release_checksums_file() - writes the SHA256 checksums of `files`
+// in the format of `sha256sum` to `checksumsFilePath`
+func create_release_checksums_file(checksumsFilePath string, files []string) error {
+	var content strings.Builder
+
+	for _, f := range files {
+		err := func() error {
+			file, err := os.Open(f)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			hash := sha256.New()
+			_, err = io.Copy(hash, file)
+			if err != nil {
+				return err
+			}
+
+			content.WriteString(
+				fmt.Sprintf("%v  %v%v", hex.EncodeToString(hash.Sum(nil)), filepath.Base(f), fmt.Sprintln()),
+			)
+			return nil
+		}()
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(checksumsFilePath, []byte(content.String()), constants.DefaultFileMode)
+}
+
+// get_release_artifacts() - returns the files, which have been created by
+// `pack` command for a specific version
+func get_release_artifacts(app *types.AppContext, releaseVersion *version.Version) ([]string, error) {
+	projectName := path.Base(app.Cwd)
+
+	artifacts := []string{}
+	for _, pattern := range []string{"%v-v%v-*.zip", "%v-v%v-*.zip.sha256"} {
+		matches, err := filepath.Glob(
+			path.Join(app.Cwd, fmt.Sprintf(pattern, projectName, releaseVersion.String())),
+		)
+		if err != nil {
+			return artifacts, err
+		}
+
+		artifacts = append(artifacts, matches...)
+	}
+
+	sort.Strings(artifacts)
+	return artifacts, nil
+}
+
+// upload_release_assets() - uploads `files` to the release of a tag,
+// where existing assets with the same name are replaced
+func upload_release_assets(app *types.AppContext, provider types.ReleaseProvider, tagName string, files []string) {
+	for i, f := range files {
+		assetName := filepath.Base(f)
+
+		stat, err := os.Stat(f)
+		utils.CheckForError(err)
+
+		uploadBar := app.NewProgressBar(
+			int(stat.Size()),
+			fmt.Sprintf(
+				"[cyan][%v/%v][reset] Uploading '%v' ...",
+				i+1, len(files),
+				assetName,
+			),
+		)
+
+		err = provider.UploadAsset(tagName, f, types.UploadReleaseAssetOptions{
+			Progress: uploadBar,
+		})
+		utils.CheckForError(err)
+
+		if !app.Quiet {
+			fmt.Println()
+		}
+	}
+}
+
+func init_release_upload_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var provider string
+	var userAgent string
+
+	var uploadCmd = &cobra.Command{
+		Use:     "upload [tag] [files]",
+		Aliases: []string{"up"},
+		Short:   "Upload release assets",
+		Long:    `Uploads local files as assets to an existing GitHub or GitLab release, where existing assets with the same name are replaced.`,
+		Args:    cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			tagName := strings.TrimSpace(args[0])
+
+			files := []string{}
+			for _, f := range args[1:] {
+				filePath := app.GetFullPathOrDefault(f, "")
+				if filePath == "" {
+					continue
+				}
+
+				isFile, err := utils.IsFileExisting(filePath)
+				utils.CheckForError(err)
+				if !isFile {
+					utils.CloseWithError(fmt.Errorf("file '%v' not found", filePath))
+				}
+
+				files = append(files, filePath)
+			}
+
+			customUserAgent := strings.TrimSpace(userAgent)
+			if customUserAgent == "" {
+				customUserAgent = fmt.Sprintf("gpm/%v", cmd.Root().Version)
+			}
+
+			releaseProvider, err := app.NewReleaseProvider(provider, customUserAgent)
+			utils.CheckForError(err)
+
+			app.Debug(fmt.Sprintf("Uploading %v file(s) to release '%v' of '%v' ...", len(files), tagName, releaseProvider.GetRepository()))
+			upload_release_assets(app, releaseProvider, tagName, files)
+		},
+	}
+
+	uploadCmd.Flags().StringVarP(&provider, "provider", "", "", "release provider like 'github' or 'gitlab', default is detected from origin")
+	uploadCmd.Flags().StringVarP(&userAgent, "user-agent", "", "", "custom user agent")
+
+	parentCmd.AddCommand(
+		uploadCmd,
+	)
+}
+
+func Init_Release_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var all bool
+	var allowDirty bool
+	var breaking bool
+	var createRelease bool
+	var draft bool
+	var feature bool
+	var fix bool
+	var force bool
+	var major int64
+	var minor int64
+	var message string
+	var noBump bool
+	var noChecksum bool
+	var noPack bool
+	var noPush bool
+	var notes string
+	var patch int64
+	var prerelease bool
+	var provider string
+	var remote string
+	var reproducible bool
+
+	var releaseCmd = &cobra.Command{
+		Use:   "release [targets]",
+		Short: "Release new version",
+		Long:  `Bumps the version, packs the project for the targets, pushes the new tag and optionally creates a GitHub or GitLab release.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			pvm := app.NewVersionManager()
+
+			if !allowDirty {
+				isDirty, err := app.GetGitDirty()
+				utils.CheckForError(err)
+
+				if isDirty {
+					utils.CloseWithError(fmt.Errorf("working tree is not clean, commit your changes or use --allow-dirty"))
+				}
+			}
+
+			// 1. version
+			var releaseVersion *version.Version
+			if noBump {
+				latestVersion, err := pvm.GetLatestVersion()
+				utils.CheckForError(err)
+
+				if latestVersion == nil {
+					utils.CloseWithError(fmt.Errorf("no version found in git tags"))
+				}
+
+				releaseVersion = latestVersion
+			} else {
+				bumpOptions := types.BumpProjectVersionOptions{
+					Breaking: &breaking,
+					DryRun:   &app.DryRun,
+					Feature:  &feature,
+					Fix:      &fix,
+					Force:    &force,
+					Major:    &major,
+					Message:  &message,
+					Minor:    &minor,
+					Patch:    &patch,
+				}
+
+				newVersion, err := pvm.Bump(bumpOptions)
+				utils.CheckForError(err)
+
+				releaseVersion = newVersion
+			}
+
+			tagName := fmt.Sprintf("v%v", releaseVersion.String())
+			fmt.Fprintln(app.Out, tagName)
+
+			// 2. pack
+			artifacts := []string{}
+			if !noPack {
+				packArgs := []string{"pack", "--version", releaseVersion.String()}
+				if all {
+					packArgs = append(packArgs, "--all")
+				}
+				if noChecksum {
+					packArgs = append(packArgs, "--no-checksum")
+				}
+				if reproducible {
+					packArgs = append(packArgs, "--reproducible")
+				}
+				packArgs = append(packArgs, args...)
+
+				app.Debug(fmt.Sprintf("Packing version '%v' ...", releaseVersion.String()))
+				utils.RunCommand(create_self_command(app, cmd, packArgs...))
+
+				packedFiles, err := get_release_artifacts(app, releaseVersion)
+				utils.CheckForError(err)
+
+				artifacts = append(artifacts, packedFiles...)
+
+				if !noChecksum && !app.DryRun {
+					checksumsFilePath := path.Join(
+						app.Cwd,
+						fmt.Sprintf("%v-%v-checksums.txt", path.Base(app.Cwd), tagName),
+					)
+
+					zipFiles := []string{}
+					for _, f := range packedFiles {
+						if strings.HasSuffix(f, ".zip") {
+							zipFiles = append(zipFiles, f)
+						}
+					}
+
+					app.Debug(fmt.Sprintf("Writing checksums to '%v' ...", checksumsFilePath))
+					err = create_release_checksums_file(checksumsFilePath, zipFiles)
+					utils.CheckForError(err)
+
+					artifacts = append(artifacts, checksumsFilePath)
+				}
+			}
+
+			// 3. push
+			if !noPush {
+				remoteName := strings.TrimSpace(remote)
+
+				currentBranchName, err := app.GetCurrentGitBranch()
+				if err == nil {
+					app.RunShellCommandByArgs("git", "push", remoteName, currentBranchName)
+				} else {
+					app.Warn(fmt.Sprintf("Will not push branch: %v", err))
+				}
+
+				app.RunShellCommandByArgs("git", "push", remoteName, tagName)
+			}
+
+			// 4. GitHub / GitLab release
+			if createRelease {
+				releaseProvider, err := app.NewReleaseProvider(provider, fmt.Sprintf("gpm/%v", cmd.Root().Version))
+				utils.CheckForError(err)
+
+				if app.DryRun {
+					// no release and no artifacts to upload
+					fmt.Fprintf(app.Out, "[DRY-RUN] create %v release '%v' in '%v'%v", releaseProvider.GetName(), tagName, releaseProvider.GetRepository(), fmt.Sprintln())
+					return
+				}
+
+				app.Debug(fmt.Sprintf("Creating %v release for '%v' in '%v' ...", releaseProvider.GetName(), tagName, releaseProvider.GetRepository()))
+				release, err := releaseProvider.CreateRelease(types.CreateReleaseOptions{
+					Body:       notes,
+					Draft:      draft,
+					Prerelease: prerelease,
+					TagName:    tagName,
+				})
+				utils.CheckForError(err)
+
+				upload_release_assets(app, releaseProvider, tagName, artifacts)
+
+				if release.Url != "" {
+					fmt.Fprintln(app.Out, release.Url)
+				}
+			}
+		},
+	}
+
+	releaseCmd.Flags().BoolVarP(&all, "all", "", false, "pack for all architectures")
+	releaseCmd.Flags().BoolVarP(&allowDirty, "allow-dirty", "", false, "do not abort if working tree is not clean")
+	releaseCmd.Flags().BoolVarP(&breaking, "breaking", "", false, "increase major part by 1")
+	releaseCmd.Flags().BoolVarP(&createRelease, "create-release", "", false, "create GitHub or GitLab release with artifacts")
+	releaseCmd.Flags().BoolVarP(&draft, "draft", "", false, "create GitHub release as draft")
+	releaseCmd.Flags().BoolVarP(&feature, "feature", "", false, "increase minor part by 1")
+	releaseCmd.Flags().BoolVarP(&fix, "fix", "", false, "increase patch part by 1")
+	releaseCmd.Flags().BoolVarP(&force, "force", "", false, "ignore value of previous version")
+	releaseCmd.Flags().Int64VarP(&major, "major", "", -1, "set major part")
+	releaseCmd.Flags().StringVarP(&message, "message", "", "", "custom git message")
+	releaseCmd.Flags().Int64VarP(&minor, "minor", "", -1, "set minor part")
+	releaseCmd.Flags().BoolVarP(&noBump, "no-bump", "", false, "do not bump version and use latest one")
+	releaseCmd.Flags().BoolVarP(&noChecksum, "no-checksum", "", false, "do not create checksum files")
+	releaseCmd.Flags().BoolVarP(&noPack, "no-pack", "", false, "do not pack project")
+	releaseCmd.Flags().BoolVarP(&noPush, "no-push", "", false, "do not push branch and tag")
+	releaseCmd.Flags().StringVarP(&notes, "notes", "", "", "release notes")
+	releaseCmd.Flags().Int64VarP(&patch, "patch", "", -1, "set patch part")
+	releaseCmd.Flags().BoolVarP(&prerelease, "prerelease", "", false, "mark GitHub release as pre-release")
+	releaseCmd.Flags().StringVarP(&provider, "provider", "", "", "release provider like 'github' or 'gitlab', default is detected from origin")
+	releaseCmd.Flags().StringVarP(&remote, "remote", "", "origin", "the remote to push to")
+	releaseCmd.Flags().BoolVarP(&reproducible, "reproducible", "", false, "create reproducible builds and zip files")
+
+	init_release_upload_command(releaseCmd, app)
+
+	parentCmd.AddCommand(
+		releaseCmd,
+	)
+}
@@ -0,0 +1,93 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+func TestReleaseCommandWithDryRun(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+
+	git := func(args ...string) string {
+		p := exec.Command("git", append([]string{"-c", "user.name=gpm", "-c", "user.email=gpm@localhost"}, args...)...)
+		p.Dir = dir
+
+		output, err := p.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", strings.Join(args, " "), err, output)
+		}
+		return string(output)
+	}
+
+	git("init", "-q")
+	err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# test"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	git("add", "README.md")
+	git("commit", "-q", "-m", "initial commit")
+	git("tag", "v1.0.0")
+
+	var out bytes.Buffer
+
+	app := &types.AppContext{
+		Cwd:    dir,
+		DryRun: true,
+		Out:    &out,
+	}
+
+	rootCmd := &cobra.Command{Use: "gpm"}
+	Init_Release_Command(rootCmd, app)
+
+	rootCmd.SetArgs([]string{"release", "--fix", "--no-pack", "--remote=origin"})
+	err = rootCmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := out.String()
+	if !strings.HasPrefix(output, "v1.0.1\n") {
+		t.Errorf("expected new version first, got %q", output)
+	}
+	if !strings.Contains(output, "[DRY-RUN] git push origin v1.0.1") {
+		t.Errorf("expected push of tag in dry-run mode, got %q", output)
+	}
+
+	tags := strings.Fields(git("tag", "--list"))
+	if len(tags) != 1 || tags[0] != "v1.0.0" {
+		t.Errorf("no tag should be created in dry-run mode, got %v", tags)
+	}
+}
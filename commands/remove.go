@@ -51,6 +51,7 @@ func init_remove_alias_command(parentCmd *cobra.Command, app *types.AppContext)
 			err := app.UpdateAliasesFile()
 			utils.CheckForError(err)
 		},
+		ValidArgsFunction: utils.CreateCompletionFunc(app.GetAliasNames),
 	}
 
 	parentCmd.AddCommand(
@@ -120,6 +121,7 @@ func init_remove_project_command(parentCmd *cobra.Command, app *types.AppContext
 			err := app.UpdateProjectsFile()
 			utils.CheckForError(err)
 		},
+		ValidArgsFunction: utils.CreateCompletionFunc(app.GetProjectNames),
 	}
 
 	parentCmd.AddCommand(
@@ -23,14 +23,50 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// create_self_command() - creates a command which runs this executable
+// with `args` and all global flags of the current command line, like
+// `--environment` or `--quiet`
+func create_self_command(app *types.AppContext, cmd *cobra.Command, args ...string) *exec.Cmd {
+	selfPath, err := os.Executable()
+	utils.CheckForError(err)
+
+	childArgs := append([]string{}, args...)
+	cmd.Root().PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+
+		if sliceValue, ok := f.Value.(pflag.SliceValue); ok {
+			// like `--env-file`
+			for _, v := range sliceValue.GetSlice() {
+				childArgs = append(childArgs, fmt.Sprintf("--%v=%v", f.Name, v))
+			}
+		} else {
+			childArgs = append(childArgs, fmt.Sprintf("--%v=%v", f.Name, f.Value.String()))
+		}
+	})
+
+	p := utils.CreateShellCommandByArgs(selfPath, childArgs...)
+	p.Dir = app.Cwd
+
+	return p
+}
+
 func run_scripts(app *types.AppContext, args []string) {
 	scriptsToExecute := []string{}
 
@@ -40,7 +76,7 @@ func run_scripts(app *types.AppContext, args []string) {
 			continue
 		}
 
-		_, ok := app.GpmFile.Scripts[scriptName]
+		ok := app.HasScript(scriptName)
 		if !ok {
 			utils.CloseWithError(fmt.Errorf("script '%v' not found", scriptName))
 		}
@@ -59,8 +95,78 @@ func run_scripts(app *types.AppContext, args []string) {
 	}
 }
 
+// run_with_watch() - starts a process, created by `createCmd`, and restarts it
+// whenever files inside `watchDir`, which match one of `watchPatterns`, change
+func run_with_watch(app *types.AppContext, createCmd func() *exec.Cmd, watchDir string, watchPatterns []string) {
+	var current *exec.Cmd
+	var done chan struct{}
+
+	start := func() {
+		p := createCmd()
+		utils.PrepareProcessGroup(p)
+
+		app.Debug(fmt.Sprintf("Starting '%v' ...", strings.Join(p.Args, " ")))
+		err := p.Start()
+		if err != nil {
+			app.Warn(fmt.Sprintf("Could not start '%v': %v", strings.Join(p.Args, " "), err))
+			current = nil
+			return
+		}
+
+		d := make(chan struct{})
+		go func() {
+			p.Wait()
+			close(d)
+		}()
+
+		current = p
+		done = d
+	}
+
+	stop := func() {
+		if current == nil {
+			return
+		}
+
+		select {
+		case <-done:
+			// already finished
+		default:
+			app.Debug(fmt.Sprintf("Stopping process %v ...", current.Process.Pid))
+
+			err := utils.StopProcessGroup(current, done, 5*time.Second)
+			if err != nil {
+				app.Warn(fmt.Sprintf("Could not stop process %v: %v", current.Process.Pid, err))
+			}
+
+			<-done
+		}
+
+		current = nil
+	}
+
+	ctx, stopNotify := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopNotify()
+
+	start()
+
+	app.Debug(fmt.Sprintf("Watching '%v' for %v ...", watchDir, strings.Join(watchPatterns, ", ")))
+	err := utils.WatchFiles(ctx, watchDir, watchPatterns, func(changedFiles []string) {
+		app.Info(fmt.Sprintf("Detected changes in %v, restarting ...", strings.Join(changedFiles, ", ")))
+
+		stop()
+		start()
+	})
+
+	stop()
+	utils.CheckForError(err)
+}
+
 func Init_Run_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	var mode string
+	var watch bool
+	var watchDir string
+	var watchPatterns []string
 
 	var runCmd = &cobra.Command{
 		Use:     "run [resource]",
@@ -72,7 +178,24 @@ func Init_Run_Command(parentCmd *cobra.Command, app *types.AppContext) {
 
 			switch m {
 			case "", "s", "script", "scripts":
-				run_scripts(app, args)
+				if watch {
+					// run scripts without --watch in a child process
+					childArgs := append([]string{"run"}, args...)
+
+					patterns := watchPatterns
+					if len(patterns) == 0 {
+						patterns = app.GetGpmFilesSection()
+					}
+					if len(patterns) == 0 {
+						patterns = []string{`\.go$`}
+					}
+
+					run_with_watch(app, func() *exec.Cmd {
+						return create_self_command(app, cmd, childArgs...)
+					}, app.GetFullPathOrDefault(watchDir, app.Cwd), patterns)
+				} else {
+					run_scripts(app, args)
+				}
 			default:
 				utils.CloseWithError(fmt.Errorf("invalid value '%v' for mode", m))
 			}
@@ -80,6 +203,9 @@ func Init_Run_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	}
 
 	runCmd.Flags().StringVarP(&mode, "mode", "m", "", "the mode like scripts or workflows")
+	runCmd.Flags().BoolVarP(&watch, "watch", "w", false, "re-run on file changes")
+	runCmd.Flags().StringVarP(&watchDir, "watch-dir", "", "", "custom directory to watch")
+	runCmd.Flags().StringArrayVarP(&watchPatterns, "watch-pattern", "", []string{}, "regular expressions of files to watch, default is 'files' section of gpm.yaml")
 
 	parentCmd.AddCommand(
 		runCmd,
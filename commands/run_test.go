@@ -0,0 +1,68 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+func TestCreateSelfCommandForwardsGlobalFlags(t *testing.T) {
+	app := &types.AppContext{
+		Cwd: t.TempDir(),
+	}
+
+	var envFiles []string
+	var gpmRoot string
+	var quiet bool
+	var timings bool
+
+	rootCmd := &cobra.Command{Use: "gpm"}
+	rootCmd.PersistentFlags().StringArrayVarP(&envFiles, "env-file", "e", []string{}, "")
+	rootCmd.PersistentFlags().StringVarP(&gpmRoot, "gpm-root", "", "", "")
+	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "")
+	rootCmd.PersistentFlags().BoolVarP(&timings, "timings", "", false, "")
+
+	var childArgs []string
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "release",
+		Run: func(cmd *cobra.Command, args []string) {
+			p := create_self_command(app, cmd, "pack", "--no-checksum")
+			childArgs = p.Args[1:]
+		},
+	})
+
+	rootCmd.SetArgs([]string{"release", "-q", "--gpm-root=/tmp/my gpm", "-e", "a.env", "-e", "b.env"})
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "pack --no-checksum --env-file=a.env --env-file=b.env --gpm-root=/tmp/my gpm --quiet=true"
+	if actual := strings.Join(childArgs, " "); actual != expected {
+		t.Errorf("expected '%v', got '%v'", expected, actual)
+	}
+}
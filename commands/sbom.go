@@ -0,0 +1,255 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+type sbomModule struct {
+	license string
+	main    bool
+	path    string
+	purl    string
+	version string
+}
+
+// create_cyclonedx_bom() - creates a CycloneDX document from modules
+func create_cyclonedx_bom(modules []sbomModule, toolVersion string) types.CycloneDXBom {
+	bom := types.CycloneDXBom{
+		BomFormat:  "CycloneDX",
+		Components: []types.CycloneDXComponent{},
+		Metadata: &types.CycloneDXMetadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Tools: &types.CycloneDXTools{
+				Components: []types.CycloneDXComponent{
+					{
+						Name:    "gpm",
+						Type:    "application",
+						Version: toolVersion,
+					},
+				},
+			},
+		},
+		SerialNumber: "urn:uuid:" + uuid.New().String(),
+		SpecVersion:  "1.5",
+		Version:      1,
+	}
+
+	for _, m := range modules {
+		component := types.CycloneDXComponent{
+			BomRef:  m.purl,
+			Name:    m.path,
+			Purl:    m.purl,
+			Type:    "library",
+			Version: m.version,
+		}
+		if m.license != "" {
+			component.Licenses = []types.CycloneDXLicenseReference{
+				{
+					License: types.CycloneDXLicense{
+						Id: m.license,
+					},
+				},
+			}
+		}
+
+		if m.main {
+			component.Type = "application"
+			bom.Metadata.Component = &component
+		} else {
+			bom.Components = append(bom.Components, component)
+		}
+	}
+
+	return bom
+}
+
+// create_spdx_document() - creates a SPDX document from modules
+func create_spdx_document(modules []sbomModule, toolVersion string) types.SpdxDocument {
+	documentName := "unknown"
+	for _, m := range modules {
+		if m.main {
+			documentName = m.path
+			break
+		}
+	}
+
+	doc := types.SpdxDocument{
+		CreationInfo: types.SpdxCreationInfo{
+			Created:  time.Now().UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: gpm-" + toolVersion},
+		},
+		DataLicense:       "CC0-1.0",
+		DocumentNamespace: fmt.Sprintf("https://gpm.kloubert.dev/spdx/%s-%s", documentName, uuid.New().String()),
+		Name:              documentName,
+		Packages:          []types.SpdxPackage{},
+		Relationships:     []types.SpdxRelationship{},
+		SpdxId:            "SPDXRef-DOCUMENT",
+		SpdxVersion:       "SPDX-2.3",
+	}
+
+	mainPackageId := ""
+	dependencyIds := []string{}
+	for i, m := range modules {
+		license := m.license
+		if license == "" {
+			license = "NOASSERTION"
+		}
+
+		packageId := fmt.Sprintf("SPDXRef-Package-%v", i+1)
+
+		doc.Packages = append(doc.Packages, types.SpdxPackage{
+			DownloadLocation: "NOASSERTION",
+			ExternalRefs: []types.SpdxExternalRef{
+				{
+					ReferenceCategory: "PACKAGE-MANAGER",
+					ReferenceLocator:  m.purl,
+					ReferenceType:     "purl",
+				},
+			},
+			FilesAnalyzed:    false,
+			LicenseConcluded: license,
+			LicenseDeclared:  license,
+			Name:             m.path,
+			SpdxId:           packageId,
+			VersionInfo:      m.version,
+		})
+
+		if m.main {
+			mainPackageId = packageId
+		} else {
+			dependencyIds = append(dependencyIds, packageId)
+		}
+	}
+
+	if mainPackageId != "" {
+		doc.Relationships = append(doc.Relationships, types.SpdxRelationship{
+			RelatedSpdxElement: mainPackageId,
+			RelationshipType:   "DESCRIBES",
+			SpdxElementId:      doc.SpdxId,
+		})
+
+		for _, id := range dependencyIds {
+			doc.Relationships = append(doc.Relationships, types.SpdxRelationship{
+				RelatedSpdxElement: id,
+				RelationshipType:   "DEPENDS_ON",
+				SpdxElementId:      mainPackageId,
+			})
+		}
+	}
+
+	return doc
+}
+
+// get_sbom_modules() - collects all modules of the current project
+// with their package URLs and licenses
+func get_sbom_modules(app *types.AppContext) []sbomModule {
+	goModules, err := app.GetGoModules()
+	utils.CheckForError(err)
+
+	modules := []sbomModule{}
+	for _, m := range goModules {
+		if m.Path == nil {
+			continue
+		}
+
+		module := sbomModule{
+			main: m.Main != nil && *m.Main,
+			path: strings.TrimSpace(*m.Path),
+		}
+		if m.Version != nil {
+			module.version = strings.TrimSpace(*m.Version)
+		}
+		if m.Dir != nil {
+			module.license = utils.DetectLicense(*m.Dir)
+		}
+
+		// s. https://github.com/package-url/purl-spec
+		module.purl = "pkg:golang/" + strings.ToLower(module.path)
+		if module.version != "" {
+			module.purl += "@" + strings.ReplaceAll(module.version, "+", "%2B")
+		}
+
+		modules = append(modules, module)
+	}
+
+	return modules
+}
+
+func Init_Sbom_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var format string
+	var output string
+
+	var sbomCmd = &cobra.Command{
+		Use:   "sbom",
+		Short: "Create SBOM",
+		Long:  `Creates a Software Bill of Materials of the current project in CycloneDX or SPDX format.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			modules := get_sbom_modules(app)
+
+			var document interface{}
+			switch strings.TrimSpace(strings.ToLower(format)) {
+			case "", "cyclonedx":
+				document = create_cyclonedx_bom(modules, cmd.Root().Version)
+			case "spdx":
+				document = create_spdx_document(modules, cmd.Root().Version)
+			default:
+				utils.CloseWithError(fmt.Errorf("invalid value '%v' for format", format))
+			}
+
+			var out io.Writer = app.Out
+			output = strings.TrimSpace(output)
+			if output != "" {
+				outputFile, err := os.Create(app.GetFullPathOrDefault(output, ""))
+				utils.CheckForError(err)
+				defer outputFile.Close()
+
+				out = outputFile
+			}
+
+			encoder := json.NewEncoder(out)
+			encoder.SetIndent("", "  ")
+
+			err := encoder.Encode(document)
+			utils.CheckForError(err)
+		},
+	}
+
+	sbomCmd.Flags().StringVarP(&format, "format", "", "cyclonedx", "output format: cyclonedx or spdx")
+	sbomCmd.Flags().StringVarP(&output, "output", "o", "", "custom output file")
+
+	parentCmd.AddCommand(
+		sbomCmd,
+	)
+}
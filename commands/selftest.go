@@ -0,0 +1,283 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mkloubert/go-package-manager/constants"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+const selfTestModuleName = "example.com/gpm-self-test"
+
+const selfTestMainGo = `package main
+
+import "fmt"
+
+func Add(a, b int) int {
+	return a + b
+}
+
+func main() {
+	fmt.Println(Add(1, 2))
+}
+`
+
+const selfTestMainTestGo = `package main
+
+import "testing"
+
+func TestAdd(t *testing.T) {
+	if Add(1, 2) != 3 {
+		t.Fatal("1 + 2 should be 3")
+	}
+}
+`
+
+func Init_SelfTest_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var keep bool
+	var noAI bool
+
+	var selfTestCmd = &cobra.Command{
+		Use:     "self-test",
+		Aliases: []string{"selftest"},
+		Short:   "Tests installation",
+		Long:    `Runs a battery of safe checks to verify that this installation works.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			green := color.New(color.FgGreen).SprintFunc()
+			red := color.New(color.FgRed).SprintFunc()
+			yellow := color.New(color.FgYellow).SprintFunc()
+
+			executable, err := os.Executable()
+			utils.CheckForError(err)
+
+			tempDir, err := os.MkdirTemp("", "gpm-self-test-")
+			utils.CheckForError(err)
+			if keep {
+				fmt.Printf("Working directory: %s%s", tempDir, fmt.Sprintln())
+			} else {
+				defer os.RemoveAll(tempDir)
+			}
+
+			// runs this executable with arguments and optional input
+			// inside the temp directory and returns STDOUT
+			runSelf := func(input []byte, args ...string) (string, error) {
+				app.Debug(fmt.Sprintf("Running 'gpm %v' ...", strings.Join(args, " ")))
+
+				var stdout bytes.Buffer
+				var stderr bytes.Buffer
+
+				p := exec.Command(executable, args...)
+				p.Dir = tempDir
+				p.Stdout = &stdout
+				p.Stderr = &stderr
+				if input != nil {
+					p.Stdin = bytes.NewReader(input)
+				}
+
+				err := p.Run()
+				if err != nil {
+					return stdout.String(), fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr.String()))
+				}
+
+				return stdout.String(), nil
+			}
+
+			failed := 0
+			check := func(name string, f func() error) {
+				err := f()
+				if err == nil {
+					fmt.Printf("\t[%s] %s%s", green("✓"), name, fmt.Sprintln())
+				} else {
+					failed++
+					fmt.Printf("\t[%s] %s: %s%s", red("!"), name, err.Error(), fmt.Sprintln())
+				}
+			}
+			skip := func(name string, reason string) {
+				fmt.Printf("\t[%s] %s skipped: %s%s", yellow("-"), name, reason, fmt.Sprintln())
+			}
+
+			fmt.Println("Checking project commands ...")
+			check("create temp module", func() error {
+				err := os.WriteFile(
+					filepath.Join(tempDir, "go.mod"),
+					[]byte(fmt.Sprintf("module %s%s%sgo 1.20%s", selfTestModuleName, fmt.Sprintln(), fmt.Sprintln(), fmt.Sprintln())),
+					constants.DefaultFileMode,
+				)
+				if err != nil {
+					return err
+				}
+
+				err = os.WriteFile(filepath.Join(tempDir, "main.go"), []byte(selfTestMainGo), constants.DefaultFileMode)
+				if err != nil {
+					return err
+				}
+
+				return os.WriteFile(filepath.Join(tempDir, "main_test.go"), []byte(selfTestMainTestGo), constants.DefaultFileMode)
+			})
+			check("build", func() error {
+				_, err := runSelf(nil, "build")
+				if err != nil {
+					return err
+				}
+
+				executableName := path.Base(selfTestModuleName)
+				if utils.IsWindows() {
+					executableName += constants.WindowsExecutableExt
+				}
+
+				isExisting, err := utils.IsFileExisting(filepath.Join(tempDir, executableName))
+				if err == nil && !isExisting {
+					err = fmt.Errorf("executable '%s' not found", executableName)
+				}
+				return err
+			})
+			check("test", func() error {
+				_, err := runSelf(nil, "test")
+				return err
+			})
+			check("pack", func() error {
+				_, err := runSelf(nil, "pack", "--no-checksum", "--version", "0.0.1")
+				if err != nil {
+					return err
+				}
+
+				zipFiles, err := filepath.Glob(filepath.Join(tempDir, "*.zip"))
+				if err == nil && len(zipFiles) == 0 {
+					err = fmt.Errorf("no zip file created")
+				}
+				return err
+			})
+			fmt.Println()
+
+			fmt.Println("Checking tools ...")
+			check("generate guid", func() error {
+				output, err := runSelf(nil, "generate", "guid")
+				if err != nil {
+					return err
+				}
+
+				guidRegex := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+				if !guidRegex.MatchString(strings.TrimSpace(output)) {
+					return fmt.Errorf("invalid GUID '%s'", output)
+				}
+				return nil
+			})
+			check("generate password", func() error {
+				output, err := runSelf(nil, "generate", "password", "--length", "32")
+				if err != nil {
+					return err
+				}
+
+				if len(output) != 32 {
+					return fmt.Errorf("password has %v instead of 32 characters", len(output))
+				}
+				return nil
+			})
+			check("base64", func() error {
+				output, err := runSelf([]byte("gpm"), "base64")
+				if err != nil {
+					return err
+				}
+
+				if strings.TrimSpace(output) != "Z3Bt" {
+					return fmt.Errorf("unexpected output '%s'", output)
+				}
+				return nil
+			})
+			check("compress round-trip", func() error {
+				input := []byte(strings.Repeat("Go Package Manager ", 100))
+
+				compressed, err := runSelf(input, "compress")
+				if err != nil {
+					return err
+				}
+
+				uncompressed, err := runSelf([]byte(compressed), "uncompress")
+				if err != nil {
+					return err
+				}
+
+				if uncompressed != string(input) {
+					return fmt.Errorf("uncompressed data does not match input")
+				}
+				return nil
+			})
+			fmt.Println()
+
+			fmt.Println("Checking AI ...")
+			if noAI {
+				skip("AI connectivity", "--no-ai flag is set")
+			} else if os.Getenv("GPM_AI_API") == "" && os.Getenv("OPENAI_API_KEY") == "" && !app.Ollama {
+				skip("AI connectivity", "no AI provider configured")
+			} else {
+				check("AI connectivity", func() error {
+					chat, err := app.CreateAIChat()
+					if err != nil {
+						return err
+					}
+
+					answer := ""
+					err = chat.SendPrompt("Answer only with 'OK'.", func(messageChunk string) error {
+						answer += messageChunk
+						return nil
+					})
+					if err == nil && strings.TrimSpace(answer) == "" {
+						err = fmt.Errorf("empty answer from %s (%s)", chat.GetProvider(), chat.GetModel())
+					}
+					return err
+				})
+			}
+			fmt.Println()
+
+			if failed > 0 {
+				if !keep {
+					// deferred calls are not executed by CloseWithError()
+					os.RemoveAll(tempDir)
+				}
+
+				utils.CloseWithError(fmt.Errorf("%v check(s) failed", failed))
+			}
+
+			fmt.Printf("[%s] All checks passed%s", green("✓"), fmt.Sprintln())
+		},
+	}
+
+	selfTestCmd.Flags().BoolVarP(&keep, "keep", "", false, "keep temporary working directory")
+	selfTestCmd.Flags().BoolVarP(&noAI, "no-ai", "", false, "do not check AI connectivity")
+
+	parentCmd.AddCommand(
+		selfTestCmd,
+	)
+}
@@ -0,0 +1,377 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+const serveLiveReloadPath = "/__gpm/live-reload"
+
+const serveLiveReloadScript = `<script>new EventSource("` + serveLiveReloadPath + `").onmessage = function () { location.reload(); };</script>`
+
+// serveHandlerOptions stores options for `create_serve_handler()`
+type serveHandlerOptions struct {
+	Dotfiles   bool // serve files and directories whose names start with `.`
+	Listing    bool // list contents of directories without index.html
+	LiveReload bool // inject live reload script into HTML pages
+	SPA        bool // serve index.html for unknown routes
+}
+
+// serveNoDotfilesFileSystem is an implementation of http.FileSystem, which
+// hides files and directories whose names start with `.`
+type serveNoDotfilesFileSystem struct {
+	http.FileSystem
+}
+
+// serveNoDotfilesFile is an implementation of http.File, which
+// does not list entries whose names start with `.`
+type serveNoDotfilesFile struct {
+	http.File
+}
+
+// create_serve_handler() - creates the handler, which serves the files of `rootDir`
+func create_serve_handler(app *types.AppContext, rootDir string, options serveHandlerOptions) http.Handler {
+	var fileSystem http.FileSystem = http.Dir(rootDir)
+	if !options.Dotfiles {
+		fileSystem = serveNoDotfilesFileSystem{fileSystem}
+	}
+
+	fileServer := http.FileServer(fileSystem)
+
+	serveFile := func(w http.ResponseWriter, r *http.Request, filePath string) {
+		ext := strings.ToLower(filepath.Ext(filePath))
+		if !options.LiveReload || (ext != ".html" && ext != ".htm") {
+			http.ServeFile(w, r, filePath)
+			return
+		}
+
+		html, err := os.ReadFile(filePath)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(inject_live_reload_script(string(html))))
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		app.Debug(fmt.Sprintf("%v %v", r.Method, r.URL.Path))
+
+		urlPath := path.Clean("/" + r.URL.Path)
+		if !options.Dotfiles && is_serve_dotfile_path(urlPath) {
+			// like `.env` or `.git/config`
+			http.NotFound(w, r)
+			return
+		}
+
+		fullPath := filepath.Join(rootDir, filepath.FromSlash(urlPath))
+
+		info, err := os.Stat(fullPath)
+		if err == nil {
+			if !info.IsDir() {
+				serveFile(w, r, fullPath)
+				return
+			}
+
+			indexFile := filepath.Join(fullPath, "index.html")
+			isIndexFileExisting, _ := utils.IsFileExisting(indexFile)
+			if isIndexFileExisting {
+				serveFile(w, r, indexFile)
+				return
+			}
+
+			if options.Listing {
+				fileServer.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		if options.SPA && path.Ext(urlPath) == "" {
+			// let client side router handle unknown routes
+			indexFile := filepath.Join(rootDir, "index.html")
+			isIndexFileExisting, _ := utils.IsFileExisting(indexFile)
+			if isIndexFileExisting {
+				serveFile(w, r, indexFile)
+				return
+			}
+		}
+
+		http.NotFound(w, r)
+	})
+}
+
+// get_serve_dir_state() - returns a value which changes if files
+// inside a directory are added, removed or updated
+func get_serve_dir_state(dir string) string {
+	var fileCount int
+	var lastModTime time.Time
+
+	filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.IsDir() {
+			switch d.Name() {
+			case ".git", "node_modules":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err == nil {
+			fileCount++
+			if info.ModTime().After(lastModTime) {
+				lastModTime = info.ModTime()
+			}
+		}
+		return nil
+	})
+
+	return fmt.Sprintf("%v:%v", fileCount, lastModTime.UnixNano())
+}
+
+// inject_live_reload_script() - adds the live reload script to HTML content
+func inject_live_reload_script(html string) string {
+	bodyEnd := strings.LastIndex(strings.ToLower(html), "</body>")
+	if bodyEnd < 0 {
+		return html + serveLiveReloadScript
+	}
+
+	return html[:bodyEnd] + serveLiveReloadScript + html[bodyEnd:]
+}
+
+// is_serve_dotfile_path() - returns `true` if a segment of
+// an URL path starts with `.`
+func is_serve_dotfile_path(urlPath string) bool {
+	for _, segment := range strings.Split(urlPath, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (fsys serveNoDotfilesFileSystem) Open(name string) (http.File, error) {
+	if is_serve_dotfile_path(name) {
+		return nil, fs.ErrNotExist
+	}
+
+	f, err := fsys.FileSystem.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return serveNoDotfilesFile{f}, nil
+}
+
+func (f serveNoDotfilesFile) Readdir(count int) ([]fs.FileInfo, error) {
+	entries, err := f.File.Readdir(count)
+
+	visibleEntries := []fs.FileInfo{}
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Name(), ".") {
+			visibleEntries = append(visibleEntries, e)
+		}
+	}
+
+	return visibleEntries, err
+}
+
+func Init_Serve_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var dir string
+	var dotfiles bool
+	var host string
+	var listing bool
+	var liveReload bool
+	var noOpen bool
+	var port int
+	var spa bool
+
+	var serveCmd = &cobra.Command{
+		Use:     "serve",
+		Aliases: []string{"srv"},
+		Short:   "Serve files",
+		Long:    `Serves static files of a directory over HTTP.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			rootDir := app.GetFullPathOrDefault(dir, app.Cwd)
+
+			isRootDirExisting, err := utils.IsDirExisting(rootDir)
+			utils.CheckForError(err)
+			if !isRootDirExisting {
+				utils.CloseWithError(fmt.Errorf("directory '%v' not found", rootDir))
+			}
+
+			// changes if files have been changed
+			var reloadVersion atomic.Int64
+
+			mux := http.NewServeMux()
+			if liveReload {
+				mux.HandleFunc(serveLiveReloadPath, func(w http.ResponseWriter, r *http.Request) {
+					flusher, ok := w.(http.Flusher)
+					if !ok {
+						http.Error(w, "streaming not supported", http.StatusInternalServerError)
+						return
+					}
+
+					w.Header().Set("Cache-Control", "no-cache")
+					w.Header().Set("Content-Type", "text/event-stream")
+					flusher.Flush()
+
+					lastVersion := reloadVersion.Load()
+
+					ticker := time.NewTicker(250 * time.Millisecond)
+					defer ticker.Stop()
+
+					for {
+						select {
+						case <-r.Context().Done():
+							return
+						case <-ticker.C:
+							currentVersion := reloadVersion.Load()
+							if currentVersion != lastVersion {
+								lastVersion = currentVersion
+
+								fmt.Fprint(w, "data: reload\n\n")
+								flusher.Flush()
+							}
+						}
+					}
+				})
+			}
+			mux.Handle("/", create_serve_handler(app, rootDir, serveHandlerOptions{
+				Dotfiles:   dotfiles,
+				Listing:    listing,
+				LiveReload: liveReload,
+				SPA:        spa,
+			}))
+
+			listener, err := net.Listen("tcp", fmt.Sprintf("%v:%v", host, port))
+			utils.CheckForError(err)
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			server := &http.Server{
+				// s.t. open live reload streams end on shutdown
+				BaseContext: func(l net.Listener) context.Context {
+					return ctx
+				},
+				Handler: mux,
+			}
+
+			if liveReload {
+				go func() {
+					lastState := get_serve_dir_state(rootDir)
+
+					ticker := time.NewTicker(time.Second)
+					defer ticker.Stop()
+
+					for {
+						select {
+						case <-ctx.Done():
+							return
+						case <-ticker.C:
+							currentState := get_serve_dir_state(rootDir)
+							if currentState != lastState {
+								lastState = currentState
+
+								app.Debug("Files changed, reloading ...")
+								reloadVersion.Add(1)
+							}
+						}
+					}
+				}()
+			}
+
+			serverErrors := make(chan error, 1)
+			go func() {
+				serverErrors <- server.Serve(listener)
+			}()
+
+			url := fmt.Sprintf("http://%v", listener.Addr().String())
+			if host == "" || host == "0.0.0.0" {
+				url = fmt.Sprintf("http://localhost:%v", listener.Addr().(*net.TCPAddr).Port)
+			}
+
+			fmt.Fprintf(app.Out, "Serving '%v' at %v%v", rootDir, url, fmt.Sprintln())
+
+			if !noOpen {
+				err := utils.OpenUrl(url)
+				if err != nil {
+					app.Warn(fmt.Sprintf("Could not open '%v': %v", url, err))
+				}
+			}
+
+			select {
+			case err := <-serverErrors:
+				if !errors.Is(err, http.ErrServerClosed) {
+					utils.CheckForError(err)
+				}
+			case <-ctx.Done():
+				app.Debug("Shutting down server ...")
+
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+
+				err := server.Shutdown(shutdownCtx)
+				utils.CheckForError(err)
+			}
+		},
+	}
+
+	serveCmd.Flags().StringVarP(&dir, "dir", "", "", "custom directory to serve")
+	serveCmd.Flags().BoolVarP(&dotfiles, "dotfiles", "", false, "serve files and directories whose names start with '.'")
+	serveCmd.Flags().StringVarP(&host, "host", "", "localhost", "host address to bind to")
+	serveCmd.Flags().BoolVarP(&listing, "listing", "", false, "list contents of directories without index.html")
+	serveCmd.Flags().BoolVarP(&liveReload, "live-reload", "", false, "reload HTML pages in browser if files change")
+	serveCmd.Flags().BoolVarP(&noOpen, "no-open", "", false, "do not open URL in browser")
+	serveCmd.Flags().IntVarP(&port, "port", "p", 8080, "port to listen on")
+	serveCmd.Flags().BoolVarP(&spa, "spa", "", false, "serve index.html for unknown routes of single page applications")
+
+	parentCmd.AddCommand(
+		serveCmd,
+	)
+}
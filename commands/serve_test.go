@@ -0,0 +1,115 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+)
+
+func TestServeHandlerWithDotfiles(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		".env":           "SECRET=1",
+		".git/config":    "[core]",
+		"sub/.hidden":    "hidden",
+		"sub/visible.js": "visible",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+
+		err := os.MkdirAll(filepath.Dir(filePath), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filePath, []byte(content), 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := &types.AppContext{}
+
+	tests := []struct {
+		dotfiles bool
+		urlPath  string
+		expected int
+	}{
+		{false, "/.env", http.StatusNotFound},
+		{false, "/.git/config", http.StatusNotFound},
+		{false, "/.git/", http.StatusNotFound},
+		{false, "/sub/.hidden", http.StatusNotFound},
+		{false, "/sub/../.env", http.StatusNotFound},
+		{false, "/sub/visible.js", http.StatusOK},
+		{true, "/.env", http.StatusOK},
+		{true, "/.git/config", http.StatusOK},
+		{true, "/sub/.hidden", http.StatusOK},
+	}
+
+	for _, test := range tests {
+		handler := create_serve_handler(app, dir, serveHandlerOptions{
+			Dotfiles: test.dotfiles,
+			Listing:  true,
+			SPA:      true,
+		})
+
+		r := httptest.NewRequest(http.MethodGet, test.urlPath, nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+		if w.Code != test.expected {
+			t.Errorf("'%v' (dotfiles: %v): expected status %v, got %v", test.urlPath, test.dotfiles, test.expected, w.Code)
+		}
+	}
+
+	// directory listing
+	for _, dotfiles := range []bool{false, true} {
+		handler := create_serve_handler(app, dir, serveHandlerOptions{
+			Dotfiles: dotfiles,
+			Listing:  true,
+		})
+
+		r := httptest.NewRequest(http.MethodGet, "/sub/", nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200 for listing, got %v", w.Code)
+		}
+
+		listing := w.Body.String()
+		if !strings.Contains(listing, "visible.js") {
+			t.Errorf("expected 'visible.js' in listing: %v", listing)
+		}
+		if strings.Contains(listing, ".hidden") != dotfiles {
+			t.Errorf("unexpected '.hidden' in listing (dotfiles: %v): %v", dotfiles, listing)
+		}
+	}
+}
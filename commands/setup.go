@@ -42,6 +42,17 @@ import (
 	"github.com/mkloubert/go-package-manager/utils"
 )
 
+// get_setup_path_powershell_line() - returns a PowerShell command, which adds
+// `binPath` to the PATH of the user only once and does not copy the machine PATH
+func get_setup_path_powershell_line(binPath string) string {
+	quotedBinPath := "'" + strings.ReplaceAll(binPath, "'", "''") + "'"
+
+	return fmt.Sprintf(
+		`$userPath = [Environment]::GetEnvironmentVariable("Path", "User"); if (($userPath -split ";") -notcontains %v) { [Environment]::SetEnvironmentVariable("Path", ($userPath + ";" + %v).TrimStart(";"), "User") }`,
+		quotedBinPath, quotedBinPath,
+	)
+}
+
 func init_setup_git_command(parentCmd *cobra.Command, app *types.AppContext) {
 	var force bool
 	var local bool
@@ -93,6 +104,108 @@ func init_setup_git_command(parentCmd *cobra.Command, app *types.AppContext) {
 	)
 }
 
+func init_setup_path_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var printOnly bool
+	var profile string
+
+	var setupPathCmd = &cobra.Command{
+		Use:     "path",
+		Aliases: []string{"p", "pth"},
+		Short:   "Setup PATH",
+		Long:    `Adds the bin folder of this tool to the PATH by updating the shell profile.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			binPath, err := app.GetBinFolderPath()
+			utils.CheckForError(err)
+
+			if utils.IsInPathEnv(binPath) {
+				fmt.Printf("'%v' is already part of PATH%v", binPath, fmt.Sprintln())
+				return
+			}
+
+			homeDir, err := os.UserHomeDir()
+			utils.CheckForError(err)
+
+			shell := utils.GetShell()
+			app.Debug(fmt.Sprintf("Shell: %v", shell))
+
+			var line string
+			var profileFile string
+			switch shell {
+			case "Bash":
+				line = fmt.Sprintf(`export PATH="$PATH:%v"`, binPath)
+				profileFile = path.Join(homeDir, ".bashrc")
+			case "Z shell":
+				line = fmt.Sprintf(`export PATH="$PATH:%v"`, binPath)
+				profileFile = path.Join(homeDir, ".zshrc")
+			case "Fish":
+				line = fmt.Sprintf(`fish_add_path "%v"`, binPath)
+				profileFile = path.Join(homeDir, ".config", "fish", "config.fish")
+			case "PowerShell":
+				line = get_setup_path_powershell_line(binPath)
+			case "cmd.exe":
+				line = fmt.Sprintf(`set "PATH=%%PATH%%;%v"`, binPath)
+			default:
+				if utils.IsWindows() {
+					line = get_setup_path_powershell_line(binPath)
+				} else {
+					line = fmt.Sprintf(`export PATH="$PATH:%v"`, binPath)
+				}
+			}
+
+			if strings.TrimSpace(profile) != "" {
+				profileFile = app.GetFullPathOrDefault(profile, "")
+			}
+
+			if printOnly || profileFile == "" || app.DryRun {
+				if profileFile == "" && !printOnly {
+					fmt.Printf("Shell '%v' is not supported, add the following line manually:%v", shell, fmt.Sprintln())
+				}
+
+				fmt.Println(line)
+				return
+			}
+
+			profileData, err := os.ReadFile(profileFile)
+			if err != nil && !os.IsNotExist(err) {
+				utils.CheckForError(err)
+			}
+
+			if strings.Contains(string(profileData), line) {
+				fmt.Printf("'%v' already contains '%v'%v", profileFile, binPath, fmt.Sprintln())
+				return
+			}
+
+			err = os.MkdirAll(path.Dir(profileFile), constants.DefaultDirMode)
+			utils.CheckForError(err)
+
+			f, err := os.OpenFile(profileFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			utils.CheckForError(err)
+			defer f.Close()
+
+			newContent := fmt.Sprintf("%v# added by gpm%v%v%v", fmt.Sprintln(), fmt.Sprintln(), line, fmt.Sprintln())
+			if len(profileData) > 0 && !strings.HasSuffix(string(profileData), "\n") {
+				newContent = fmt.Sprintln() + newContent
+			}
+
+			app.Debug(fmt.Sprintf("Updating '%v' ...", profileFile))
+			_, err = f.WriteString(newContent)
+			utils.CheckForError(err)
+
+			fmt.Printf(
+				"Added '%v' to '%v', restart your shell to apply the change%v",
+				binPath, color.New(color.FgWhite, color.Bold).Sprint(profileFile), fmt.Sprintln(),
+			)
+		},
+	}
+
+	setupPathCmd.Flags().BoolVarP(&printOnly, "print", "", false, "only print the line for manual addition")
+	setupPathCmd.Flags().StringVarP(&profile, "profile", "", "", "custom shell profile file")
+
+	parentCmd.AddCommand(
+		setupPathCmd,
+	)
+}
+
 func init_setup_updater_command(parentCmd *cobra.Command, app *types.AppContext) {
 	var installPath string
 
@@ -114,8 +227,46 @@ func init_setup_updater_command(parentCmd *cobra.Command, app *types.AppContext)
 			var createScript func()
 
 			if utils.IsWindows() {
-				// not supported
-				createScript = nil
+				targetFolder := strings.TrimSpace(installPath)
+				if targetFolder == "" {
+					targetFolder = `C:\Program Files\gpm`
+				}
+
+				pwshScriptFilePath := path.Join(binPath, "gpm-update.ps1")
+
+				createScript = func() {
+					templateData, err := resources.Templates.ReadFile("templates/gpm-update.ps1")
+					utils.CheckForError(err)
+
+					template, err := template.New("gpm-update.ps1").Parse(string(templateData))
+					utils.CheckForError(err)
+
+					var pwshScriptBuffer bytes.Buffer
+					err = template.Execute(&pwshScriptBuffer, map[string]string{
+						"GOOS":         goos,
+						"GOARCH":       goarch,
+						"TargetFolder": targetFolder,
+					})
+					utils.CheckForError(err)
+					defer pwshScriptBuffer.Reset()
+
+					pwshScript := pwshScriptBuffer.String()
+
+					app.Debug(fmt.Sprintf("Writing PowerShell script to '%v' ...", pwshScriptFilePath))
+					err = os.WriteFile(pwshScriptFilePath, []byte(pwshScript), constants.DefaultFileMode)
+					utils.CheckForError(err)
+
+					fmt.Printf(
+						"Wrote following script to '%v':%v%v",
+						color.New(color.FgWhite, color.Bold).Sprint(pwshScriptFilePath),
+						fmt.Sprintln(), fmt.Sprintln(),
+					)
+
+					err = quick.Highlight(app.Out, pwshScript, "powershell", consoleFormatter, consoleStyle)
+					if err != nil {
+						fmt.Print(pwshScript)
+					}
+				}
 			} else {
 				targetFolder := strings.TrimSpace(installPath)
 				if targetFolder == "" {
@@ -174,7 +325,7 @@ func init_setup_updater_command(parentCmd *cobra.Command, app *types.AppContext)
 		},
 	}
 
-	setupUpdaterCmd.Flags().StringVarP(&installPath, "install-path", "", "", "custom target folder")
+	setupUpdaterCmd.Flags().StringVarP(&installPath, "install-path", "", "", "custom target folder, default is '/usr/local/bin' or 'C:\\Program Files\\gpm' on Windows")
 
 	parentCmd.AddCommand(
 		setupUpdaterCmd,
@@ -193,6 +344,7 @@ func Init_Setup_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	}
 
 	init_setup_git_command(setupCmd, app)
+	init_setup_path_command(setupCmd, app)
 	init_setup_updater_command(setupCmd, app)
 
 	parentCmd.AddCommand(
@@ -23,15 +23,18 @@
 package commands
 
 import (
-	"bufio"
+	"encoding/json"
 	"fmt"
 	"html"
 	"os"
-	"os/exec"
 	"path"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 
 	"github.com/mkloubert/go-package-manager/constants"
@@ -64,13 +67,7 @@ func init_show_dependencies_command(parentCmd *cobra.Command, app *types.AppCont
 			graphInfoboxWidth := strings.TrimSpace(infoboxWidth)
 			graphSidebarWidth := strings.TrimSpace(sidebarWidth)
 
-			cmdArgs := []string{"go", "mod", "graph"}
-
-			p := exec.Command(cmdArgs[0], cmdArgs[1:]...)
-			p.Dir = app.Cwd
-
-			app.Debug(fmt.Sprintf("Running '%v' ...", strings.Join(cmdArgs, " ")))
-			dependencyGraph, err := p.Output()
+			dependencyGraph, err := app.GetGoModuleGraph()
 			utils.CheckForError(err)
 
 			installedModulesAndVersions := map[string]bool{}
@@ -89,20 +86,10 @@ func init_show_dependencies_command(parentCmd *cobra.Command, app *types.AppCont
 				)
 			}
 
-			scanner := bufio.NewScanner(strings.NewReader(string(dependencyGraph)))
-			for scanner.Scan() {
-				// read line and split into
-				// parts from space as separator
-				line := scanner.Text()
-				parts := strings.Fields(line)
-
-				if len(parts) != 2 {
-					continue
-				}
-
+			for _, edge := range dependencyGraph.Edges {
 				// get left and right part
-				left := strings.TrimSpace(parts[0])
-				right := strings.TrimSpace(parts[1])
+				left := edge.From
+				right := edge.To
 
 				installedModulesAndVersions[left] = true
 				installedModulesAndVersions[right] = true
@@ -139,9 +126,6 @@ func init_show_dependencies_command(parentCmd *cobra.Command, app *types.AppCont
 				)
 			}
 
-			err = scanner.Err()
-			utils.CheckForError(err)
-
 			// first collect
 			installedModuleHtmlList := []interface{}{}
 			for k := range installedModulesAndVersions {
@@ -158,14 +142,7 @@ func init_show_dependencies_command(parentCmd *cobra.Command, app *types.AppCont
 					installedModuleHtmlList[i].(string),
 				)
 
-				name := nameAndVersion
-				version := ""
-
-				sepIndex := strings.Index(nameAndVersion, "@")
-				if sepIndex > -1 {
-					version = strings.TrimSpace(name[sepIndex+1:])
-					name = strings.TrimSpace(name[0:sepIndex])
-				}
+				name, version := types.SplitGoModuleAndVersion(nameAndVersion)
 
 				moduleLink := ""
 				if name != "" {
@@ -255,6 +232,227 @@ func init_show_dependencies_command(parentCmd *cobra.Command, app *types.AppCont
 	)
 }
 
+// aiHistoryItem stores the summarized AI usage of a gpm command
+type aiHistoryItem struct {
+	Command      string   `json:"command"`
+	Cost         *float64 `json:"cost,omitempty"`
+	Errors       int      `json:"errors"`
+	First        string   `json:"first"`
+	InputTokens  int      `json:"inputTokens"`
+	Last         string   `json:"last"`
+	Models       []string `json:"models"`
+	OutputTokens int      `json:"outputTokens"`
+	Requests     int      `json:"requests"`
+}
+
+// parse_ai_history_since() - parses the value of `--since` flag, which can be
+// a duration like `24h` or a date like `2006-01-02` or `2006-01-02T15:04:05Z07:00`
+func parse_ai_history_since(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if d, err := utils.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid value '%v' for --since", s)
+}
+
+// summarize_ai_log_entries() - summarizes AI log entries by gpm command,
+// entries before `since` are ignored
+func summarize_ai_log_entries(app *types.AppContext, entries []types.AILogEntry, since time.Time) []aiHistoryItem {
+	items := []aiHistoryItem{}
+	firstTimes := map[string]time.Time{}
+	lastTimes := map[string]time.Time{}
+	hasUnknownPrice := map[string]bool{}
+
+	for _, e := range entries {
+		t, err := time.Parse(time.RFC3339, e.Time)
+		if err != nil {
+			app.Debug(fmt.Sprintf("Invalid time '%v' in AI log entry", e.Time))
+			continue
+		}
+		if !since.IsZero() && t.Before(since) {
+			continue
+		}
+
+		command := strings.TrimSpace(e.Command)
+		if command == "" {
+			command = "(unknown)"
+		}
+
+		i := slices.IndexFunc(items, func(item aiHistoryItem) bool {
+			return item.Command == command
+		})
+		if i < 0 {
+			items = append(items, aiHistoryItem{
+				Command: command,
+				Models:  []string{},
+			})
+			i = len(items) - 1
+
+			firstTimes[command] = t
+			lastTimes[command] = t
+		}
+
+		item := &items[i]
+		usage := e.GetUsage()
+
+		item.Requests++
+		if e.Error != "" || e.Status >= 400 {
+			item.Errors++
+		}
+		item.InputTokens += usage.InputTokens
+		item.OutputTokens += usage.OutputTokens
+		if usage.Model != "" && !slices.Contains(item.Models, usage.Model) {
+			item.Models = append(item.Models, usage.Model)
+		}
+
+		if t.Before(firstTimes[command]) {
+			firstTimes[command] = t
+		}
+		if t.After(lastTimes[command]) {
+			lastTimes[command] = t
+		}
+
+		if usage.InputTokens > 0 || usage.OutputTokens > 0 {
+			price, ok := app.SettingsFile.GetAIPrice(usage.Model)
+			if ok {
+				cost := float64(usage.InputTokens)/1000000.0*price.Input +
+					float64(usage.OutputTokens)/1000000.0*price.Output
+				if item.Cost == nil {
+					item.Cost = new(float64)
+				}
+				*item.Cost += cost
+			} else {
+				hasUnknownPrice[command] = true
+			}
+		}
+	}
+
+	for i := range items {
+		item := &items[i]
+
+		item.First = firstTimes[item.Command].Format(time.RFC3339)
+		item.Last = lastTimes[item.Command].Format(time.RFC3339)
+		if hasUnknownPrice[item.Command] {
+			// an incomplete estimation would be misleading
+			item.Cost = nil
+		}
+	}
+
+	sort.Slice(items, func(x, y int) bool {
+		return strings.ToLower(items[x].Command) < strings.ToLower(items[y].Command)
+	})
+
+	return items
+}
+
+func init_show_history_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var outputJson bool
+	var since string
+
+	var showHistoryCmd = &cobra.Command{
+		Use:     "history [log file]",
+		Aliases: []string{"hist", "h"},
+		Short:   "Show AI history",
+		Long:    `Shows a summary of AI interactions from the log file of '--ai-log' flag or 'GPM_AI_LOG' environment variable.`,
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			logFile := app.GetAILogFile()
+			if len(args) > 0 {
+				logFile = app.GetFullPathOrDefault(args[0], "")
+			}
+			if logFile == "" {
+				utils.CloseWithError(fmt.Errorf("no AI log file defined"))
+			}
+
+			var sinceTime time.Time
+			if strings.TrimSpace(since) != "" {
+				t, err := parse_ai_history_since(since, time.Now())
+				utils.CheckForError(err)
+
+				sinceTime = t
+			}
+
+			app.Debug(fmt.Sprintf("Reading AI log from '%v' ...", logFile))
+			file, err := os.Open(logFile)
+			utils.CheckForError(err)
+			defer file.Close()
+
+			entries, err := types.LoadAILogEntries(file)
+			utils.CheckForError(err)
+
+			items := summarize_ai_log_entries(app, entries, sinceTime)
+
+			if outputJson {
+				encoder := json.NewEncoder(app.Out)
+				encoder.SetIndent("", "  ")
+
+				err := encoder.Encode(items)
+				utils.CheckForError(err)
+
+				return
+			}
+
+			formatCost := func(cost *float64) string {
+				if cost == nil {
+					return "-"
+				}
+
+				return fmt.Sprintf("$%.4f", *cost)
+			}
+
+			tHeadColor := color.New(color.FgWhite, color.Bold).SprintFunc()
+
+			t := table.NewWriter()
+			t.SetOutputMirror(app.Out)
+
+			t.AppendHeader(table.Row{tHeadColor("Command"), tHeadColor("Requests"), tHeadColor("Input"), tHeadColor("Output"), tHeadColor("Models"), tHeadColor("First"), tHeadColor("Last"), tHeadColor("Cost")})
+
+			total := aiHistoryItem{}
+			for _, item := range items {
+				t.AppendRow(table.Row{
+					item.Command,
+					item.Requests,
+					item.InputTokens,
+					item.OutputTokens,
+					strings.Join(item.Models, ", "),
+					item.First,
+					item.Last,
+					formatCost(item.Cost),
+				})
+
+				total.Requests += item.Requests
+				total.InputTokens += item.InputTokens
+				total.OutputTokens += item.OutputTokens
+				if item.Cost != nil {
+					if total.Cost == nil {
+						total.Cost = new(float64)
+					}
+					*total.Cost += *item.Cost
+				}
+			}
+
+			t.AppendFooter(table.Row{"Total", total.Requests, total.InputTokens, total.OutputTokens, "", "", "", formatCost(total.Cost)})
+
+			t.Render()
+		},
+	}
+
+	showHistoryCmd.Flags().BoolVarP(&outputJson, "json", "", false, "output as JSON")
+	showHistoryCmd.Flags().StringVarP(&since, "since", "", "", "only show interactions since a duration like '24h' or a date like '2006-01-02'")
+
+	parentCmd.AddCommand(
+		showHistoryCmd,
+	)
+}
+
 func Init_Show_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	var showCmd = &cobra.Command{
 		Use:     "show [resource]",
@@ -267,6 +465,7 @@ func Init_Show_Command(parentCmd *cobra.Command, app *types.AppContext) {
 	}
 
 	init_show_dependencies_command(showCmd, app)
+	init_show_history_command(showCmd, app)
 
 	parentCmd.AddCommand(
 		showCmd,
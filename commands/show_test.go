@@ -0,0 +1,160 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+// OpenAI, streamed OpenAI, Ollama and a request without usage
+const testAILog = `{"command":"gpm prompt","duration":100,"method":"POST","request":{"model":"gpt-4o-mini"},"response":{"model":"gpt-4o-mini-2024-07-18","usage":{"prompt_tokens":1000,"completion_tokens":500}},"status":200,"time":"2024-07-10T10:00:00Z","url":"https://api.openai.com/v1/chat/completions"}
+{"command":"gpm prompt","duration":100,"method":"POST","request":{"model":"gpt-4o-mini","stream":true},"response":"data: {\"model\":\"gpt-4o-mini-2024-07-18\",\"usage\":null}\n\ndata: {\"model\":\"gpt-4o-mini-2024-07-18\",\"usage\":{\"prompt_tokens\":2000,\"completion_tokens\":1000}}\n\ndata: [DONE]\n\n","status":200,"time":"2024-07-15T10:00:00Z","url":"https://api.openai.com/v1/chat/completions"}
+
+{"command":"gpm chat","duration":100,"method":"POST","request":{"model":"llama3"},"response":"{\"model\":\"llama3\",\"done\":false}\n{\"model\":\"llama3\",\"done\":true,\"prompt_eval_count\":30,\"eval_count\":20}\n","status":200,"time":"2024-07-14T10:00:00Z","url":"http://localhost:11434/api/chat"}
+{"duration":100,"error":"connection refused","method":"POST","request":{"model":"llama3"},"status":0,"time":"2024-07-16T10:00:00Z","url":"http://localhost:11434/api/chat"}
+`
+
+func TestShowHistoryCommand(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "ai.jsonl")
+	err := os.WriteFile(logFile, []byte(testAILog), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) string {
+		var out bytes.Buffer
+
+		app := &types.AppContext{
+			AILogFile: logFile,
+			Cwd:       filepath.Dir(logFile),
+			Out:       &out,
+		}
+		app.SettingsFile.AI.Prices = map[string]types.SettingsFileAIPrice{
+			"gpt-4o":      {Input: 5, Output: 15},
+			"gpt-4o-mini": {Input: 0.2, Output: 0.8},
+		}
+
+		rootCmd := &cobra.Command{Use: "gpm"}
+		Init_Show_Command(rootCmd, app)
+
+		rootCmd.SetArgs(append([]string{"show", "history"}, args...))
+		err := rootCmd.Execute()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return out.String()
+	}
+
+	getItems := func(args ...string) map[string]aiHistoryItem {
+		var items []aiHistoryItem
+		err := json.Unmarshal([]byte(run(append(args, "--json")...)), &items)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		itemsByCommand := map[string]aiHistoryItem{}
+		for _, item := range items {
+			itemsByCommand[item.Command] = item
+		}
+		return itemsByCommand
+	}
+
+	items := getItems()
+	if len(items) != 3 {
+		t.Fatalf("expected 3 commands, got %v", len(items))
+	}
+
+	prompt := items["gpm prompt"]
+	if prompt.Requests != 2 || prompt.InputTokens != 3000 || prompt.OutputTokens != 1500 {
+		t.Errorf("unexpected 'gpm prompt' summary: %+v", prompt)
+	}
+	if len(prompt.Models) != 1 || prompt.Models[0] != "gpt-4o-mini-2024-07-18" {
+		t.Errorf("unexpected 'gpm prompt' models: %v", prompt.Models)
+	}
+	if prompt.First != "2024-07-10T10:00:00Z" || prompt.Last != "2024-07-15T10:00:00Z" {
+		t.Errorf("unexpected 'gpm prompt' times: %v - %v", prompt.First, prompt.Last)
+	}
+	// 3000 * 0.2 / 1M + 1500 * 0.8 / 1M
+	if prompt.Cost == nil || *prompt.Cost < 0.00179 || *prompt.Cost > 0.00181 {
+		t.Errorf("unexpected 'gpm prompt' cost: %v", prompt.Cost)
+	}
+
+	chat := items["gpm chat"]
+	if chat.Requests != 1 || chat.InputTokens != 30 || chat.OutputTokens != 20 || chat.Cost != nil {
+		t.Errorf("unexpected 'gpm chat' summary: %+v", chat)
+	}
+
+	unknown := items["(unknown)"]
+	if unknown.Requests != 1 || unknown.Errors != 1 || unknown.InputTokens != 0 {
+		t.Errorf("unexpected '(unknown)' summary: %+v", unknown)
+	}
+
+	items = getItems("--since=2024-07-14T12:00:00Z")
+	if len(items) != 2 || items["gpm prompt"].Requests != 1 || items["gpm prompt"].InputTokens != 2000 {
+		t.Errorf("unexpected summary with --since: %+v", items)
+	}
+
+	text := run()
+	for _, expected := range []string{"gpm prompt", "gpm chat", "3030", "$0.0018"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("expected '%v' in output:\n%v", expected, text)
+		}
+	}
+}
+
+func TestParseAIHistorySince(t *testing.T) {
+	now := time.Date(2024, time.July, 15, 20, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"24h", time.Date(2024, time.July, 14, 20, 30, 0, 0, time.UTC)},
+		{"90", time.Date(2024, time.July, 15, 20, 28, 30, 0, time.UTC)},
+		{"2024-07-01T08:00:00Z", time.Date(2024, time.July, 1, 8, 0, 0, 0, time.UTC)},
+		{"2024-07-01", time.Date(2024, time.July, 1, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, test := range tests {
+		actual, err := parse_ai_history_since(test.input, now)
+		if err != nil {
+			t.Errorf("'%v': %v", test.input, err)
+		} else if !actual.Equal(test.expected) {
+			t.Errorf("'%v': expected '%v', got '%v'", test.input, test.expected, actual)
+		}
+	}
+
+	if _, err := parse_ai_history_since("yesterday", now); err == nil {
+		t.Error("expected error for 'yesterday'")
+	}
+}
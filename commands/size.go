@@ -0,0 +1,252 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/mkloubert/go-package-manager/constants"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+type sizeItem struct {
+	Package string  `json:"package"`
+	Percent float64 `json:"percent"`
+	Size    int64   `json:"size"`
+	Symbols int     `json:"symbols"`
+}
+
+// get_binary_size_report() - builds the current project to `binaryPath`, if `build`
+// is `true`, and returns the file information and sizes by package of the executable
+func get_binary_size_report(app *types.AppContext, binaryPath string, build bool) (os.FileInfo, []sizeItem, error) {
+	if build {
+		app.Debug(fmt.Sprintf("Running 'go build -o %v .' ...", binaryPath))
+		p := utils.CreateShellCommandByArgs("go", "build", "-o", binaryPath, ".")
+		p.Dir = app.Cwd
+
+		err := p.Run()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	binaryInfo, err := os.Stat(binaryPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, err := get_binary_sizes_by_package(app, binaryPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return binaryInfo, items, nil
+}
+
+// get_package_of_symbol() - extracts the package name of a symbol
+// from the output of `go tool nm`
+func get_package_of_symbol(symbol string) string {
+	// prefixes like `type:` or `go:`
+	colonIndex := strings.Index(symbol, ":")
+	if colonIndex > 0 && !strings.Contains(symbol[:colonIndex], "/") && !strings.Contains(symbol[:colonIndex], ".") {
+		return "<" + symbol[:colonIndex] + ">"
+	}
+
+	// ignore type parameters like in `sync/atomic.(*Pointer[net/http.T]).Load`
+	if bracketIndex := strings.Index(symbol, "["); bracketIndex > -1 {
+		symbol = symbol[:bracketIndex]
+	}
+
+	pkgStart := strings.LastIndex(symbol, "/") + 1
+	dotIndex := strings.Index(symbol[pkgStart:], ".")
+	if dotIndex < 0 {
+		return "<other>"
+	}
+
+	// dots in last path element are escaped, like `gopkg.in/yaml%2ev3`
+	return strings.ReplaceAll(symbol[:pkgStart+dotIndex], "%2e", ".")
+}
+
+// get_binary_sizes_by_package() - returns the sizes of symbols of an executable
+// grouped by packages, sorted by size descending
+func get_binary_sizes_by_package(app *types.AppContext, binaryPath string) ([]sizeItem, error) {
+	app.Debug(fmt.Sprintf("Running 'go tool nm -size %v' ...", binaryPath))
+
+	p := exec.Command("go", "tool", "nm", "-size", binaryPath)
+	p.Dir = app.Cwd
+	p.Stderr = app.ErrorOut
+
+	output, err := p.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := map[string]*sizeItem{}
+	var totalSize int64 = 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		// <address> <size> <type> <symbol>
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || size <= 0 {
+			continue
+		}
+
+		pkg := get_package_of_symbol(strings.Join(fields[3:], " "))
+
+		item, ok := sizes[pkg]
+		if !ok {
+			item = &sizeItem{
+				Package: pkg,
+			}
+			sizes[pkg] = item
+		}
+
+		item.Size += size
+		item.Symbols++
+
+		totalSize += size
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	items := []sizeItem{}
+	for _, item := range sizes {
+		if totalSize > 0 {
+			item.Percent = float64(item.Size) * 100.0 / float64(totalSize)
+		}
+
+		items = append(items, *item)
+	}
+
+	sort.Slice(items, func(x int, y int) bool {
+		if items[x].Size != items[y].Size {
+			return items[x].Size > items[y].Size
+		}
+
+		return items[x].Package < items[y].Package
+	})
+
+	return items, nil
+}
+
+func Init_Size_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var outputJson bool
+	var top int
+
+	var sizeCmd = &cobra.Command{
+		Use:   "size [executable]",
+		Short: "Analyze binary size",
+		Long:  `Builds the current project, or takes an existing executable, and shows the sizes grouped by packages.`,
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var binaryPath string
+			var tempDir string
+			if len(args) > 0 {
+				binaryPath = app.GetFullPathOrDefault(args[0], "")
+			} else {
+				dir, err := os.MkdirTemp("", "gpm-size-")
+				utils.CheckForError(err)
+
+				tempDir = dir
+
+				binaryPath = path.Join(tempDir, path.Base(app.Cwd))
+				if utils.IsWindows() {
+					binaryPath += constants.WindowsExecutableExt
+				}
+			}
+
+			binaryInfo, items, err := get_binary_size_report(app, binaryPath, tempDir != "")
+			if tempDir != "" {
+				// before exiting on errors
+				app.Debug(fmt.Sprintf("Removing folder '%v' ...", tempDir))
+				os.RemoveAll(tempDir)
+			}
+			utils.CheckForError(err)
+
+			if top > 0 {
+				items = utils.EnsureMaxSliceLength(items, top)
+			}
+
+			if outputJson {
+				encoder := json.NewEncoder(app.Out)
+				encoder.SetIndent("", "  ")
+
+				err := encoder.Encode(map[string]interface{}{
+					"file":     binaryPath,
+					"fileSize": binaryInfo.Size(),
+					"packages": items,
+				})
+				utils.CheckForError(err)
+
+				return
+			}
+
+			tHeadColor := color.New(color.FgWhite, color.Bold).SprintFunc()
+
+			fmt.Fprintf(app.Out, "%v (%v)%v", binaryPath, utils.FormatByteSize(binaryInfo.Size()), fmt.Sprintln())
+
+			t := table.NewWriter()
+			t.SetOutputMirror(app.Out)
+
+			t.AppendHeader(table.Row{tHeadColor("#"), tHeadColor("Package"), tHeadColor("Size"), tHeadColor("%"), tHeadColor("Symbols")})
+			for i, item := range items {
+				t.AppendRow(table.Row{
+					i + 1,
+					item.Package,
+					utils.FormatByteSize(item.Size),
+					fmt.Sprintf("%.2f", item.Percent),
+					item.Symbols,
+				})
+			}
+
+			t.Render()
+		},
+	}
+
+	sizeCmd.Flags().BoolVarP(&outputJson, "json", "", false, "output as JSON")
+	sizeCmd.Flags().IntVarP(&top, "top", "", 20, "maximum number of packages to show, 0 for all")
+
+	parentCmd.AddCommand(
+		sizeCmd,
+	)
+}
@@ -0,0 +1,82 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+func Init_Sleep_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var jitter string
+
+	var sleepCmd = &cobra.Command{
+		Use:     "sleep [duration]",
+		Aliases: []string{"wait"},
+		Args:    cobra.ExactArgs(1),
+		Short:   "Wait",
+		Long:    `Waits for a duration like 500ms, 2m or 1h30m. Plain numbers are handled as seconds.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			duration, err := utils.ParseDuration(args[0])
+			utils.CheckForError(err)
+
+			jitter = strings.TrimSpace(jitter)
+			if jitter != "" {
+				maxJitter, err := utils.ParseDuration(jitter)
+				utils.CheckForError(err)
+
+				duration = utils.AddJitter(duration, maxJitter)
+			}
+
+			app.Debug(fmt.Sprintf("Sleeping for %v ...", duration))
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			timer := time.NewTimer(duration)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				app.Debug("Interrupted")
+				utils.Exit(130)
+			}
+		},
+	}
+
+	sleepCmd.Flags().StringVarP(&jitter, "jitter", "", "", "add a random duration between 0 and this value")
+
+	parentCmd.AddCommand(
+		sleepCmd,
+	)
+}
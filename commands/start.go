@@ -23,13 +23,21 @@
 package commands
 
 import (
+	"fmt"
+	"os/exec"
+
 	"github.com/mkloubert/go-package-manager/constants"
 	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
 func Init_Start_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var clearConsole bool
 	var noScript bool
+	var watch bool
+	var watchDir string
+	var watchPatterns []string
 
 	var startCmd = &cobra.Command{
 		Use:     "start",
@@ -37,8 +45,33 @@ func Init_Start_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		Short:   "Runs current project",
 		Long:    `Runs the current project or 'start' script, if defined.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			_, ok := app.GpmFile.Scripts[constants.StartScriptName]
-			if !noScript && ok {
+			ok := app.HasScript(constants.StartScriptName)
+
+			if watch {
+				patterns := watchPatterns
+				if len(patterns) == 0 {
+					patterns = []string{`\.go$`}
+				}
+
+				isFirstStart := true
+				run_with_watch(app, func() *exec.Cmd {
+					if clearConsole && !isFirstStart {
+						err := utils.ClearConsole()
+						if err != nil {
+							app.Debug(fmt.Sprintf("Could not clear console: %v", err))
+						}
+					}
+					isFirstStart = false
+
+					if !noScript && ok {
+						// run script without --watch in a child process
+						childArgs := append([]string{"run", constants.StartScriptName}, args...)
+
+						return create_self_command(app, cmd, childArgs...)
+					}
+					return app.CreateCurrentProjectCommand(args...)
+				}, app.GetFullPathOrDefault(watchDir, app.Cwd), patterns)
+			} else if !noScript && ok {
 				app.RunScript(constants.StartScriptName, args...)
 			} else {
 				app.RunCurrentProject(args...)
@@ -46,7 +79,11 @@ func Init_Start_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		},
 	}
 
+	startCmd.Flags().BoolVarP(&clearConsole, "clear", "", false, "clear console before each restart in watch mode")
 	startCmd.Flags().BoolVarP(&noScript, "no-script", "n", false, "do not handle '"+constants.StartScriptName+"' script")
+	startCmd.Flags().BoolVarP(&watch, "watch", "w", false, "restart on changes of .go files")
+	startCmd.Flags().StringVarP(&watchDir, "watch-dir", "", "", "custom directory to watch")
+	startCmd.Flags().StringArrayVarP(&watchPatterns, "watch-pattern", "", []string{}, "custom regular expressions of files to watch")
 
 	parentCmd.AddCommand(
 		startCmd,
@@ -0,0 +1,169 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/spf13/cobra"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+// sort_git_tags() - sorts tags by semantic version, where tags, which are
+// no valid version, are sorted lexically and placed after them
+func sort_git_tags(tags []string) []string {
+	type versionTag struct {
+		name    string
+		version *version.Version
+	}
+
+	versionTags := []versionTag{}
+	otherTags := []string{}
+	for _, t := range tags {
+		name := strings.TrimSpace(t)
+		if name == "" {
+			continue
+		}
+
+		v, err := version.NewVersion(name)
+		if err == nil {
+			versionTags = append(versionTags, versionTag{name: name, version: v})
+		} else {
+			otherTags = append(otherTags, name)
+		}
+	}
+
+	sort.SliceStable(versionTags, func(x, y int) bool {
+		vX := versionTags[x].version
+		vY := versionTags[y].version
+
+		if vX.Equal(vY) {
+			return versionTags[x].name < versionTags[y].name
+		}
+		return vX.LessThan(vY)
+	})
+	sort.Strings(otherTags)
+
+	sortedTags := make([]string, 0, len(versionTags)+len(otherTags))
+	for _, vt := range versionTags {
+		sortedTags = append(sortedTags, vt.name)
+	}
+	sortedTags = append(sortedTags, otherTags...)
+
+	return sortedTags
+}
+
+func init_tag_create_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var message string
+
+	var createCmd = &cobra.Command{
+		Use:     "create [name]",
+		Aliases: []string{"c", "new"},
+		Short:   "Create tag",
+		Long:    `Creates a new git tag for the current commit.`,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			tagName := strings.TrimSpace(args[0])
+			if tagName == "" {
+				utils.CloseWithError(fmt.Errorf("no tag name defined"))
+			}
+
+			cmdArgs := []string{"git", "tag"}
+
+			tagMessage := strings.TrimSpace(message)
+			if tagMessage != "" {
+				// annotated tag
+				cmdArgs = append(cmdArgs, "-a", tagName, "-m", tagMessage)
+			} else {
+				cmdArgs = append(cmdArgs, tagName)
+			}
+
+			app.RunShellCommandByArgs(cmdArgs[0], cmdArgs[1:]...)
+		},
+	}
+
+	createCmd.Flags().StringVarP(&message, "message", "m", "", "custom message, which creates an annotated tag")
+
+	parentCmd.AddCommand(
+		createCmd,
+	)
+}
+
+func init_tag_delete_command(parentCmd *cobra.Command, app *types.AppContext) {
+	var remote bool
+
+	var deleteCmd = &cobra.Command{
+		Use:     "delete [name]",
+		Aliases: []string{"d", "del", "rm"},
+		Short:   "Delete tag",
+		Long:    `Deletes a git tag locally and optionally on origin.`,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			tagName := strings.TrimSpace(args[0])
+			if tagName == "" {
+				utils.CloseWithError(fmt.Errorf("no tag name defined"))
+			}
+
+			app.RunShellCommandByArgs("git", "tag", "-d", tagName)
+
+			if remote {
+				app.RunShellCommandByArgs("git", "push", "origin", "--delete", "refs/tags/"+tagName)
+			}
+		},
+	}
+
+	deleteCmd.Flags().BoolVarP(&remote, "remote", "r", false, "also delete tag on origin")
+
+	parentCmd.AddCommand(
+		deleteCmd,
+	)
+}
+
+func Init_Tag_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var tagCmd = &cobra.Command{
+		Use:     "tag",
+		Aliases: []string{"tags"},
+		Short:   "Handle git tags",
+		Long:    `Lists git tags sorted by version or handles them with sub commands.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			tags, err := app.GetGitTags()
+			utils.CheckForError(err)
+
+			for _, t := range sort_git_tags(tags) {
+				fmt.Fprintln(app.Out, t)
+			}
+		},
+	}
+
+	init_tag_create_command(tagCmd, app)
+	init_tag_delete_command(tagCmd, app)
+
+	parentCmd.AddCommand(
+		tagCmd,
+	)
+}
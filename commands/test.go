@@ -23,7 +23,21 @@
 package commands
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -31,29 +45,481 @@ const postTestScriptName = "posttest"
 const preTestScriptName = "pretest"
 const testScriptName = "test"
 
+// maximum number of slowest tests in a summary
+const maxSlowestTests = 5
+
+// goTestEvent is an event of `go test -json`
+type goTestEvent struct {
+	Action  string  `json:"Action"`
+	Elapsed float64 `json:"Elapsed,omitempty"`
+	Output  string  `json:"Output,omitempty"`
+	Package string  `json:"Package,omitempty"`
+	Test    string  `json:"Test,omitempty"`
+}
+
+// goTestResult stores the result of a single test
+type goTestResult struct {
+	Elapsed float64 `json:"elapsed"`
+	Name    string  `json:"name,omitempty"`
+	Output  string  `json:"output,omitempty"`
+	Package string  `json:"package"`
+	Status  string  `json:"status"`
+}
+
+// goTestSummary stores the aggregated result of a `go test -json` run
+type goTestSummary struct {
+	ExitCode int            `json:"exitCode"`
+	Failed   int            `json:"failed"`
+	Failures []goTestResult `json:"failures"`
+	Flaky    []goTestResult `json:"flaky"`
+	Passed   int            `json:"passed"`
+	Skipped  int            `json:"skipped"`
+	Slowest  []goTestResult `json:"slowest"`
+	Success  bool           `json:"success"`
+}
+
+// get_changed_flags() - returns all flags of `names`, which
+// have been set in the command line, like `--json`
+func get_changed_flags(cmd *cobra.Command, names ...string) []string {
+	changedFlags := []string{}
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			changedFlags = append(changedFlags, "--"+name)
+		}
+	}
+
+	return changedFlags
+}
+
+// parse_go_test_events() - parses the event stream of `go test -json`
+// and aggregates it to a summary
+func parse_go_test_events(r io.Reader, errOut io.Writer) (goTestSummary, error) {
+	summary := goTestSummary{
+		Failures: []goTestResult{},
+		Flaky:    []goTestResult{},
+		Slowest:  []goTestResult{},
+	}
+
+	outputs := map[string]*strings.Builder{}
+	getOutput := func(key string) *strings.Builder {
+		sb, ok := outputs[key]
+		if !ok {
+			sb = &strings.Builder{}
+			outputs[key] = sb
+		}
+
+		return sb
+	}
+
+	packagesWithFailedTests := map[string]bool{}
+	results := []goTestResult{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		var event goTestEvent
+		err := json.Unmarshal([]byte(line), &event)
+		if err != nil || event.Action == "" {
+			// no event, like build errors
+			fmt.Fprintln(errOut, line)
+			continue
+		}
+
+		key := event.Package + "\x00" + event.Test
+
+		switch event.Action {
+		case "output":
+			getOutput(key).WriteString(event.Output)
+		case "pass", "fail", "skip":
+			if event.Test == "" {
+				if event.Action == "fail" && !packagesWithFailedTests[event.Package] {
+					// package itself failed, like by a panic in `TestMain()`
+					summary.Failures = append(summary.Failures, goTestResult{
+						Elapsed: event.Elapsed,
+						Output:  getOutput(key).String(),
+						Package: event.Package,
+						Status:  event.Action,
+					})
+				}
+
+				continue
+			}
+
+			result := goTestResult{
+				Elapsed: event.Elapsed,
+				Name:    event.Test,
+				Package: event.Package,
+				Status:  event.Action,
+			}
+
+			switch event.Action {
+			case "pass":
+				summary.Passed++
+			case "fail":
+				summary.Failed++
+				packagesWithFailedTests[event.Package] = true
+
+				result.Output = getOutput(key).String()
+				summary.Failures = append(summary.Failures, result)
+			case "skip":
+				summary.Skipped++
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(x int, y int) bool {
+		return results[x].Elapsed > results[y].Elapsed
+	})
+	for _, r := range results {
+		if r.Status == "skip" {
+			continue
+		}
+
+		r.Output = ""
+		summary.Slowest = append(summary.Slowest, r)
+	}
+	summary.Slowest = utils.EnsureMaxSliceLength(summary.Slowest, maxSlowestTests)
+
+	return summary, scanner.Err()
+}
+
+// print_go_test_summary() - writes a summary of a `go test -json` run
+// in a human readable format
+func print_go_test_summary(app *types.AppContext, summary goTestSummary) {
+	green := color.New(color.FgGreen).SprintFunc()
+	red := color.New(color.FgRed).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+	tHeadColor := color.New(color.FgWhite, color.Bold).SprintFunc()
+
+	for _, f := range summary.Failures {
+		name := f.Package
+		if f.Name != "" {
+			name = fmt.Sprintf("%v %v", f.Package, f.Name)
+		}
+
+		fmt.Fprintf(app.Out, "%v %v (%.2fs)%v", red("FAIL"), name, f.Elapsed, fmt.Sprintln())
+		if f.Output != "" {
+			fmt.Fprint(app.Out, f.Output)
+		}
+		fmt.Fprintln(app.Out)
+	}
+
+	if len(summary.Slowest) > 0 {
+		fmt.Fprintln(app.Out, "Slowest tests:")
+
+		t := table.NewWriter()
+		t.SetOutputMirror(app.Out)
+
+		t.AppendHeader(table.Row{tHeadColor("#"), tHeadColor("Package"), tHeadColor("Test"), tHeadColor("Duration")})
+		for i, r := range summary.Slowest {
+			t.AppendRow(table.Row{
+				i + 1,
+				r.Package,
+				r.Name,
+				fmt.Sprintf("%.2fs", r.Elapsed),
+			})
+		}
+
+		t.Render()
+		fmt.Fprintln(app.Out)
+	}
+
+	if len(summary.Flaky) > 0 {
+		fmt.Fprintln(app.Out, "Flaky tests:")
+		for _, f := range summary.Flaky {
+			fmt.Fprintf(app.Out, "  %v %v %v%v", yellow("FLAKY"), f.Package, f.Name, fmt.Sprintln())
+		}
+		fmt.Fprintln(app.Out)
+	}
+
+	fmt.Fprintf(
+		app.Out,
+		"%v passed, %v failed, %v skipped, %v flaky%v",
+		green(summary.Passed), red(summary.Failed), yellow(summary.Skipped), yellow(len(summary.Flaky)),
+		fmt.Sprintln(),
+	)
+}
+
+// retry_failed_go_tests() - re-runs the failed tests of a summary with
+// a precise `-run` filter per package and returns the updated summary,
+// which contains top level tests, that failed before and passed now, as flaky
+func retry_failed_go_tests(app *types.AppContext, summary goTestSummary, additionalArgs ...string) (goTestSummary, error) {
+	failures := []goTestResult{}
+
+	getTopLevelName := func(name string) string {
+		return strings.SplitN(name, "/", 2)[0]
+	}
+
+	// top level test names by package
+	testsToRetry := map[string][]string{}
+	for _, f := range summary.Failures {
+		if f.Name == "" {
+			// package failed without failing test,
+			// which cannot be filtered
+			failures = append(failures, f)
+			continue
+		}
+
+		topLevelName := getTopLevelName(f.Name)
+		if !slices.Contains(testsToRetry[f.Package], topLevelName) {
+			testsToRetry[f.Package] = append(testsToRetry[f.Package], topLevelName)
+		}
+	}
+
+	packages := make([]string, 0, len(testsToRetry))
+	for pkg := range testsToRetry {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	exitCode := 0
+	if len(failures) > 0 {
+		exitCode = summary.ExitCode
+	}
+
+	for _, pkg := range packages {
+		names := testsToRetry[pkg]
+
+		quotedNames := make([]string, 0, len(names))
+		for _, n := range names {
+			quotedNames = append(quotedNames, regexp.QuoteMeta(n))
+		}
+
+		args := append([]string{}, additionalArgs...)
+		args = append(args, "-run", fmt.Sprintf("^(%v)$", strings.Join(quotedNames, "|")))
+
+		retrySummary, err := run_go_test_json(app, []string{pkg}, args...)
+		if err != nil {
+			return summary, err
+		}
+
+		failures = append(failures, retrySummary.Failures...)
+		if retrySummary.ExitCode != 0 {
+			exitCode = retrySummary.ExitCode
+		}
+	}
+
+	isStillFailing := func(f goTestResult) bool {
+		return slices.ContainsFunc(failures, func(r goTestResult) bool {
+			return r.Package == f.Package && r.Name == f.Name
+		})
+	}
+	isTopLevelStillFailing := func(f goTestResult) bool {
+		return slices.ContainsFunc(failures, func(r goTestResult) bool {
+			return r.Package == f.Package && r.Name != "" && getTopLevelName(r.Name) == getTopLevelName(f.Name)
+		})
+	}
+
+	// flaky top level tests, because a failed sub test
+	// like `TestX/sub` also lets `TestX` fail
+	flakyTests := map[string]*goTestResult{}
+	flakyKeys := []string{}
+
+	failed := 0
+	for _, f := range summary.Failures {
+		if f.Name == "" {
+			continue
+		}
+
+		if isStillFailing(f) {
+			failed++
+			continue
+		}
+		if isTopLevelStillFailing(f) {
+			continue
+		}
+
+		topLevelName := getTopLevelName(f.Name)
+		key := f.Package + "\x00" + topLevelName
+
+		flaky, ok := flakyTests[key]
+		if !ok {
+			flaky = &goTestResult{
+				Elapsed: f.Elapsed,
+				Name:    topLevelName,
+				Package: f.Package,
+				Status:  "flaky",
+			}
+
+			flakyTests[key] = flaky
+			flakyKeys = append(flakyKeys, key)
+		}
+		if f.Name == topLevelName {
+			flaky.Elapsed = f.Elapsed
+		}
+	}
+
+	for _, key := range flakyKeys {
+		summary.Flaky = append(summary.Flaky, *flakyTests[key])
+		summary.Passed++
+	}
+
+	summary.ExitCode = exitCode
+	summary.Failed = failed
+	summary.Failures = failures
+	summary.Success = summary.ExitCode == 0 && len(summary.Failures) == 0
+
+	return summary, nil
+}
+
+// run_go_test_json() - runs `go test -json` and returns the aggregated summary
+func run_go_test_json(app *types.AppContext, packages []string, additionalArgs ...string) (goTestSummary, error) {
+	args := []string{"test", "-json"}
+	args = append(args, additionalArgs...)
+	args = append(args, packages...)
+
+	if app.DryRun {
+		app.RunShellCommandByArgs("go", args...)
+
+		return goTestSummary{
+			Failures: []goTestResult{},
+			Flaky:    []goTestResult{},
+			Slowest:  []goTestResult{},
+			Success:  true,
+		}, nil
+	}
+
+	p := utils.CreateShellCommandByArgs("go", args...)
+	p.Dir = app.Cwd
+	p.Stderr = app.ErrorOut
+	p.Stdout = nil
+
+	stdout, err := p.StdoutPipe()
+	if err != nil {
+		return goTestSummary{}, err
+	}
+
+	app.Debug(fmt.Sprintf("Running 'go %v' ...", strings.Join(args, " ")))
+	err = p.Start()
+	if err != nil {
+		return goTestSummary{}, err
+	}
+
+	summary, err := parse_go_test_events(stdout, app.ErrorOut)
+	if err != nil {
+		p.Process.Kill()
+		p.Wait()
+
+		return summary, err
+	}
+
+	err = p.Wait()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return summary, err
+		}
+
+		summary.ExitCode = exitErr.ExitCode()
+	}
+
+	summary.Success = summary.ExitCode == 0 && len(summary.Failures) == 0
+
+	return summary, nil
+}
+
 func Init_Test_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var failFast bool
 	var noPostScript bool
 	var noPreScript bool
 	var noScript bool
+	var output string
+	var outputJson bool
+	var retry int
+	var run string
 
 	var testCmd = &cobra.Command{
-		Use:     "test",
+		Use:     "test [packages]",
 		Aliases: []string{"t", "tst"},
 		Short:   "Runs tests",
 		Long:    `Runs tests or 'test' script, if defined.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			_, ok := app.GpmFile.Scripts[testScriptName]
+			ok := app.HasScript(testScriptName)
 			if !noScript && ok {
+				changedFlags := get_changed_flags(cmd, "fail-fast", "json", "output", "retry", "run")
+				if len(changedFlags) > 0 {
+					utils.CloseWithError(fmt.Errorf("%v cannot be used with '%v' script, use --no-script to run 'go test' instead", strings.Join(changedFlags, ", "), testScriptName))
+				}
+
 				app.RunScript(testScriptName, args...)
+				return
+			}
+
+			retryArgs := []string{}
+			if failFast {
+				retryArgs = append(retryArgs, "-failfast")
+			}
+
+			goTestArgs := append([]string{}, retryArgs...)
+			if run != "" {
+				goTestArgs = append(goTestArgs, "-run", run)
+			}
+
+			output = strings.TrimSpace(strings.ToLower(output))
+			if output != "" && output != "text" && output != "json" {
+				utils.CloseWithError(fmt.Errorf("invalid value '%v' for output", output))
+			}
+
+			packages := args
+			if len(packages) == 0 {
+				packages = []string{"."}
+			}
+
+			if !outputJson && output == "" && retry < 1 {
+				// plain passthrough
+				goArgs := append([]string{"test"}, goTestArgs...)
+				goArgs = append(goArgs, packages...)
+
+				app.RunShellCommandByArgs("go", goArgs...)
+				return
+			}
+
+			summary, err := run_go_test_json(app, packages, goTestArgs...)
+			utils.CheckForError(err)
+
+			for i := 0; i < retry && !summary.Success; i++ {
+				if summary.Failed == 0 {
+					break // nothing to filter for
+				}
+
+				app.Warn(fmt.Sprintf("Retrying %v failed test(s) (%v/%v) ...", summary.Failed, i+1, retry))
+
+				summary, err = retry_failed_go_tests(app, summary, retryArgs...)
+				utils.CheckForError(err)
+			}
+
+			if output == "json" {
+				encoder := json.NewEncoder(app.Out)
+				encoder.SetIndent("", "  ")
+
+				err := encoder.Encode(&summary)
+				utils.CheckForError(err)
 			} else {
-				app.RunShellCommandByArgs("go", "test", ".")
+				print_go_test_summary(app, summary)
+			}
+
+			if !summary.Success {
+				exitCode := summary.ExitCode
+				if exitCode <= 0 {
+					exitCode = 1
+				}
+				utils.Exit(exitCode)
 			}
 		},
 	}
 
+	testCmd.Flags().BoolVarP(&failFast, "fail-fast", "", false, "do not start new tests after the first test failure")
+	testCmd.Flags().BoolVarP(&outputJson, "json", "", false, "run tests with '-json' and output a summary")
 	testCmd.Flags().BoolVarP(&noPostScript, "no-post-script", "", false, "do not handle '"+postTestScriptName+"' script")
 	testCmd.Flags().BoolVarP(&noPreScript, "no-pre-script", "", false, "do not handle '"+preTestScriptName+"' script")
 	testCmd.Flags().BoolVarP(&noScript, "no-script", "n", false, "do not handle '"+testScriptName+"' script")
+	testCmd.Flags().StringVarP(&output, "output", "", "", "output format of summary: text or json")
+	testCmd.Flags().IntVarP(&retry, "retry", "", 0, "maximum number of re-runs of failed tests before failing")
+	testCmd.Flags().StringVarP(&run, "run", "", "", "run only tests matching the regular expression")
 
 	parentCmd.AddCommand(
 		testCmd,
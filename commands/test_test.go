@@ -0,0 +1,303 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/spf13/cobra"
+)
+
+func TestTestCommandPassesPackages(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		// plain passthrough
+		{[]string{}, "[DRY-RUN] go test ."},
+		{[]string{"./utils/..."}, "[DRY-RUN] go test ./utils/..."},
+		{[]string{"--run=TestFoo", "--fail-fast", "./a", "./b"}, "[DRY-RUN] go test -failfast -run TestFoo ./a ./b"},
+		// with summary
+		{[]string{"--json"}, "[DRY-RUN] go test -json ."},
+		{[]string{"--json", "--run=TestFoo", "./a", "./b"}, "[DRY-RUN] go test -json -run TestFoo ./a ./b"},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+
+		app := &types.AppContext{
+			Cwd:    t.TempDir(),
+			DryRun: true,
+			Out:    &out,
+		}
+
+		rootCmd := &cobra.Command{Use: "gpm"}
+		Init_Test_Command(rootCmd, app)
+
+		rootCmd.SetArgs(append([]string{"test"}, test.args...))
+		err := rootCmd.Execute()
+		if err != nil {
+			t.Fatalf("%v: %v", test.args, err)
+		}
+
+		firstLine, _, _ := strings.Cut(out.String(), "\n")
+		expected := test.expected + " (in '" + app.Cwd + "')"
+		if firstLine != expected {
+			t.Errorf("%v: expected '%v', got '%v'", test.args, expected, firstLine)
+		}
+	}
+}
+
+func TestTestCommandWithScript(t *testing.T) {
+	var out bytes.Buffer
+
+	app := &types.AppContext{
+		Cwd:    t.TempDir(),
+		DryRun: true,
+		GpmFile: types.GpmFile{
+			Scripts: map[string]types.GpmFileScript{
+				"test": {Run: "make test"},
+			},
+		},
+		Out: &out,
+	}
+
+	rootCmd := &cobra.Command{Use: "gpm"}
+	Init_Test_Command(rootCmd, app)
+
+	rootCmd.SetArgs([]string{"test"})
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "[DRY-RUN] make test (in ") {
+		t.Errorf("expected script to run, got '%v'", out.String())
+	}
+
+	// flags, which are not supported by scripts
+	testCmd, _, err := rootCmd.Find([]string{"test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = testCmd.ParseFlags([]string{"--json", "--retry=2", "--run=TestFoo", "--no-script"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changedFlags := get_changed_flags(testCmd, "fail-fast", "json", "output", "retry", "run")
+	if strings.Join(changedFlags, ", ") != "--json, --retry, --run" {
+		t.Errorf("unexpected changed flags %v", changedFlags)
+	}
+}
+
+func TestParseGoTestEvents(t *testing.T) {
+	events := `{"Action":"run","Package":"example.com/a","Test":"TestX"}
+{"Action":"output","Package":"example.com/a","Test":"TestX/sub","Output":"    x_test.go:10: boom\n"}
+{"Action":"fail","Package":"example.com/a","Test":"TestX/sub","Elapsed":0.1}
+{"Action":"fail","Package":"example.com/a","Test":"TestX","Elapsed":0.2}
+{"Action":"pass","Package":"example.com/a","Test":"TestY","Elapsed":1.5}
+{"Action":"skip","Package":"example.com/a","Test":"TestZ","Elapsed":0}
+{"Action":"fail","Package":"example.com/a","Elapsed":2}
+# example.com/b
+{"Action":"output","Package":"example.com/b","Output":"panic: setup failed\n"}
+{"Action":"fail","Package":"example.com/b","Elapsed":0.3}
+`
+
+	var errOut bytes.Buffer
+	summary, err := parse_go_test_events(strings.NewReader(events), &errOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Passed != 1 || summary.Failed != 2 || summary.Skipped != 1 {
+		t.Errorf("unexpected counts: %v passed, %v failed, %v skipped", summary.Passed, summary.Failed, summary.Skipped)
+	}
+
+	if len(summary.Failures) != 3 {
+		t.Fatalf("expected 3 failures, got %v", summary.Failures)
+	}
+	if summary.Failures[0].Name != "TestX/sub" || !strings.Contains(summary.Failures[0].Output, "boom") {
+		t.Errorf("unexpected failure %v", summary.Failures[0])
+	}
+	if summary.Failures[2].Package != "example.com/b" || summary.Failures[2].Name != "" || !strings.Contains(summary.Failures[2].Output, "setup failed") {
+		t.Errorf("unexpected package failure %v", summary.Failures[2])
+	}
+
+	if len(summary.Slowest) != 3 || summary.Slowest[0].Name != "TestY" {
+		t.Errorf("unexpected slowest tests %v", summary.Slowest)
+	}
+
+	if errOut.String() != "# example.com/b\n" {
+		t.Errorf("unexpected error output %q", errOut.String())
+	}
+}
+
+func TestRetryFailedGoTestsCountsTopLevelTests(t *testing.T) {
+	var out bytes.Buffer
+
+	// in dry-run mode all retried tests pass
+	app := &types.AppContext{
+		Cwd:    t.TempDir(),
+		DryRun: true,
+		Out:    &out,
+	}
+
+	summary := goTestSummary{
+		ExitCode: 1,
+		Failed:   4,
+		Failures: []goTestResult{
+			{Package: "example.com/a", Name: "TestX/sub", Elapsed: 0.1, Status: "fail"},
+			{Package: "example.com/a", Name: "TestX/other", Elapsed: 0.1, Status: "fail"},
+			{Package: "example.com/a", Name: "TestX", Elapsed: 0.3, Status: "fail"},
+			{Package: "example.com/b", Name: "TestY", Elapsed: 0.2, Status: "fail"},
+			{Package: "example.com/c", Status: "fail"},
+		},
+		Flaky:  []goTestResult{},
+		Passed: 10,
+	}
+
+	summary, err := retry_failed_go_tests(app, summary, "-failfast")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Passed != 12 {
+		t.Errorf("expected 12 passed tests, got %v", summary.Passed)
+	}
+	if summary.Failed != 0 {
+		t.Errorf("expected 0 failed tests, got %v", summary.Failed)
+	}
+
+	if len(summary.Flaky) != 2 {
+		t.Fatalf("expected 2 flaky tests, got %v", summary.Flaky)
+	}
+	if summary.Flaky[0].Package != "example.com/a" || summary.Flaky[0].Name != "TestX" || summary.Flaky[0].Elapsed != 0.3 {
+		t.Errorf("unexpected flaky test %v", summary.Flaky[0])
+	}
+	if summary.Flaky[1].Package != "example.com/b" || summary.Flaky[1].Name != "TestY" {
+		t.Errorf("unexpected flaky test %v", summary.Flaky[1])
+	}
+
+	// package failure cannot be retried
+	if len(summary.Failures) != 1 || summary.Failures[0].Package != "example.com/c" {
+		t.Errorf("unexpected failures %v", summary.Failures)
+	}
+	if summary.Success || summary.ExitCode != 1 {
+		t.Errorf("expected failed summary with exit code 1, got %v", summary.ExitCode)
+	}
+
+	// one run per package with top level names
+	dryRunOutput := out.String()
+	for _, expected := range []string{
+		"go test -json -failfast -run ^(TestX)$ example.com/a ",
+		"go test -json -failfast -run ^(TestY)$ example.com/b ",
+	} {
+		if !strings.Contains(dryRunOutput, expected) {
+			t.Errorf("expected '%v' in %q", expected, dryRunOutput)
+		}
+	}
+}
+
+func TestRetryFailedGoTestsWithFlakyTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go toolchain")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go is not installed")
+	}
+
+	dir := t.TempDir()
+
+	// `TestFlaky` fails on the first run only and
+	// `TestBroken` always fails
+	files := map[string]string{
+		"go.mod": "module example.com/flaky\n\ngo 1.21\n",
+		"flaky_test.go": `package flaky
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFlaky(t *testing.T) {
+	t.Run("sub", func(t *testing.T) {
+		if _, err := os.Stat("marker"); err != nil {
+			os.WriteFile("marker", []byte("1"), 0644)
+			t.Fatal("first run")
+		}
+	})
+}
+
+func TestBroken(t *testing.T) {
+	t.Fatal("always")
+}
+
+func TestOk(t *testing.T) {
+}
+`,
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var errOut bytes.Buffer
+	app := &types.AppContext{
+		Cwd:      dir,
+		ErrorOut: &errOut,
+	}
+
+	summary, err := run_go_test_json(app, []string{"."}, "-count=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Passed != 1 || summary.Failed != 3 {
+		t.Fatalf("unexpected first run: %v passed, %v failed: %v", summary.Passed, summary.Failed, errOut.String())
+	}
+
+	summary, err = retry_failed_go_tests(app, summary, "-count=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary.Passed != 2 || summary.Failed != 1 {
+		t.Errorf("unexpected retry: %v passed, %v failed", summary.Passed, summary.Failed)
+	}
+	if len(summary.Flaky) != 1 || summary.Flaky[0].Name != "TestFlaky" {
+		t.Errorf("unexpected flaky tests %v", summary.Flaky)
+	}
+	if len(summary.Failures) != 1 || summary.Failures[0].Name != "TestBroken" {
+		t.Errorf("unexpected failures %v", summary.Failures)
+	}
+	if summary.Success {
+		t.Error("summary should not be successful")
+	}
+}
@@ -0,0 +1,104 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/spf13/cobra"
+)
+
+func Init_Uncompress_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var algorithm string
+	var output string
+
+	var uncompressCmd = &cobra.Command{
+		Use:     "uncompress [files]",
+		Aliases: []string{"uncmp"},
+		Short:   "Uncompress data",
+		Long:    `Uncompresses data from STDIN and/or files to STDOUT or extracts archives.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			targetDir := app.GetFullPathOrDefault(output, app.Cwd)
+
+			printExtractedFiles := func(extractedFiles []string) {
+				for _, f := range extractedFiles {
+					app.Debug(fmt.Sprintf("Extracted '%v'", f))
+				}
+				app.Debug(fmt.Sprintf("Files extracted: %v", len(extractedFiles)))
+			}
+
+			pipeReader, pipeWriter := io.Pipe()
+			go func() {
+				_, err := app.WriteAllInputsTo(pipeWriter, args...)
+				pipeWriter.CloseWithError(err)
+			}()
+
+			input := bufio.NewReader(pipeReader)
+
+			inputHeader, _ := input.Peek(4)
+			if utils.IsZipArchive(inputHeader) {
+				// zip archives need random access
+				zipData, err := io.ReadAll(input)
+				utils.CheckForError(err)
+
+				extractedFiles, err := utils.ExtractZip(bytes.NewReader(zipData), int64(len(zipData)), targetDir)
+				utils.CheckForError(err)
+
+				printExtractedFiles(extractedFiles)
+				return
+			}
+
+			decompressor, err := utils.CreateDecompressor(input, algorithm, args...)
+			utils.CheckForError(err)
+			defer decompressor.Close()
+
+			uncompressedInput := bufio.NewReader(decompressor)
+
+			uncompressedHeader, _ := uncompressedInput.Peek(262)
+			if utils.IsTarArchive(uncompressedHeader) {
+				extractedFiles, err := utils.ExtractTar(uncompressedInput, targetDir)
+				utils.CheckForError(err)
+
+				printExtractedFiles(extractedFiles)
+				return
+			}
+
+			written, err := io.Copy(app.Out, uncompressedInput)
+			utils.CheckForError(err)
+
+			app.Debug(fmt.Sprintf("Bytes uncompressed: %v", written))
+		},
+	}
+
+	uncompressCmd.Flags().StringVarP(&algorithm, "algo", "", "auto", "compression algorithm like gzip, xz, zlib or zstd, auto detects it by default")
+	uncompressCmd.Flags().StringVarP(&output, "output", "o", "", "target directory for extracted archives")
+
+	parentCmd.AddCommand(
+		uncompressCmd,
+	)
+}
@@ -23,12 +23,37 @@
 package commands
 
 import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
 )
 
+// get_go_modules_by_path() - returns the modules of the current
+// project, grouped by their paths
+func get_go_modules_by_path(app *types.AppContext) map[string]types.GoModule {
+	modules, err := app.GetGoModules()
+	utils.CheckForError(err)
+
+	modulesByPath := map[string]types.GoModule{}
+	for _, m := range modules {
+		if m.Path != nil && (m.Main == nil || !*m.Main) {
+			modulesByPath[*m.Path] = m
+		}
+	}
+
+	return modulesByPath
+}
+
 func Init_Uninstall_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var prune bool
+	var yes bool
+
 	var uninstallCmd = &cobra.Command{
 		Use:     "uninstall [module name or url]",
 		Aliases: []string{"u"},
@@ -36,16 +61,73 @@ func Init_Uninstall_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		Long:    `Uninstalls one or more modules by a short name or a valid URL to a git repository.`,
 		Args:    cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			urls := []string{}
 			for _, moduleName := range args {
-				urls := app.GetModuleUrls(moduleName)
+				urls = append(urls, app.GetModuleUrls(moduleName)...)
+			}
+
+			if prune && !yes {
+				fmt.Printf("Remove %v and tidy up the project (y/N)? ", strings.Join(urls, ", "))
+
+				reader := bufio.NewReader(app.In)
+				confirmation, _ := reader.ReadString('\n')
+
+				switch strings.TrimSpace(strings.ToLower(confirmation)) {
+				case "y", "yes":
+				default:
+					fmt.Println("Aborted")
+					return
+				}
+			}
+
+			var modulesBefore map[string]types.GoModule
+			if prune {
+				modulesBefore = get_go_modules_by_path(app)
+			}
+
+			for _, u := range urls {
+				app.RunShellCommandByArgs("go", "get", u+"@none")
+			}
+
+			if prune {
+				app.TidyUp()
 
-				for _, u := range urls {
-					app.RunShellCommandByArgs("go", "get", u+"@none")
+				modulesAfter := get_go_modules_by_path(app)
+
+				droppedModules := []string{}
+				for modulePath, m := range modulesBefore {
+					if _, ok := modulesAfter[modulePath]; ok {
+						continue
+					}
+
+					entry := modulePath
+					if m.Version != nil && *m.Version != "" {
+						entry += "@" + *m.Version
+					}
+					if m.Indirect != nil && *m.Indirect {
+						entry += " (indirect)"
+					}
+
+					droppedModules = append(droppedModules, entry)
+				}
+				sort.Strings(droppedModules)
+
+				if len(droppedModules) == 0 {
+					fmt.Fprintln(app.Out, "No modules have been dropped")
+				} else {
+					fmt.Fprintf(app.Out, "%v module(s) have been dropped:%v", len(droppedModules), fmt.Sprintln())
+					for _, m := range droppedModules {
+						fmt.Fprintf(app.Out, "\t- %v%v", m, fmt.Sprintln())
+					}
 				}
 			}
 		},
+		ValidArgsFunction: utils.CreateCompletionFunc(app.GetAliasNames),
 	}
 
+	uninstallCmd.Flags().BoolVarP(&prune, "prune", "", false, "tidy up project and report dropped modules")
+	uninstallCmd.Flags().BoolVarP(&yes, "yes", "y", false, "do not ask for confirmation")
+
 	parentCmd.AddCommand(
 		uninstallCmd,
 	)
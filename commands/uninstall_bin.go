@@ -0,0 +1,167 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mkloubert/go-package-manager/constants"
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+// find_executable_in_bin_folder() - returns the name of an existing
+// executable file in bin folder or an empty string if not found
+func find_executable_in_bin_folder(binPath string, binName string, noAutoExt bool) (string, error) {
+	// only files directly inside bin folder
+	if binName == "" || binName == "." || binName == ".." || filepath.Base(binName) != binName || strings.ContainsAny(binName, `/\`) {
+		return "", fmt.Errorf("invalid executable name '%v'", binName)
+	}
+
+	candidates := []string{binName}
+	if !noAutoExt && utils.IsWindows() && !strings.HasSuffix(binName, constants.WindowsExecutableExt) {
+		candidates = append(candidates, binName+constants.WindowsExecutableExt)
+	}
+
+	for _, c := range candidates {
+		isExisting, err := utils.IsFileExisting(path.Join(binPath, c))
+		if err != nil {
+			return "", err
+		}
+
+		if isExisting {
+			return c, nil
+		}
+	}
+
+	return "", nil
+}
+
+func Init_UninstallBin_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var all bool
+	var noAutoExt bool
+	var yes bool
+
+	var uninstallBinCmd = &cobra.Command{
+		Use:     "uninstall-bin [executable name]",
+		Aliases: []string{"ub", "uninstall-binary"},
+		Short:   "Uninstalls executables",
+		Long:    `Removes one or more executables from bin folder, which have been installed by make command for example.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !all && len(args) == 0 {
+				utils.CloseWithError(fmt.Errorf("no executable defined, use --all to remove all"))
+			}
+
+			binPath, err := app.EnsureBinFolder()
+			utils.CheckForError(err)
+
+			manifest, err := app.LoadMakeManifestFile()
+			utils.CheckForError(err)
+
+			binFilenames := []string{}
+			if all {
+				binEntries, err := os.ReadDir(binPath)
+				utils.CheckForError(err)
+
+				for _, entry := range binEntries {
+					if !entry.IsDir() {
+						binFilenames = append(binFilenames, entry.Name())
+					}
+				}
+			} else {
+				for _, a := range args {
+					binName := strings.TrimSpace(a)
+
+					binFilename, err := find_executable_in_bin_folder(binPath, binName, noAutoExt)
+					utils.CheckForError(err)
+
+					if binFilename == "" {
+						utils.CloseWithError(fmt.Errorf("executable '%v' not found in '%v'", binName, binPath))
+					}
+
+					binFilenames = append(binFilenames, binFilename)
+				}
+			}
+			sort.Strings(binFilenames)
+
+			if len(binFilenames) == 0 {
+				fmt.Println("No executables found")
+				return
+			}
+
+			var totalSize int64 = 0
+			for _, binFilename := range binFilenames {
+				info, err := os.Stat(path.Join(binPath, binFilename))
+				utils.CheckForError(err)
+
+				totalSize += info.Size()
+			}
+
+			if !yes {
+				fmt.Printf("Remove %v (%v) from '%v' (y/N)? ", strings.Join(binFilenames, ", "), utils.FormatByteSize(totalSize), binPath)
+
+				reader := bufio.NewReader(app.In)
+				confirmation, _ := reader.ReadString('\n')
+
+				switch strings.TrimSpace(strings.ToLower(confirmation)) {
+				case "y", "yes":
+				default:
+					fmt.Println("Aborted")
+					return
+				}
+			}
+
+			for _, binFilename := range binFilenames {
+				executableFilePath := path.Join(binPath, binFilename)
+
+				app.Debug(fmt.Sprintf("Removing executable file '%v' ...", executableFilePath))
+				err := os.Remove(executableFilePath)
+				utils.CheckForError(err)
+
+				delete(manifest.Executables, binFilename)
+				delete(manifest.Executables, strings.TrimSuffix(binFilename, constants.WindowsExecutableExt))
+			}
+
+			err = app.UpdateMakeManifestFile(manifest)
+			utils.CheckForError(err)
+
+			fmt.Printf("Removed %v executable(s), freed %v%v", len(binFilenames), utils.FormatByteSize(totalSize), fmt.Sprintln())
+		},
+	}
+
+	uninstallBinCmd.Flags().BoolVarP(&all, "all", "a", false, "remove all executables from bin folder")
+	uninstallBinCmd.Flags().BoolVarP(&noAutoExt, "no-auto-extension", "", false, "do not add file extension automatically")
+	uninstallBinCmd.Flags().BoolVarP(&yes, "yes", "y", false, "do not ask for confirmation")
+
+	parentCmd.AddCommand(
+		uninstallBinCmd,
+	)
+}
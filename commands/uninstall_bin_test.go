@@ -0,0 +1,62 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindExecutableInBinFolder(t *testing.T) {
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "bin")
+
+	for _, p := range []string{filepath.Join(binPath, "tool"), filepath.Join(dir, "outside")} {
+		err := os.MkdirAll(filepath.Dir(p), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(p, []byte("#!/bin/sh\n"), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	name, err := find_executable_in_bin_folder(binPath, "tool", true)
+	if err != nil || name != "tool" {
+		t.Errorf("expected 'tool', got '%v' (%v)", name, err)
+	}
+
+	name, err = find_executable_in_bin_folder(binPath, "unknown", true)
+	if err != nil || name != "" {
+		t.Errorf("expected no executable, got '%v' (%v)", name, err)
+	}
+
+	for _, invalidName := range []string{"", ".", "..", "../outside", "sub/tool", `..\outside`, "/etc/passwd"} {
+		name, err := find_executable_in_bin_folder(binPath, invalidName, true)
+		if err == nil {
+			t.Errorf("'%v': expected error, got '%v'", invalidName, name)
+		}
+	}
+}
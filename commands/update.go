@@ -31,7 +31,10 @@ import (
 )
 
 func Init_Update_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var check bool
+	var checksum string
 	var force bool
+	var noChecksum bool
 	var noCleanup bool
 	var noVersionPrint bool
 	var powerShell bool
@@ -46,10 +49,12 @@ func Init_Update_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		Short:   "Update dependencies",
 		Long:    `Updates all or only specific dependencies in this project.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if selfUpdate {
+			if check {
+				run_self_update_check(app, cmd.Root().Version, userAgent)
+			} else if selfUpdate {
 				run_self_update_command(
 					app,
-					force, noVersionPrint, powerShell, powerShellBin, updateScript, userAgent,
+					checksum, force, noChecksum, noVersionPrint, powerShell, powerShellBin, updateScript, userAgent,
 				)
 			} else {
 				modulesToUpdate := make([]string, 0)
@@ -85,7 +90,10 @@ func Init_Update_Command(parentCmd *cobra.Command, app *types.AppContext) {
 		},
 	}
 
+	updateCmd.Flags().BoolVarP(&check, "check", "", false, "only check if there is a newer version of this binary")
+	updateCmd.Flags().StringVarP(&checksum, "checksum", "", "", "expected SHA256 checksum of update script")
 	updateCmd.Flags().BoolVarP(&force, "force", "", false, "force self-update")
+	updateCmd.Flags().BoolVarP(&noChecksum, "no-checksum", "", false, "do not verify checksum of update script")
 	updateCmd.Flags().BoolVarP(&noCleanup, "no-cleanup", "", false, "do not cleanup go.mod and go.sum")
 	updateCmd.Flags().BoolVarP(&noVersionPrint, "no-version-print", "", false, "do not print new version after successful update")
 	updateCmd.Flags().BoolVarP(&powerShell, "powershell", "", false, "force execution of PowerShell script")
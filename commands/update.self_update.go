@@ -25,6 +25,7 @@ package commands
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,23 +36,83 @@ import (
 
 	browser "github.com/EDDYCJY/fake-useragent"
 	"github.com/alecthomas/chroma/quick"
+	"github.com/hashicorp/go-version"
 	"github.com/mkloubert/go-package-manager/types"
 	"github.com/mkloubert/go-package-manager/utils"
 )
 
+// get_self_update_user_agent() - returns the custom user agent
+// for self-update requests or a random browser one
+func get_self_update_user_agent(userAgent string) string {
+	customUserAgent := strings.TrimSpace(userAgent)
+	if customUserAgent == "" {
+		customUserAgent = browser.Chrome()
+	}
+
+	return customUserAgent
+}
+
+// run_self_update_check() - checks if there is a newer release than `currentVersion`
+// without updating anything, exits with code 2 if an update is available
+func run_self_update_check(app *types.AppContext, currentVersion string, userAgent string) {
+	url := "https://api.github.com/repos/mkloubert/go-package-manager/releases/latest"
+
+	customUserAgent := get_self_update_user_agent(userAgent)
+
+	app.Debug(fmt.Sprintf("Checking latest release from '%s' ...", url))
+	app.Debug(fmt.Sprintf("User agent: %s", customUserAgent))
+
+	req, err := http.NewRequest("GET", url, nil)
+	utils.CheckForError(err)
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", customUserAgent)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	utils.CheckForError(err)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		utils.CloseWithError(fmt.Errorf("unexpected response from '%s': %v", url, resp.Status))
+	}
+
+	var release struct {
+		HtmlUrl string `json:"html_url"`
+		TagName string `json:"tag_name"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&release)
+	utils.CheckForError(err)
+
+	latestVersion, err := version.NewVersion(strings.TrimSpace(release.TagName))
+	utils.CheckForError(err)
+
+	installedVersion, err := version.NewVersion(strings.TrimSpace(currentVersion))
+	utils.CheckForError(err)
+
+	if installedVersion.GreaterThanOrEqual(latestVersion) {
+		fmt.Fprintf(app.Out, "gpm is up-to-date (%v)%v", installedVersion.String(), fmt.Sprintln())
+		return
+	}
+
+	fmt.Fprintf(app.Out, "Update available: %v -> %v%v", installedVersion.String(), latestVersion.String(), fmt.Sprintln())
+	if release.HtmlUrl != "" {
+		fmt.Fprintln(app.Out, release.HtmlUrl)
+	}
+
+	utils.Exit(2)
+}
+
 func run_self_update_command(
 	app *types.AppContext,
-	force bool, noVersionPrint bool, powerShell bool, powerShellBin string, updateScript string, userAgent string,
+	checksum string, force bool, noChecksum bool, noVersionPrint bool, powerShell bool, powerShellBin string, updateScript string, userAgent string,
 ) {
 	app.Debug("Will start self-update ...")
 
 	consoleFormatter := utils.GetBestChromaFormatterName()
 	consoleStyle := utils.GetBestChromaStyleName()
 
-	customUserAgent := strings.TrimSpace(userAgent)
-	if customUserAgent == "" {
-		customUserAgent = browser.Chrome()
-	}
+	customUserAgent := get_self_update_user_agent(userAgent)
 
 	customPowerShellBin := strings.TrimSpace(powerShellBin)
 	if customPowerShellBin == "" {
@@ -90,6 +151,34 @@ func run_self_update_command(
 		return responseData, err
 	}
 
+	// verifies a script by the SHA256 checksum from `--checksum`
+	// or the one in `<scriptUrl>.sha256`
+	verifyScript := func(scriptUrl string, script []byte) {
+		if noChecksum {
+			app.Warn("Checksum verification of update script has been skipped")
+			return
+		}
+
+		expectedChecksum := strings.TrimSpace(checksum)
+		if expectedChecksum == "" {
+			checksumUrl := scriptUrl + ".sha256"
+
+			checksumFileContent, err := downloadScript(checksumUrl)
+			if err != nil {
+				utils.CloseWithError(fmt.Errorf("could not download checksum from '%s': %v", checksumUrl, err))
+			}
+
+			expectedChecksum, err = utils.ParseSHA256ChecksumFile(checksumFileContent)
+			utils.CheckForError(err)
+		}
+
+		app.Debug(fmt.Sprintf("Verifying script with checksum '%s' ...", expectedChecksum))
+		err := utils.VerifySHA256(script, expectedChecksum)
+		if err != nil {
+			utils.CloseWithError(fmt.Errorf("update script '%s' is not trustworthy: %v", scriptUrl, err))
+		}
+	}
+
 	showNewVersion := func() {
 		if noVersionPrint {
 			return
@@ -115,6 +204,8 @@ func run_self_update_command(
 		pwshScript, err := downloadScript(scriptUrl)
 		utils.CheckForError(err)
 
+		verifyScript(scriptUrl, pwshScript)
+
 		executeScript := func() {
 			p := exec.Command(customPowerShellBin, "-NoProfile", "-Command", "-")
 			p.Dir = app.Cwd
@@ -184,6 +275,8 @@ func run_self_update_command(
 		bashScript, err := downloadScript(scriptUrl)
 		utils.CheckForError(err)
 
+		verifyScript(scriptUrl, bashScript)
+
 		executeScript := func() {
 			p := exec.Command("sh")
 			p.Dir = app.Cwd
@@ -0,0 +1,79 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/mkloubert/go-package-manager/types"
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+func Init_Validate_Command(parentCmd *cobra.Command, app *types.AppContext) {
+	var validateCmd = &cobra.Command{
+		Use:     "validate [gpm.yaml]",
+		Aliases: []string{"lint"},
+		Short:   "Validate gpm.yaml",
+		Long:    `Checks a gpm.yaml file for unknown keys, invalid scripts and invalid file patterns.`,
+		Args:    cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			green := color.New(color.FgGreen).SprintFunc()
+			red := color.New(color.FgRed).SprintFunc()
+
+			var gpmFilePath string
+			if len(args) > 0 {
+				gpmFilePath = app.GetFullPathOrDefault(args[0], "")
+			} else {
+				p, err := app.GetGpmFilePath()
+				utils.CheckForError(err)
+
+				gpmFilePath = p
+			}
+
+			app.Debug(fmt.Sprintf("Validating '%v' ...", gpmFilePath))
+			yamlData, err := os.ReadFile(gpmFilePath)
+			utils.CheckForError(err)
+
+			issues := types.ValidateGpmFile(yamlData)
+			if len(issues) == 0 {
+				fmt.Fprintf(app.Out, "[%s] %v is valid%s", green("✓"), path.Base(gpmFilePath), fmt.Sprintln())
+				return
+			}
+
+			for _, issue := range issues {
+				fmt.Fprintf(app.Out, "[%s] %v%s", red("!"), issue.String(), fmt.Sprintln())
+			}
+
+			utils.Exit(1)
+		},
+	}
+
+	parentCmd.AddCommand(
+		validateCmd,
+	)
+}
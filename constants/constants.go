@@ -23,6 +23,7 @@
 package constants
 
 // AI APIs
+const AIApiMock = "mock"
 const AIApiOllama = "ollama"
 const AIApiOpenAI = "openai"
 
@@ -38,3 +39,6 @@ const PreInstallScriptName = "preinstall"
 const PrePackScriptName = "prepack"
 const StartScriptName = "start"
 const TidyScriptName = "tidy"
+
+// templates
+const TemplateModuleNamePlaceholder = "__MODULE_NAME__"
@@ -26,6 +26,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -55,15 +56,28 @@ func main() {
 	app.In = os.Stdin
 	app.IsCI = strings.TrimSpace(strings.ToLower(os.Getenv("CI"))) == "true"
 	app.Out = os.Stdout
+	app.StartTime = time.Now()
 
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		app.CommandPath = cmd.CommandPath()
+	}
+
+	// use "ai-log flag" everywhere
+	rootCmd.PersistentFlags().StringVarP(&app.AILogFile, "ai-log", "", "", "write AI requests and responses to a JSONL file")
 	// use "aliases-file flag" everywhere
 	rootCmd.PersistentFlags().StringVarP(&app.AliasesFilePath, "aliases-file", "", "", "custom aliases file")
+	// use "dry-run flag" everywhere
+	rootCmd.PersistentFlags().BoolVarP(&app.DryRun, "dry-run", "", false, "only output commands instead of executing them")
 	// use "environment flag" everywhere
 	rootCmd.PersistentFlags().StringVarP(&app.Environment, "environment", "", "", "name of the environment")
 	// use "env-file flag" everywhere
 	rootCmd.PersistentFlags().StringArrayVarP(&app.EnvFiles, "env-file", "e", []string{}, "one or more environment files")
 	// use "gpm-root flag" everywhere
 	rootCmd.PersistentFlags().StringVarP(&app.GpmRootPath, "gpm-root", "", "", "custom root directory for this app")
+	// use "log-format flag" everywhere
+	rootCmd.PersistentFlags().StringVarP(&app.LogFormat, "log-format", "", "", "log format, like 'text' or 'json' (default in CI)")
+	// use "ignore-budget flag" everywhere
+	rootCmd.PersistentFlags().BoolVarP(&app.IgnoreBudget, "ignore-budget", "", false, "do not check AI budget from settings file")
 	// use custom AI model
 	rootCmd.PersistentFlags().StringVarP(&app.Model, "model", "", "", "custom AI model")
 	// use "no-system-prompt flag" everywhere
@@ -74,33 +88,47 @@ func main() {
 	rootCmd.PersistentFlags().StringVarP(&app.Prompt, "prompt", "", "", "custom (AI) prompt")
 	// use "projects-file flag" everywhere
 	rootCmd.PersistentFlags().StringVarP(&app.ProjectsFilePath, "projects-file", "", "", "custom projects file")
+	// use "quiet flag" everywhere
+	rootCmd.PersistentFlags().BoolVarP(&app.Quiet, "quiet", "q", false, "suppress non-essential output")
 	// use "system-prompt flag" everywhere
 	rootCmd.PersistentFlags().StringVarP(&app.SystemPrompt, "system-prompt", "", "", "custom (AI) system prompt")
+	// use "timings flag" everywhere
+	rootCmd.PersistentFlags().BoolVarP(&app.Timings, "timings", "", false, "output durations of major phases to STDERR")
 	// use "verbose flag" everywhere
 	rootCmd.PersistentFlags().BoolVarP(&app.Verbose, "verbose", "v", false, "verbose output")
 
 	app.LoadEnvFilesIfExist()
 	app.LoadAliasesFileIfExist()
 	app.LoadProjectsFileIfExist()
+	app.LoadSettingsFileIfExist()
+	app.LoadTemplatesFileIfExist()
 	app.LoadGpmFileIfExist()
 
 	// initialize commands
 	commands.Init_Add_Command(rootCmd, &app)
+	commands.Init_Audit_Command(rootCmd, &app)
 	commands.Init_Base64_Command(rootCmd, &app)
+	commands.Init_Bench_Command(rootCmd, &app)
 	commands.Init_Build_Command(rootCmd, &app)
 	commands.Init_Bump_Command(rootCmd, &app)
 	commands.Init_Cat_Command(rootCmd, &app)
 	commands.Init_Chat_Command(rootCmd, &app)
 	commands.Init_Checkout_Command(rootCmd, &app)
+	commands.Init_Compress_Command(rootCmd, &app)
+	commands.Init_Config_Command(rootCmd, &app)
+	commands.Init_Cron_Command(rootCmd, &app)
+	commands.Init_Deps_Command(rootCmd, &app)
 	commands.Init_Describe_Command(rootCmd, &app)
 	commands.Init_Diff_Command(rootCmd, &app)
 	commands.Init_Doctor_Command(rootCmd, &app)
 	commands.Init_Down_Command(rootCmd, &app)
 	commands.Init_Exec_Command(rootCmd, &app)
 	commands.Init_Generate_Command(rootCmd, &app)
+	commands.Init_Hash_Command(rootCmd, &app)
 	commands.Init_Import_Command(rootCmd, &app)
 	commands.Init_Init_Command(rootCmd, &app)
 	commands.Init_Install_Command(rootCmd, &app)
+	commands.Init_Jwt_Command(rootCmd, &app)
 	commands.Init_List_Command(rootCmd, &app)
 	commands.Init_Make_Command(rootCmd, &app)
 	commands.Init_Monitor_Command(rootCmd, &app)
@@ -109,23 +137,40 @@ func main() {
 	commands.Init_Open_Command(rootCmd, &app)
 	commands.Init_Pack_Command(rootCmd, &app)
 	commands.Init_Prompt_Command(rootCmd, &app)
+	commands.Init_Proxy_Command(rootCmd, &app)
 	commands.Init_Publish_Command(rootCmd, &app)
 	commands.Init_Pull_Command(rootCmd, &app)
 	commands.Init_Push_Command(rootCmd, &app)
+	commands.Init_Release_Command(rootCmd, &app)
 	commands.Init_Remove_Command(rootCmd, &app)
 	commands.Init_Run_Command(rootCmd, &app)
+	commands.Init_Sbom_Command(rootCmd, &app)
+	commands.Init_SelfTest_Command(rootCmd, &app)
+	commands.Init_Serve_Command(rootCmd, &app)
 	commands.Init_Setup_Command(rootCmd, &app)
 	commands.Init_Show_Command(rootCmd, &app)
+	commands.Init_Size_Command(rootCmd, &app)
+	commands.Init_Sleep_Command(rootCmd, &app)
 	commands.Init_Start_Command(rootCmd, &app)
 	commands.Init_Sync_Command(rootCmd, &app)
+	commands.Init_Tag_Command(rootCmd, &app)
 	commands.Init_Test_Command(rootCmd, &app)
 	commands.Init_Tidy_Command(rootCmd, &app)
+	commands.Init_Uncompress_Command(rootCmd, &app)
 	commands.Init_Uninstall_Command(rootCmd, &app)
+	commands.Init_UninstallBin_Command(rootCmd, &app)
 	commands.Init_Up_Command(rootCmd, &app)
 	commands.Init_Update_Command(rootCmd, &app)
+	commands.Init_Validate_Command(rootCmd, &app)
+
+	// timings should also be printed, if a command exits early
+	utils.OnExit(app.PrintTimings)
 
 	// execute
-	if err := rootCmd.Execute(); err != nil {
+	err = rootCmd.Execute()
+	if err != nil {
 		utils.CloseWithError(err)
 	}
+
+	utils.Exit(0)
 }
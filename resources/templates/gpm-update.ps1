@@ -0,0 +1,92 @@
+function Handle-Error {
+    param (
+        [string]$Message
+    )
+    Write-Host "Error: $Message" -ForegroundColor Red
+    exit 1
+}
+
+Write-Host "gpm-update"
+Write-Host ""
+
+$TempFolder = Join-Path ([System.IO.Path]::GetTempPath()) ("gpm-update-" + [System.Guid]::NewGuid().ToString())
+New-Item -ItemType Directory -Path $TempFolder | Out-Null
+
+$ZipFile = Join-Path $TempFolder "gpm.zip"
+$Sha256File = Join-Path $TempFolder "gpm.zip.sha256"
+$ExtractFolder = Join-Path $TempFolder "gpm_extracted"
+
+Write-Host "Finding download URL and SHA256 URL ..."
+try {
+    $LatestReleaseInfo = Invoke-RestMethod -Uri "https://api.github.com/repos/mkloubert/go-package-manager/releases/latest"
+} catch {
+    Handle-Error "Could not fetch release infos"
+}
+
+$DownloadUrl = $LatestReleaseInfo.assets | Where-Object {
+    $_.browser_download_url -match "gpm" -and
+    $_.browser_download_url -match "{{.GOOS}}" -and
+    $_.browser_download_url -match "{{.GOARCH}}" -and
+    $_.browser_download_url -notmatch "sha256"
+} | Select-Object -First 1 -ExpandProperty browser_download_url
+
+$Sha256Url = $LatestReleaseInfo.assets | Where-Object {
+    $_.browser_download_url -match "gpm" -and
+    $_.browser_download_url -match "{{.GOOS}}" -and
+    $_.browser_download_url -match "{{.GOARCH}}" -and
+    $_.browser_download_url -match "sha256"
+} | Select-Object -First 1 -ExpandProperty browser_download_url
+
+if (-not $DownloadUrl) {
+    Handle-Error "No valid download URL found"
+}
+if (-not $Sha256Url) {
+    Handle-Error "No valid SHA256 URL found"
+}
+
+Write-Host "Downloading zip file from '$DownloadUrl'..."
+try {
+    Invoke-WebRequest -Uri $DownloadUrl -OutFile $ZipFile
+} catch {
+    Handle-Error "Failed to download zip file"
+}
+
+Write-Host "Downloading SHA256 file from '$Sha256Url'..."
+try {
+    Invoke-WebRequest -Uri $Sha256Url -OutFile $Sha256File
+} catch {
+    Handle-Error "Failed to download SHA256 file"
+}
+
+Write-Host "Verifying zip file ..."
+$ExpectedHash = ((Get-Content $Sha256File -Raw).Trim() -split '\s+')[0]
+$CalculatedHash = (Get-FileHash -Path $ZipFile -Algorithm SHA256).Hash
+if ($ExpectedHash -ne $CalculatedHash) {
+    Handle-Error "SHA256 verification failed"
+}
+
+Write-Host "Extracting binary ..."
+try {
+    Expand-Archive -Path $ZipFile -DestinationPath $ExtractFolder -Force
+} catch {
+    Handle-Error "Could not extract 'gpm.exe' binary"
+}
+
+Write-Host "Installing 'gpm.exe' to {{.TargetFolder}} ..."
+try {
+    if (-not (Test-Path "{{.TargetFolder}}")) {
+        New-Item -ItemType Directory -Path "{{.TargetFolder}}" | Out-Null
+    }
+    Move-Item -Path (Join-Path $ExtractFolder "gpm.exe") -Destination (Join-Path "{{.TargetFolder}}" "gpm.exe") -Force
+} catch {
+    Handle-Error "Could not move 'gpm.exe' to '{{.TargetFolder}}'"
+}
+
+Write-Host "Cleaning up ..."
+try {
+    Remove-Item -Path $TempFolder -Recurse -Force
+} catch {
+    Handle-Error "Cleanups failed"
+}
+
+Write-Host "'gpm.exe' successfully installed or updated 👍" -ForegroundColor Green
@@ -29,18 +29,23 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
 	"github.com/gdamore/tcell/v2"
+	"github.com/mkloubert/go-package-manager/utils"
 	"github.com/rivo/tview"
 )
 
 // AIEditor represents an AI editor / viewer
 type AIEditor struct {
-	App           *AppContext     // the underlying application context
-	ChatEditor    *tview.TextArea // the chat editor TextArea
-	ChatHistory   *tview.List     // the chat history
-	CreateButton  *tview.Button   // the "create" button
-	FileViewer    *tview.TextView // the viewer for file content
-	InfoLeft      *tview.TextView // the last info
+	App           *AppContext       // the underlying application context
+	ChatEditor    *tview.TextArea   // the chat editor TextArea
+	ChatHistory   *tview.List       // the chat history
+	CreateButton  *tview.Button     // the "create" button
+	FileViewer    *tview.TextView   // the viewer for file content
+	Filter        *tview.InputField // the filter for the file tree
+	InfoLeft      *tview.TextView   // the last info
 	isCreating    bool
 	isResetting   bool
 	isSending     bool
@@ -244,7 +249,7 @@ func (e *AIEditor) init_create_button() *tview.Button {
 
 func (e *AIEditor) init_file_viewer() *tview.TextView {
 	fileViewer := tview.NewTextView().
-		SetDynamicColors(false) // TODO: implement later
+		SetDynamicColors(true)
 
 	fileViewer.SetBorder(true).
 		SetBorderPadding(0, 0, 1, 1)
@@ -264,6 +269,36 @@ func (e *AIEditor) init_file_viewer() *tview.TextView {
 	return fileViewer
 }
 
+func (e *AIEditor) init_filter() *tview.InputField {
+	filter := tview.NewInputField().
+		SetPlaceholder("Filter files").
+		SetFieldWidth(0)
+
+	filter.SetBorder(true)
+
+	filter.SetChangedFunc(func(text string) {
+		e.rebuild_file_tree()
+	})
+
+	filter.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyTab || event.Key() == tcell.KeyEnter || event.Key() == tcell.KeyDown {
+			// TAB, ENTER or down
+			e.UI.SetFocus(e.Tree)
+			return nil
+		}
+		if event.Key() == tcell.KeyEscape {
+			// ESC => clear filter
+			filter.SetText("")
+			return nil
+		}
+		return event
+	})
+
+	e.Filter = filter
+
+	return filter
+}
+
 func (e *AIEditor) init_left_infobox() *tview.TextView {
 	infoLeft := tview.NewTextView()
 
@@ -329,6 +364,7 @@ func (e *AIEditor) init_root() *tview.Flex {
 	// complete left side
 	left := tview.NewFlex().
 		SetDirection(tview.FlexRow).
+		AddItem(e.Filter, 3, 1, false).
 		AddItem(e.Tree, 0, 1, false).
 		AddItem(e.InfoLeft, 0, 0, false).
 		AddItem(leftButtonGroup, 3, 1, false)
@@ -361,7 +397,7 @@ func (e *AIEditor) init_send_button() *tview.Button {
 
 	sendButton.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if event.Key() == tcell.KeyTab {
-			e.UI.SetFocus(e.Tree)
+			e.UI.SetFocus(e.Filter)
 			return nil
 		}
 		if event.Key() == tcell.KeyLeft {
@@ -414,6 +450,11 @@ func (e *AIEditor) init_tree() *tview.TreeView {
 			e.UI.SetFocus(e.CreateButton)
 			return nil
 		}
+		if event.Key() == tcell.KeyRune && event.Rune() == '/' {
+			// slash => filter
+			e.UI.SetFocus(e.Filter)
+			return nil
+		}
 		return event
 	})
 
@@ -447,6 +488,7 @@ func NewAIEditor(app *AppContext, projectUrl string) *AIEditor {
 	e.init_chat_history()
 	e.init_create_button()
 	e.init_file_viewer()
+	e.init_filter()
 	e.init_left_infobox()
 	e.init_reset_button()
 	e.init_send_button()
@@ -472,9 +514,12 @@ func (e *AIEditor) rebuild_file_tree() {
 	e.Tree.SetRoot(root).
 		SetCurrentNode(root)
 
+	filterText := strings.TrimSpace(strings.ToLower(e.Filter.GetText()))
+
 	// A helper function which adds the files and directories of the given path
 	// to the given target node.
-	add := func(parentNode *tview.TreeNode, node *AIEditorFileTreeNode) {
+	var add func(parentNode *tview.TreeNode, node *AIEditorFileTreeNode)
+	add = func(parentNode *tview.TreeNode, node *AIEditorFileTreeNode) {
 		if node.Children == nil {
 			return // nothing to do
 		}
@@ -494,13 +539,23 @@ func (e *AIEditor) rebuild_file_tree() {
 			parentNode.AddChild(treeNode)
 
 			node.Node = treeNode
+
+			if filterText != "" {
+				// show all matching files
+				add(treeNode, child)
+			}
 		}
 	}
 
 	// build tree
 	{
+		treeNodes := e.TreeNodes
+		if filterText != "" {
+			treeNodes = filter_ai_editor_file_nodes(treeNodes, "", filterText)
+		}
+
 		rootNode := &AIEditorFileTreeNode{
-			Children: e.TreeNodes,
+			Children: treeNodes,
 			Content:  []byte{},
 			Name:     "",
 			Node:     root,
@@ -537,6 +592,89 @@ func (e *AIEditor) Run() error {
 		Run()
 }
 
+// highlight_code_for_tview() - converts source code to text with
+// tview color tags based on the lexer for the file name
+func highlight_code_for_tview(name string, code string) (string, error) {
+	lexer := lexers.Match(path.Base(name))
+	if lexer == nil {
+		lexer = lexers.Analyse(code)
+	}
+	if lexer == nil {
+		return tview.Escape(code), nil
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	style := styles.Get(utils.GetBestChromaStyleName())
+
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return "", err
+	}
+
+	var highlightedCode strings.Builder
+	for _, token := range iterator.Tokens() {
+		value := tview.Escape(token.Value)
+
+		entry := style.Get(token.Type)
+
+		attributes := ""
+		if entry.Bold == chroma.Yes {
+			attributes += "b"
+		}
+		if entry.Italic == chroma.Yes {
+			attributes += "i"
+		}
+		if entry.Underline == chroma.Yes {
+			attributes += "u"
+		}
+
+		if !entry.Colour.IsSet() && attributes == "" {
+			highlightedCode.WriteString(value)
+			continue
+		}
+
+		foreground := "-"
+		if entry.Colour.IsSet() {
+			foreground = entry.Colour.String()
+		}
+
+		highlightedCode.WriteString(fmt.Sprintf("[%s::%s]%s[-::-]", foreground, attributes, value))
+	}
+
+	return highlightedCode.String(), nil
+}
+
+// filter_ai_editor_file_nodes() - returns copies of nodes whose path contains
+// a filter text, including the parent directories of matching files
+func filter_ai_editor_file_nodes(nodes []*AIEditorFileTreeNode, parentPath string, filterText string) []*AIEditorFileTreeNode {
+	filteredNodes := make([]*AIEditorFileTreeNode, 0)
+
+	for _, node := range nodes {
+		nodePath := path.Join(parentPath, node.Name)
+
+		if node.Type == "file" {
+			if strings.Contains(strings.ToLower(nodePath), filterText) {
+				filteredNodes = append(filteredNodes, node)
+			}
+
+			continue
+		}
+
+		filteredChildren := filter_ai_editor_file_nodes(node.Children, nodePath, filterText)
+		if len(filteredChildren) > 0 {
+			filteredNodes = append(filteredNodes, &AIEditorFileTreeNode{
+				Children: filteredChildren,
+				Content:  node.Content,
+				Name:     node.Name,
+				Parent:   node.Parent,
+				Type:     node.Type,
+			})
+		}
+	}
+
+	return filteredNodes
+}
+
 func sort_ai_editor_file_nodes(nodes []*AIEditorFileTreeNode) {
 	sort.Slice(nodes, func(i, j int) bool {
 		if nodes[i].Type == nodes[j].Type {
@@ -601,47 +739,12 @@ func (e *AIEditor) update_create_button_disabled_state() {
 }
 
 func (e *AIEditor) update_file_viewer(name string, content []byte) {
-	e.FileViewer.SetTitle(fmt.Sprintf(" %v ", name))
-
-	viewerText := string(content)
-
-	// TODO: currently this code does not work as expected, so implement later
-	/*
-		lexerName := strings.TrimSpace(
-			strings.ToLower(name),
-		)
-		for {
-			if strings.HasPrefix(lexerName, ".") {
-				lexerName = strings.TrimSpace(lexerName[1:])
-			} else {
-				break
-			}
-		}
-
-		lexer := lexers.Get(lexerName)
-		if lexer == nil {
-			lexer = lexers.Fallback
-		}
-
-		styleName := utils.GetBestChromaStyleName()
-
-		style := styles.Get(styleName)
-		if style == nil {
-			style = styles.Fallback
-		}
+	e.FileViewer.SetTitle(fmt.Sprintf(" %v ", tview.Escape(name)))
 
-		formatterName := utils.GetBestChromaFormatterName()
-		formatter := formatters.Get(formatterName)
-
-		iterator, err := lexer.Tokenise(nil, viewerText)
-		if err == nil {
-			var highlightedCode bytes.Buffer
-			err := formatter.Format(&highlightedCode, style, iterator)
-			if err == nil {
-				viewerText = highlightedCode.String()
-			}
-		}
-	*/
+	viewerText, err := highlight_code_for_tview(name, string(content))
+	if err != nil {
+		viewerText = tview.Escape(string(content))
+	}
 
 	e.FileViewer.
 		SetText(viewerText)
@@ -0,0 +1,258 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/constants"
+)
+
+// AILogEntry is an entry of an AI log file
+type AILogEntry struct {
+	Command  string            `json:"command,omitempty"`  // the gpm command, which sent the request, like `gpm prompt`
+	Duration int64             `json:"duration"`           // duration in milliseconds
+	Error    string            `json:"error,omitempty"`    // error message, if request failed
+	Headers  map[string]string `json:"headers,omitempty"`  // request headers with redacted secrets
+	Method   string            `json:"method"`             // the HTTP method
+	Request  json.RawMessage   `json:"request,omitempty"`  // the request body
+	Response json.RawMessage   `json:"response,omitempty"` // the response body
+	Status   int               `json:"status,omitempty"`   // the HTTP status code
+	Time     string            `json:"time"`               // the start time in RFC3339 format
+	Url      string            `json:"url"`                // the URL
+}
+
+// AILogTransport is an implementation of http.RoundTripper, which
+// writes requests and responses of AI APIs to a JSONL file
+type AILogTransport struct {
+	CheckBudget func() error      // optional function, which returns an error if the AI budget is exceeded
+	Command     string            // the current gpm command
+	File        string            // the path of the log file
+	Next        http.RoundTripper // the underlying transport
+	mutex       sync.Mutex
+}
+
+// AILogUsage stores the token usage of an `AILogEntry`
+type AILogUsage struct {
+	InputTokens  int    // number of tokens of the prompt
+	Model        string // the used model
+	OutputTokens int    // number of generated tokens
+}
+
+// LoadAILogEntries() - reads all entries from the JSONL data of an AI log
+func LoadAILogEntries(r io.Reader) ([]AILogEntry, error) {
+	entries := []AILogEntry{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for lineNr := 1; scanner.Scan(); lineNr++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var entry AILogEntry
+		err := json.Unmarshal([]byte(line), &entry)
+		if err != nil {
+			return entries, fmt.Errorf("invalid entry in line %v: %v", lineNr, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// e.GetUsage() - returns the model and token counts of an entry, which are read
+// from responses of OpenAI and Ollama, including streamed ones
+func (e *AILogEntry) GetUsage() AILogUsage {
+	usage := AILogUsage{}
+
+	var request struct {
+		Model string `json:"model"`
+	}
+	if json.Unmarshal(e.Request, &request) == nil {
+		usage.Model = request.Model
+	}
+
+	if len(e.Response) == 0 {
+		return usage
+	}
+
+	// streamed responses are no valid JSON and logged as string
+	responses := []string{string(e.Response)}
+	var responseText string
+	if json.Unmarshal(e.Response, &responseText) == nil {
+		responses = strings.Split(responseText, "\n")
+	}
+
+	for _, r := range responses {
+		r = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(r), "data:"))
+		if r == "" || r == "[DONE]" {
+			continue
+		}
+
+		var response struct {
+			EvalCount       int    `json:"eval_count"`
+			Model           string `json:"model"`
+			PromptEvalCount int    `json:"prompt_eval_count"`
+			Usage           *struct {
+				CompletionTokens int `json:"completion_tokens"`
+				PromptTokens     int `json:"prompt_tokens"`
+			} `json:"usage"`
+		}
+		if json.Unmarshal([]byte(r), &response) != nil {
+			continue
+		}
+
+		if response.Model != "" {
+			usage.Model = response.Model
+		}
+
+		// OpenAI
+		if response.Usage != nil {
+			usage.InputTokens += response.Usage.PromptTokens
+			usage.OutputTokens += response.Usage.CompletionTokens
+		}
+
+		// Ollama
+		usage.InputTokens += response.PromptEvalCount
+		usage.OutputTokens += response.EvalCount
+	}
+
+	return usage
+}
+
+// to_ai_log_json() - returns data as JSON, or as JSON string if it is no valid JSON
+func to_ai_log_json(data []byte) json.RawMessage {
+	if len(data) == 0 {
+		return nil
+	}
+
+	if json.Valid(data) {
+		return json.RawMessage(data)
+	}
+
+	jsonStr, _ := json.Marshal(string(data))
+	return json.RawMessage(jsonStr)
+}
+
+func (t *AILogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.CheckBudget != nil {
+		err := t.CheckBudget()
+		if err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+
+			return nil, err
+		}
+	}
+
+	startTime := time.Now()
+
+	entry := AILogEntry{
+		Command: t.Command,
+		Headers: map[string]string{},
+		Method:  req.Method,
+		Time:    startTime.Format(time.RFC3339),
+		Url:     req.URL.String(),
+	}
+
+	for name, values := range req.Header {
+		value := strings.Join(values, ", ")
+
+		switch strings.ToLower(name) {
+		case "api-key", "authorization", "x-api-key":
+			value = "[REDACTED]"
+		}
+
+		entry.Headers[name] = value
+	}
+
+	if req.Body != nil {
+		requestBody, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Request = to_ai_log_json(requestBody)
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+
+		responseBody, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+
+		entry.Response = to_ai_log_json(responseBody)
+		if readErr != nil {
+			entry.Error = readErr.Error()
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	}
+
+	entry.Duration = time.Since(startTime).Milliseconds()
+
+	t.writeEntry(entry)
+
+	return resp, err
+}
+
+func (t *AILogTransport) writeEntry(entry AILogEntry) {
+	jsonData, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	f, err := os.OpenFile(t.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, constants.DefaultFileMode)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	f.Write(append(jsonData, '\n'))
+}
@@ -0,0 +1,209 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+// AISummarizerTextPlaceholder is the placeholder in prompts
+// of an `AISummarizer` which is replaced by the text to summarize
+const AISummarizerTextPlaceholder = "{{TEXT}}"
+
+const defaultAISummarizerMapPrompt = `Summarize the following text in a compact but complete way.
+Keep all important facts, names and numbers.
+Only output the summary without any introduction.
+
+Text:
+` + AISummarizerTextPlaceholder
+
+const defaultAISummarizerReducePrompt = `The following text contains partial summaries of a larger document.
+Combine them to one coherent summary without repeating information.
+Only output the summary without any introduction.
+
+Partial summaries:
+` + AISummarizerTextPlaceholder
+
+// AISummarizer summarizes texts, which are too large for the context
+// window of an AI model, by summarizing single chunks first (map)
+// and combining these summaries afterwards (reduce)
+type AISummarizer struct {
+	app  *AppContext
+	chat ChatAI
+}
+
+// AISummarizeOptions stores options for `Summarize()` and `SummarizeChunks()`
+// methods of `AISummarizer` instance
+type AISummarizeOptions struct {
+	MapPrompt    *string                      // custom prompt for a single chunk
+	MaxChunkSize *int                         // maximum size of a chunk in characters, default 6000
+	MaxRounds    *int                         // maximum number of reduce rounds, default 5
+	OnProgress   func(phase string, i, n int) // optional function which receives progress information
+	ReducePrompt *string                      // custom prompt for combining summaries
+}
+
+// app.NewAISummarizer() - creates a new `AISummarizer` instance based on
+// this application context and a chat
+func (app *AppContext) NewAISummarizer(chat ChatAI) *AISummarizer {
+	s := &AISummarizer{
+		app:  app,
+		chat: chat,
+	}
+
+	return s
+}
+
+// s.Summarize() - splits a text into chunks and summarizes it
+func (s *AISummarizer) Summarize(text string, options ...AISummarizeOptions) (string, error) {
+	maxChunkSize := s.getMaxChunkSize(options...)
+
+	chunks := utils.SplitText(text, utils.SpliTextOptions{
+		MaxChunkSize: &maxChunkSize,
+	})
+
+	return s.SummarizeChunks(chunks, options...)
+}
+
+// s.SummarizeChunks() - summarizes each chunk and then recursively the summaries
+// until the result fits into one chunk
+func (s *AISummarizer) SummarizeChunks(chunks []string, options ...AISummarizeOptions) (string, error) {
+	mapPrompt := defaultAISummarizerMapPrompt
+	maxChunkSize := s.getMaxChunkSize(options...)
+	maxRounds := 5
+	var onProgress func(phase string, i, n int)
+	reducePrompt := defaultAISummarizerReducePrompt
+	for _, o := range options {
+		if o.MapPrompt != nil {
+			mapPrompt = *o.MapPrompt
+		}
+		if o.MaxRounds != nil {
+			maxRounds = *o.MaxRounds
+		}
+		if o.OnProgress != nil {
+			onProgress = o.OnProgress
+		}
+		if o.ReducePrompt != nil {
+			reducePrompt = *o.ReducePrompt
+		}
+	}
+
+	reportProgress := func(phase string, i, n int) {
+		if onProgress != nil {
+			onProgress(phase, i, n)
+		}
+	}
+
+	var nonEmptyChunks []string
+	for _, c := range chunks {
+		if strings.TrimSpace(c) != "" {
+			nonEmptyChunks = append(nonEmptyChunks, c)
+		}
+	}
+	if len(nonEmptyChunks) == 0 {
+		return "", nil
+	}
+
+	// map
+	summaries, err := s.summarizeAll("map", mapPrompt, nonEmptyChunks, reportProgress)
+	if err != nil {
+		return "", err
+	}
+
+	// reduce
+	for round := 1; ; round++ {
+		combined := strings.Join(summaries, "\n\n")
+
+		if len(combined) <= maxChunkSize || len(summaries) == 1 {
+			if len(summaries) == 1 {
+				return summaries[0], nil
+			}
+
+			reportProgress("reduce", 1, 1)
+
+			s.app.Debug(fmt.Sprintf("Reducing %v summaries to final one ...", len(summaries)))
+			return s.send(reducePrompt, combined)
+		}
+
+		if round > maxRounds {
+			return "", fmt.Errorf("summaries still too large after %v reduce rounds", maxRounds)
+		}
+
+		s.app.Debug(fmt.Sprintf("Reduce round #%v with %v summaries ...", round, len(summaries)))
+
+		groups := utils.SplitText(combined, utils.SpliTextOptions{
+			MaxChunkSize: &maxChunkSize,
+		})
+
+		summaries, err = s.summarizeAll("reduce", reducePrompt, groups, reportProgress)
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func (s *AISummarizer) getMaxChunkSize(options ...AISummarizeOptions) int {
+	maxChunkSize := 6000
+	for _, o := range options {
+		if o.MaxChunkSize != nil {
+			maxChunkSize = *o.MaxChunkSize
+		}
+	}
+
+	return maxChunkSize
+}
+
+func (s *AISummarizer) send(prompt string, text string) (string, error) {
+	finalPrompt := strings.ReplaceAll(prompt, AISummarizerTextPlaceholder, text)
+	if !strings.Contains(prompt, AISummarizerTextPlaceholder) {
+		finalPrompt = prompt + "\n\n" + text
+	}
+
+	answer := ""
+	err := s.chat.SendPrompt(finalPrompt, func(messageChunk string) error {
+		answer += messageChunk
+		return nil
+	})
+
+	return strings.TrimSpace(answer), err
+}
+
+func (s *AISummarizer) summarizeAll(phase string, prompt string, texts []string, reportProgress func(phase string, i, n int)) ([]string, error) {
+	var summaries []string
+
+	for i, t := range texts {
+		reportProgress(phase, i+1, len(texts))
+
+		s.app.Debug(fmt.Sprintf("Summarizing chunk %v of %v (%v) ...", i+1, len(texts), phase))
+		summary, err := s.send(prompt, t)
+		if err != nil {
+			return summaries, err
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
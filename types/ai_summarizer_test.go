@@ -0,0 +1,138 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAISummarizerSingleChunk(t *testing.T) {
+	chat := &MockAIChat{
+		Responses: []string{"  summary  "},
+	}
+
+	summary, err := (&AppContext{}).NewAISummarizer(chat).Summarize("a short text")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary != "summary" {
+		t.Errorf("expected 'summary', got '%v'", summary)
+	}
+	if len(chat.Requests) != 1 {
+		t.Fatalf("expected 1 request, got %v", len(chat.Requests))
+	}
+	if !strings.Contains(chat.Requests[0], "a short text") {
+		t.Errorf("text is missing in prompt '%v'", chat.Requests[0])
+	}
+}
+
+func TestAISummarizerMapReduce(t *testing.T) {
+	chat := &MockAIChat{
+		Responses: []string{"s1", "s2", "s3", "final"},
+	}
+
+	mapPrompt := "MAP: " + AISummarizerTextPlaceholder
+	reducePrompt := "REDUCE"
+
+	var phases []string
+	summary, err := (&AppContext{}).NewAISummarizer(chat).SummarizeChunks(
+		[]string{"chunk 1", " ", "chunk 2", "chunk 3"},
+		AISummarizeOptions{
+			MapPrompt: &mapPrompt,
+			OnProgress: func(phase string, i, n int) {
+				phases = append(phases, phase)
+			},
+			ReducePrompt: &reducePrompt,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary != "final" {
+		t.Errorf("expected 'final', got '%v'", summary)
+	}
+
+	expectedRequests := []string{
+		"MAP: chunk 1",
+		"MAP: chunk 2",
+		"MAP: chunk 3",
+		"REDUCE\n\ns1\n\ns2\n\ns3", // prompt without placeholder
+	}
+	if strings.Join(chat.Requests, "|") != strings.Join(expectedRequests, "|") {
+		t.Errorf("expected requests %q, got %q", expectedRequests, chat.Requests)
+	}
+
+	if strings.Join(phases, ",") != "map,map,map,reduce" {
+		t.Errorf("unexpected phases %v", phases)
+	}
+}
+
+func TestAISummarizerEmptyChunks(t *testing.T) {
+	chat := &MockAIChat{}
+
+	summary, err := (&AppContext{}).NewAISummarizer(chat).SummarizeChunks([]string{"", "  \n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary != "" || len(chat.Requests) != 0 {
+		t.Errorf("expected no summary and no requests, got '%v' and %v", summary, len(chat.Requests))
+	}
+}
+
+func TestAISummarizerReturnsChatErrors(t *testing.T) {
+	chat := &MockAIChat{
+		Responses: []string{"s1"},
+	}
+
+	_, err := (&AppContext{}).NewAISummarizer(chat).SummarizeChunks([]string{"chunk 1", "chunk 2"})
+	if err == nil {
+		t.Error("expected error if chat fails")
+	}
+}
+
+func TestAISummarizerMaxRounds(t *testing.T) {
+	responses := []string{}
+	for i := 0; i < 100; i++ {
+		responses = append(responses, strings.Repeat("word ", 20))
+	}
+
+	chat := &MockAIChat{
+		Responses: responses,
+	}
+
+	maxChunkSize := 150
+	maxRounds := 1
+	_, err := (&AppContext{}).NewAISummarizer(chat).SummarizeChunks(
+		[]string{"chunk 1", "chunk 2"},
+		AISummarizeOptions{
+			MaxChunkSize: &maxChunkSize,
+			MaxRounds:    &maxRounds,
+		},
+	)
+	if err == nil || !strings.Contains(err.Error(), "reduce rounds") {
+		t.Errorf("expected error about reduce rounds, got %v", err)
+	}
+}
@@ -24,21 +24,30 @@ package types
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path"
+	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/briandowns/spinner"
 	"github.com/goccy/go-yaml"
 	"github.com/hashicorp/go-version"
 	"github.com/joho/godotenv"
 	"github.com/mkloubert/go-package-manager/utils"
+	"github.com/schollz/progressbar/v3"
 
 	constants "github.com/mkloubert/go-package-manager/constants"
 )
@@ -49,28 +58,53 @@ type AIPrompts struct {
 	SystemPrompt *string // the system prompt, if defined
 }
 
+// AIChatFactoryFunc is a function, which creates a new ChatAI instance
+type AIChatFactoryFunc func(options ...CreateAIChatOptions) (ChatAI, error)
+
 // An AppContext contains all information for running this app
 type AppContext struct {
-	AliasesFile      AliasesFile  // aliases.yaml file in home folder
-	AliasesFilePath  string       // custom file path of the `aliases.yaml` file from CLI flags
-	Cwd              string       // current working directory
-	EnvFiles         []string     // one or more env files
-	Environment      string       // the name of the environment
-	ErrorOut         io.Writer    // error output
-	GpmFile          GpmFile      // the gpm.y(a)ml file
-	GpmRootPath      string       // custom app root path from CLI flags
-	In               io.Reader    // the input stream
-	IsCI             bool         // indicates if app runs in CI environment like GitHub action or GitLab runner
-	L                *log.Logger  // the logger to use
-	Model            string       // custom model from CLI flags
-	NoSystemPrompt   bool         // do not use system prompt
-	Ollama           bool         // use Ollama
-	Out              io.Writer    // the output stream
-	ProjectsFile     ProjectsFile // projects.yaml file in home folder
-	ProjectsFilePath string       // custom file path of the `projects.yaml` file from CLI flags
-	Prompt           string       // custom (AI) prompt
-	SystemPrompt     string       // custom system prompt
-	Verbose          bool         // output verbose information
+	AIChatFactory    AIChatFactoryFunc     // custom factory for AI chats, which overrides the default provider selection, if defined
+	AILogFile        string                // custom file for logging AI requests and responses from CLI flags
+	AliasesFile      AliasesFile           // aliases.yaml file in home folder
+	AliasesFilePath  string                // custom file path of the `aliases.yaml` file from CLI flags
+	Clock            func() time.Time      // custom clock, which is used by `Now()` instead of `time.Now()`
+	CommandPath      string                // the path of the current command, like `gpm run`
+	Cwd              string                // current working directory
+	DownloadOptions  utils.DownloadOptions // custom options for downloads from CLI flags
+	DryRun           bool                  // only output commands instead of executing them
+	EnvFiles         []string              // one or more env files
+	Environment      string                // the name of the environment
+	ErrorOut         io.Writer             // error output
+	GpmFile          GpmFile               // the gpm.y(a)ml file
+	GpmRootPath      string                // custom app root path from CLI flags
+	IgnoreBudget     bool                  // do not check the AI budget from settings file
+	In               io.Reader             // the input stream
+	IsCI             bool                  // indicates if app runs in CI environment like GitHub action or GitLab runner
+	L                *log.Logger           // the logger to use
+	LogFormat        string                // custom log format from CLI flags, like `text` or `json`
+	jsonLogger       *slog.Logger          // the logger for `json` log format
+	Model            string                // custom model from CLI flags
+	NoSystemPrompt   bool                  // do not use system prompt
+	Ollama           bool                  // use Ollama
+	Out              io.Writer             // the output stream
+	ProjectsFile     ProjectsFile          // projects.yaml file in home folder
+	ProjectsFilePath string                // custom file path of the `projects.yaml` file from CLI flags
+	Quiet            bool                  // suppress non-essential output like spinners and progress bars
+	Prompt           string                // custom (AI) prompt
+	SettingsFile     SettingsFile          // settings.yaml file in home folder
+	StartTime        time.Time             // the time the app has been started
+	SystemPrompt     string                // custom system prompt
+	TemplatesFile    TemplatesFile         // templates.yaml file in home folder
+	Timings          bool                  // output durations of major phases at the end
+	timings          []AppTiming           // recorded timings
+	Verbose          bool                  // output verbose information
+}
+
+// AppTiming stores the duration of a major phase of a command
+type AppTiming struct {
+	Duration  time.Duration // the duration
+	Name      string        // the name of the phase
+	startTime time.Time     // start time, as long as phase is running
 }
 
 // ChatWithAIOption stores settings for
@@ -84,9 +118,11 @@ type ChatWithAIOption struct {
 // CreateAIChatOptions stores settings for
 // `CreateAIChat()` method
 type CreateAIChatOptions struct {
-	Model        *string // custom model
-	SystemPrompt *string // custom system prompt
-	Temperature  *int    // custom temperature
+	MaxContextMessages *int    // maximum number of non-system messages to send
+	MaxContextTokens   *int    // maximum number of estimated tokens to send
+	Model              *string // custom model
+	SystemPrompt       *string // custom system prompt
+	Temperature        *int    // custom temperature
 }
 
 // OllamaGenerateResponse is the response of
@@ -104,6 +140,12 @@ type TidyUpOptions struct {
 
 // ChatWithAI() - does a simple AI chat based on the current app settings
 func (app *AppContext) ChatWithAI(prompt string, options ...ChatWithAIOption) (string, error) {
+	if app.AIChatFactory != nil {
+		app.Debug("Using custom AI chat factory ...")
+
+		return app.chatWithAIChat(prompt, options...)
+	}
+
 	settings, err := app.GetAIChatSettings()
 	if err != nil {
 		return "", err
@@ -125,13 +167,43 @@ func (app *AppContext) ChatWithAI(prompt string, options ...ChatWithAIOption) (s
 		return app.chatWithOllama(prompt, options...)
 	}
 
+	if settings.Provider == constants.AIApiMock {
+		app.Debug("Using mock API ...")
+
+		return app.chatWithAIChat(prompt, options...)
+	}
+
 	return "", fmt.Errorf("no implementation for ai api '%v'", settings.Provider)
 }
 
+func (app *AppContext) chatWithAIChat(prompt string, options ...ChatWithAIOption) (string, error) {
+	chatOptions := make([]CreateAIChatOptions, 0, len(options))
+	for _, o := range options {
+		chatOptions = append(chatOptions, CreateAIChatOptions{
+			Model:        o.Model,
+			SystemPrompt: o.SystemPrompt,
+			Temperature:  o.Temperature,
+		})
+	}
+
+	api, err := app.CreateAIChat(chatOptions...)
+	if err != nil {
+		return "", err
+	}
+
+	answer := ""
+	err = api.SendPrompt(prompt, func(messageChunk string) error {
+		answer += messageChunk
+		return nil
+	})
+
+	return answer, err
+}
+
 func (app *AppContext) chatWithOllama(prompt string, options ...ChatWithAIOption) (string, error) {
 	model := strings.TrimSpace(app.Model)
 	if model == "" {
-		model = utils.GetDefaultAIChatModel() // no explicit => take default
+		model = app.GetDefaultAIChatModel(constants.AIApiOllama) // no explicit => take default
 	}
 	if model == "" {
 		return "", fmt.Errorf("no ai model defined")
@@ -178,7 +250,7 @@ func (app *AppContext) chatWithOllama(prompt string, options ...ChatWithAIOption
 
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := app.GetAIHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -210,7 +282,7 @@ func (app *AppContext) chatWithOpenAI(prompt string, settings AIChatSettings, op
 
 	model := strings.TrimSpace(app.Model)
 	if model == "" {
-		model = utils.GetDefaultAIChatModel()
+		model = app.GetDefaultAIChatModel(constants.AIApiOpenAI)
 	}
 
 	for _, o := range options {
@@ -262,7 +334,7 @@ func (app *AppContext) chatWithOpenAI(prompt string, settings AIChatSettings, op
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+apiKey)
 
-	client := &http.Client{}
+	client := app.GetAIHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return "", err
@@ -294,8 +366,67 @@ func (app *AppContext) chatWithOpenAI(prompt string, settings AIChatSettings, op
 	return answer, nil
 }
 
+// app.CheckAIBudget() - returns an error if the usage, which is logged in
+// the AI log file, exceeds the budget of the settings file
+func (app *AppContext) CheckAIBudget() error {
+	if app.IgnoreBudget || !app.SettingsFile.HasAIBudget() {
+		return nil
+	}
+
+	logFile := app.GetAILogFile()
+	if logFile == "" {
+		return nil
+	}
+
+	file, err := os.Open(logFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // nothing used yet
+		}
+		return err
+	}
+	defer file.Close()
+
+	entries, err := LoadAILogEntries(file)
+	if err != nil {
+		return err
+	}
+
+	return app.SettingsFile.CheckAIBudget(entries, app.Now())
+}
+
+// app.CloneGitRepository() - does a shallow clone of a git repository
+// into a target directory
+func (app *AppContext) CloneGitRepository(gitResource string, targetDir string) {
+	app.Debug(fmt.Sprintf("Cloning '%v' to '%v' ...", gitResource, targetDir))
+	app.RunShellCommandByArgs("git", "clone", "--depth", "1", gitResource, targetDir)
+}
+
+// app.CloneGitRepositoryAt() - does a shallow clone of a specific ref,
+// like a branch, tag or full commit hash, of a git repository
+// into a target directory
+func (app *AppContext) CloneGitRepositoryAt(gitResource string, targetDir string, ref string) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		app.CloneGitRepository(gitResource, targetDir)
+		return
+	}
+
+	app.Debug(fmt.Sprintf("Cloning '%v' at '%v' to '%v' ...", gitResource, ref, targetDir))
+
+	// fetching a ref explicitly works for branches, tags and commits
+	app.RunShellCommandByArgs("git", "init", "-q", targetDir)
+	app.RunShellCommandByArgs("git", "-C", targetDir, "remote", "add", "origin", gitResource)
+	app.RunShellCommandByArgs("git", "-C", targetDir, "fetch", "--depth", "1", "origin", ref)
+	app.RunShellCommandByArgs("git", "-C", targetDir, "checkout", "-q", "FETCH_HEAD")
+}
+
 // app.CreateAIChat() - creates a new ChatAI instance based on the current settings
 func (app *AppContext) CreateAIChat(options ...CreateAIChatOptions) (ChatAI, error) {
+	if app.AIChatFactory != nil {
+		return app.AIChatFactory(options...) // custom provider construction
+	}
+
 	settings, err := app.GetAIChatSettings()
 	if err != nil {
 		return nil, err
@@ -303,8 +434,16 @@ func (app *AppContext) CreateAIChat(options ...CreateAIChatOptions) (ChatAI, err
 
 	initialModel := strings.TrimSpace(app.Model)
 	systemPrompt := ""
+	maxContextMessages := 0
+	maxContextTokens := 0
 
 	for _, o := range options {
+		if o.MaxContextMessages != nil {
+			maxContextMessages = *o.MaxContextMessages
+		}
+		if o.MaxContextTokens != nil {
+			maxContextTokens = *o.MaxContextTokens
+		}
 		if o.Model != nil {
 			initialModel = strings.TrimSpace(*o.Model)
 		}
@@ -314,13 +453,16 @@ func (app *AppContext) CreateAIChat(options ...CreateAIChatOptions) (ChatAI, err
 	}
 
 	if initialModel == "" {
-		initialModel = utils.GetDefaultAIChatModel()
+		initialModel = app.GetDefaultAIChatModel(settings.Provider)
 	}
 
 	var api ChatAI = &OllamaAIChat{}
 	if settings.Provider == constants.AIApiOllama {
 		ollama := OllamaAIChat{
-			Verbose: app.Verbose,
+			HttpClient:         app.GetAIHttpClient(),
+			MaxContextMessages: maxContextMessages,
+			MaxContextTokens:   maxContextTokens,
+			Verbose:            app.Verbose,
 		}
 
 		if initialModel == "" {
@@ -330,7 +472,10 @@ func (app *AppContext) CreateAIChat(options ...CreateAIChatOptions) (ChatAI, err
 		api = &ollama
 	} else if settings.Provider == constants.AIApiOpenAI {
 		openai := OpenAIChat{
-			Verbose: app.Verbose,
+			HttpClient:         app.GetAIHttpClient(),
+			MaxContextMessages: maxContextMessages,
+			MaxContextTokens:   maxContextTokens,
+			Verbose:            app.Verbose,
 		}
 
 		if initialModel == "" {
@@ -341,6 +486,30 @@ func (app *AppContext) CreateAIChat(options ...CreateAIChatOptions) (ChatAI, err
 		}
 
 		api = &openai
+	} else if settings.Provider == constants.AIApiMock {
+		mock := MockAIChat{
+			Responses: []string{},
+		}
+
+		// scripted responses from a YAML or JSON file with a list of strings
+		GPM_AI_MOCK_RESPONSES := strings.TrimSpace(os.Getenv("GPM_AI_MOCK_RESPONSES"))
+		if GPM_AI_MOCK_RESPONSES != "" {
+			responsesData, err := os.ReadFile(app.GetFullPathOrDefault(GPM_AI_MOCK_RESPONSES, ""))
+			if err != nil {
+				return nil, err
+			}
+
+			err = yaml.Unmarshal(responsesData, &mock.Responses)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if initialModel == "" {
+			initialModel = "mock"
+		}
+
+		api = &mock
 	}
 
 	if api != nil {
@@ -357,10 +526,21 @@ func (app *AppContext) CreateAIChat(options ...CreateAIChatOptions) (ChatAI, err
 	return nil, fmt.Errorf("'%v' ai chat provider not implemented", settings.Provider)
 }
 
+// app.CreateCurrentProjectCommand() - creates a new, not started command
+// which runs the current go project
+func (app *AppContext) CreateCurrentProjectCommand(additionalArgs ...string) *exec.Cmd {
+	args := append([]string{"run", "."}, additionalArgs...)
+
+	p := utils.CreateShellCommandByArgs("go", args...)
+	p.Dir = app.Cwd
+
+	return p
+}
+
 // app.Debug() - writes debug information with the underlying logger
 func (app *AppContext) Debug(v ...any) *AppContext {
 	if app.Verbose {
-		app.L.Printf("[VERBOSE] %v", fmt.Sprintln(v...))
+		app.log(slog.LevelDebug, "VERBOSE", v...)
 	}
 
 	return app
@@ -408,6 +588,140 @@ func (app *AppContext) EnsureRootFolder() (string, error) {
 	return "", err
 }
 
+// app.ExpandScriptVariables() - replaces `${...}` placeholders in a script command
+// and returns the new command and `true` if positional arguments have been used
+//
+// Variables are resolved in the following order:
+// 1. `${name}`, `${environment}` and `${version}` (latest version from Git tags)
+// 2. positional arguments like `${1}`, `${2}` or `${@}` for all
+//
+// All other placeholders, like environment variables, are kept as they are,
+// so the shell can handle them. Values are not evaluated again by gpm and
+// are quoted for the shell of the current OS.
+func (app *AppContext) ExpandScriptVariables(cmd string, args []string) (string, bool) {
+	usedArgs := false
+
+	// resolved only once and only if needed, because it runs git
+	var version *string
+	getVersion := func() string {
+		if version == nil {
+			v := "0.0.0"
+
+			latestVersion, err := app.NewVersionManager().GetLatestVersion()
+			if err == nil && latestVersion != nil {
+				v = latestVersion.String()
+			}
+
+			version = &v
+		}
+
+		return *version
+	}
+
+	rx := regexp.MustCompile(`\$\{([^{}]+)\}`)
+
+	expandedCmd := rx.ReplaceAllStringFunc(cmd, func(placeholder string) string {
+		varName := strings.TrimSpace(placeholder[2 : len(placeholder)-1])
+
+		switch varName {
+		case "name":
+			return utils.QuoteShellArg(app.GetName())
+		case "environment":
+			return utils.QuoteShellArg(app.GetEnvironment())
+		case "version":
+			return utils.QuoteShellArg(getVersion())
+		case "@":
+			usedArgs = true
+
+			quotedArgs := []string{}
+			for _, a := range args {
+				quotedArgs = append(quotedArgs, utils.QuoteShellArg(a))
+			}
+			return strings.Join(quotedArgs, " ")
+		}
+
+		argNr, err := strconv.Atoi(varName)
+		if err == nil && argNr > 0 {
+			usedArgs = true
+
+			if argNr <= len(args) {
+				return utils.QuoteShellArg(args[argNr-1])
+			}
+			return ""
+		}
+
+		return placeholder
+	})
+
+	return expandedCmd, usedArgs
+}
+
+// app.GetAIHttpClient() - returns a new HTTP client for AI APIs, which
+// logs requests and responses to a JSONL file if `--ai-log` flag or
+// `GPM_AI_LOG` environment variable or an AI budget is defined, the app
+// exits, if the AI budget is exceeded and `--ignore-budget` is not set
+func (app *AppContext) GetAIHttpClient() *http.Client {
+	logFile := app.GetAILogFile()
+	if logFile == "" {
+		return &http.Client{}
+	}
+
+	var checkBudget func() error
+	if !app.IgnoreBudget && app.SettingsFile.HasAIBudget() {
+		checkBudget = func() error {
+			err := app.CheckAIBudget()
+			if err != nil {
+				utils.CloseWithError(err)
+			}
+
+			return err
+		}
+	}
+
+	return &http.Client{
+		Transport: &AILogTransport{
+			CheckBudget: checkBudget,
+			Command:     app.CommandPath,
+			File:        logFile,
+			Next:        http.DefaultTransport,
+		},
+	}
+}
+
+// app.GetAILogFile() - returns the full path of the AI log file from
+// `--ai-log` flag or `GPM_AI_LOG` environment variable, `ai.log.jsonl`
+// inside app's root directory if an AI budget is defined or an empty
+// string if nothing is defined
+func (app *AppContext) GetAILogFile() string {
+	logFile := strings.TrimSpace(app.AILogFile)
+	if logFile == "" {
+		logFile = strings.TrimSpace(os.Getenv("GPM_AI_LOG"))
+	}
+	if logFile == "" {
+		if !app.SettingsFile.HasAIBudget() {
+			return ""
+		}
+
+		// usage has to be tracked for the budget
+		rootDir, err := app.GetRootPath()
+		if err != nil {
+			return ""
+		}
+
+		return path.Join(rootDir, "ai.log.jsonl")
+	}
+
+	return app.GetFullPathOrDefault(logFile, "")
+}
+
+// app.GetAIChatTemperature() - returns the value for AI chat temperature
+// from `GPM_AI_CHAT_TEMPERATURE`, settings file or `defaultValue`
+func (app *AppContext) GetAIChatTemperature(defaultValue float32) float32 {
+	return utils.GetAIChatTemperature(
+		app.SettingsFile.GetAITemperature(defaultValue),
+	)
+}
+
 // app.GetAIChatSettings() - returns AI chat settings based on this app
 func (app *AppContext) GetAIChatSettings() (AIChatSettings, error) {
 	var settings AIChatSettings
@@ -437,7 +751,7 @@ func (app *AppContext) GetAIChatSettings() (AIChatSettings, error) {
 			settings.ApiKey = &OPENAI_API_KEY
 		}
 		settings.Provider = GPM_AI_API
-	case constants.AIApiOllama:
+	case constants.AIApiMock, constants.AIApiOllama:
 		settings.Provider = GPM_AI_API
 	default:
 		err = fmt.Errorf("ai api '%v' is not supported", GPM_AI_API)
@@ -476,6 +790,17 @@ func (app *AppContext) GetAIPromptSettings(defaultPrompt string, defaultSystemPr
 	}
 }
 
+// app.GetAliasNames() - returns the sorted names of all aliases
+func (app *AppContext) GetAliasNames() []string {
+	names := make([]string, 0, len(app.AliasesFile.Aliases))
+	for name := range app.AliasesFile.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // app.GetAliasesFilePath() - returns the possible path of the aliases.yaml file
 func (app *AppContext) GetAliasesFilePath() (string, error) {
 	// first try from cli flag
@@ -612,6 +937,33 @@ func (app *AppContext) GetCurrentGitBranch() (string, error) {
 	return strings.TrimSpace(output.String()), nil
 }
 
+// app.GetDownloadOptions() - returns the options for downloads from web resources
+// with `GPM_DOWNLOAD_TOKEN` environment variable as fallback for the bearer token;
+// progress output is always disabled in quiet mode
+func (app *AppContext) GetDownloadOptions() utils.DownloadOptions {
+	options := app.DownloadOptions
+
+	if strings.TrimSpace(options.Token) == "" {
+		options.Token = strings.TrimSpace(os.Getenv("GPM_DOWNLOAD_TOKEN"))
+	}
+	if app.Quiet {
+		options.Progress = false
+	}
+
+	return options
+}
+
+// app.GetDefaultAIChatModel() - returns the name of the default AI chat model
+// from `GPM_AI_CHAT_MODEL` or settings file for a specific provider
+func (app *AppContext) GetDefaultAIChatModel(provider string) string {
+	model := utils.GetDefaultAIChatModel()
+	if model == "" {
+		model = app.SettingsFile.GetAIModel(provider)
+	}
+
+	return model
+}
+
 // app.GetEnvFilePaths() - returns possible paths of .env* files
 func (app *AppContext) GetEnvFilePaths() ([]string, error) {
 	rootDir, err := app.GetRootPath()
@@ -658,6 +1010,39 @@ func (app *AppContext) GetFullPathOrDefault(p string, d string) string {
 	return path.Join(app.Cwd, p)
 }
 
+// app.GetGitAheadBehind() - returns the number of commits the current branch
+// is ahead and behind its upstream branch
+func (app *AppContext) GetGitAheadBehind() (int, int, error) {
+	p := exec.Command("git", "rev-list", "--left-right", "--count", "HEAD...@{upstream}")
+	p.Dir = app.Cwd
+
+	var output bytes.Buffer
+	p.Stdout = &output
+
+	err := p.Run()
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not compare with upstream: %v", err)
+	}
+	defer output.Reset()
+
+	fields := strings.Fields(output.String())
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected output of 'git rev-list': %v", output.String())
+	}
+
+	ahead, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	behind, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return ahead, behind, nil
+}
+
 // app.GetGitBranches() - returns the list of branches using git command
 func (app *AppContext) GetGitBranches() ([]string, error) {
 	p := exec.Command("git", "branch", "-a")
@@ -692,6 +1077,77 @@ func (app *AppContext) GetGitBranches() ([]string, error) {
 	return branchNames, nil
 }
 
+// app.GetGitBranchesSafe() - returns the names of all local and remote branches
+// or an empty list on error, which is useful for shell completion
+func (app *AppContext) GetGitBranchesSafe() []string {
+	branches, err := app.GetGitBranches()
+	if err != nil {
+		return []string{}
+	}
+
+	names := []string{}
+	for _, b := range branches {
+		if strings.Contains(b, " -> ") {
+			continue // symbolic refs like `remotes/origin/HEAD -> origin/main`
+		}
+
+		names = append(names, b)
+	}
+
+	return names
+}
+
+// app.GetGitCommitHash() - returns the hash of the current commit using git command
+func (app *AppContext) GetGitCommitHash(short bool) (string, error) {
+	args := []string{"rev-parse"}
+	if short {
+		args = append(args, "--short")
+	}
+	args = append(args, "HEAD")
+
+	p := exec.Command("git", args...)
+	p.Dir = app.Cwd
+
+	var output bytes.Buffer
+	p.Stdout = &output
+
+	err := p.Run()
+	if err != nil {
+		return "", fmt.Errorf("could not get commit hash of '%v', maybe no git repository: %v", app.Cwd, err)
+	}
+	defer output.Reset()
+
+	return strings.TrimSpace(output.String()), nil
+}
+
+// app.GetGitDirty() - returns `true` if the working tree has uncommitted
+// or untracked files
+func (app *AppContext) GetGitDirty() (bool, error) {
+	status, err := app.GetGitStatus()
+	if err != nil {
+		return false, fmt.Errorf("could not get status of '%v', maybe no git repository: %v", app.Cwd, err)
+	}
+
+	return !status.IsClean(), nil
+}
+
+// app.GetGitRemoteUrl() - returns the URL of a remote using git command
+func (app *AppContext) GetGitRemoteUrl(remote string) (string, error) {
+	p := exec.Command("git", "remote", "get-url", remote)
+	p.Dir = app.Cwd
+
+	var output bytes.Buffer
+	p.Stdout = &output
+
+	err := p.Run()
+	if err != nil {
+		return "", fmt.Errorf("could not get URL of remote '%v': %v", remote, err)
+	}
+	defer output.Reset()
+
+	return strings.TrimSpace(output.String()), nil
+}
+
 // app.GetGitRemotes() - returns the list of remotes using git command
 func (app *AppContext) GetGitRemotes() ([]string, error) {
 	p := exec.Command("git", "remote")
@@ -723,6 +1179,23 @@ func (app *AppContext) GetGitRemotes() ([]string, error) {
 	return remotes, nil
 }
 
+// app.GetGitStatus() - returns the status of the working tree using git command
+func (app *AppContext) GetGitStatus() (*GitStatus, error) {
+	p := exec.Command("git", "status", "--porcelain")
+	p.Dir = app.Cwd
+
+	var output bytes.Buffer
+	p.Stdout = &output
+
+	err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+	defer output.Reset()
+
+	return ParseGitStatus(output.String()), nil
+}
+
 // app.GetGitTags() - returns the list of tags using git command
 func (app *AppContext) GetGitTags() ([]string, error) {
 	p := exec.Command("git", "tag")
@@ -744,6 +1217,20 @@ func (app *AppContext) GetGitTags() ([]string, error) {
 	return tags, nil
 }
 
+// app.GetGoModuleGraph() - returns the parsed output of `go mod graph` of current project
+func (app *AppContext) GetGoModuleGraph() (*GoModuleGraph, error) {
+	p := exec.Command("go", "mod", "graph")
+	p.Dir = app.Cwd
+
+	app.Debug("Running 'go mod graph' ...")
+	output, err := p.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGoModuleGraph(string(output))
+}
+
 // app.GetGoModules() - returns the list of installed Go modules of current project
 func (app *AppContext) GetGoModules() ([]GoModule, error) {
 	modules := []GoModule{}
@@ -781,6 +1268,22 @@ func (app *AppContext) GetGpmFilesSection() []string {
 	return app.GpmFile.GetFilesSectionByEnvSafe(app.GetEnvironment())
 }
 
+// app.GetLogFormat() - returns the log format, which is `json` or `text`
+func (app *AppContext) GetLogFormat() string {
+	logFormat := strings.TrimSpace(strings.ToLower(app.LogFormat)) // first from command line arguments
+	if logFormat == "" {
+		logFormat = strings.TrimSpace(strings.ToLower(os.Getenv("GPM_LOG_FORMAT"))) // now from environment variable
+	}
+	if logFormat == "" && app.IsCI {
+		logFormat = "json" // easier to ingest in CI environments
+	}
+
+	if logFormat == "json" {
+		return logFormat
+	}
+	return "text"
+}
+
 // app.GetModuleUrls() - returns the list of module urls based on the
 // information from aliases.y(a)ml file if possible
 func (app *AppContext) GetModuleUrls(moduleNameOrUrl string) []string {
@@ -818,6 +1321,17 @@ func (app *AppContext) GetName() string {
 	return name
 }
 
+// app.GetProjectNames() - returns the sorted names of all projects
+func (app *AppContext) GetProjectNames() []string {
+	names := make([]string, 0, len(app.ProjectsFile.Projects))
+	for name := range app.ProjectsFile.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // app.GetProjectsFilePath() - returns the possible path of the projects.yaml file
 func (app *AppContext) GetProjectsFilePath() (string, error) {
 	// first try from cli flag
@@ -886,6 +1400,28 @@ func (app *AppContext) GetRootPath() (string, error) {
 	return "", err
 }
 
+// app.GetSettingsFilePath() - returns the possible path of the settings.yaml file
+func (app *AppContext) GetSettingsFilePath() (string, error) {
+	// first try from environment variable
+	customFile := strings.TrimSpace(
+		os.Getenv("GPM_SETTINGS_FILE"),
+	)
+	if customFile != "" && path.IsAbs(customFile) {
+		return customFile, nil
+	}
+
+	// now try from <GPM-ROOT> ...
+
+	rootDir, err := app.GetRootPath()
+	if err == nil {
+		if customFile != "" {
+			return path.Join(rootDir, customFile), nil
+		}
+		return path.Join(rootDir, "settings.yaml"), nil
+	}
+	return "", err
+}
+
 // app.GetSystemAIPrompt() - returns the AI system prompt based on the current app settings
 func (app *AppContext) GetSystemAIPrompt(defaultPrompt string) string {
 	prompt := app.SystemPrompt // first from command line arguments
@@ -901,8 +1437,45 @@ func (app *AppContext) GetSystemAIPrompt(defaultPrompt string) string {
 	return prompt
 }
 
+// app.GetTemplatesFilePath() - returns the possible path of the templates.yaml file
+func (app *AppContext) GetTemplatesFilePath() (string, error) {
+	// first try from environment variable
+	customFile := strings.TrimSpace(
+		os.Getenv("GPM_TEMPLATES_FILE"),
+	)
+	if customFile != "" && path.IsAbs(customFile) {
+		return customFile, nil
+	}
+
+	// now try from <GPM-ROOT> ...
+
+	rootDir, err := app.GetRootPath()
+	if err == nil {
+		if customFile != "" {
+			return path.Join(rootDir, customFile), nil
+		}
+		return path.Join(rootDir, "templates.yaml"), nil
+	}
+	return "", err
+}
+
+// app.HasScript() - checks if there is a script for `scriptName`,
+// including environment and operating system specific variants
+func (app *AppContext) HasScript(scriptName string) bool {
+	_, ok := app.GpmFile.Scripts[app.resolveScriptName(scriptName)]
+	return ok
+}
+
+// app.Info() - writes information with the underlying logger
+func (app *AppContext) Info(v ...any) *AppContext {
+	app.log(slog.LevelInfo, "INFO", v...)
+
+	return app
+}
+
 // app.ListFiles() - Lists all files inside the current working directory
-// based of the patterns from "files" section of gpm.yaml file.
+// based of the patterns from "files" section of gpm.yaml file, which are
+// regular expressions or globs with `glob:` prefix.
 func (app *AppContext) ListFiles() ([]string, error) {
 	gpmFiles := app.GetGpmFilesSection()
 
@@ -926,7 +1499,13 @@ func (app *AppContext) ListFiles() ([]string, error) {
 	matchingFiles := map[string]bool{}
 
 	for _, p := range patterns {
-		filesByPattern, err := utils.ListFiles(app.Cwd, p)
+		var filesByPattern []string
+		var err error
+		if strings.HasPrefix(p, utils.GlobPatternPrefix) {
+			filesByPattern, err = utils.ListFilesByGlob(app.Cwd, strings.TrimPrefix(p, utils.GlobPatternPrefix))
+		} else {
+			filesByPattern, err = utils.ListFiles(app.Cwd, p)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -943,6 +1522,30 @@ func (app *AppContext) ListFiles() ([]string, error) {
 	return files, nil
 }
 
+func (app *AppContext) log(level slog.Level, textPrefix string, v ...any) {
+	if app.GetLogFormat() == "json" {
+		if app.jsonLogger == nil {
+			var w io.Writer = app.ErrorOut
+			if w == nil {
+				w = os.Stderr
+			}
+
+			app.jsonLogger = slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+				Level: slog.LevelDebug,
+			}))
+		}
+
+		app.jsonLogger.Log(
+			context.Background(), level,
+			strings.TrimSpace(fmt.Sprintln(v...)),
+			slog.String("command", app.CommandPath),
+		)
+		return
+	}
+
+	app.L.Printf("[%v] %v", textPrefix, fmt.Sprintln(v...))
+}
+
 // app.LoadAliasesFileIfExist - Loads a gpm.y(a)ml file if it exists
 // and return `true` if file has been loaded successfully.
 func (app *AppContext) LoadAliasesFileIfExist() bool {
@@ -976,24 +1579,58 @@ func (app *AppContext) LoadAliasesFileIfExist() bool {
 }
 
 // app.LoadDataFrom() - loads binary data from a source like
-// local file system or web URL
+// local file system, `file://` URL, web URL or `-` for STDIN
+// and verifies it, if a checksum is defined in download options
 func (app *AppContext) LoadDataFrom(source string) ([]byte, error) {
 	source = strings.TrimSpace(source)
 
-	if strings.HasPrefix(source, "https:") || strings.HasPrefix(source, "http:") {
+	downloadOptions := app.GetDownloadOptions()
+
+	// checks optional checksum of data, which has not been downloaded
+	verifyData := func(data []byte, err error) ([]byte, error) {
+		if err == nil && strings.TrimSpace(downloadOptions.Checksum) != "" {
+			err = utils.VerifySHA256(data, downloadOptions.Checksum)
+		}
+
+		return data, err
+	}
+
+	if source == "-" {
+		// from STDIN
+		app.Debug("Loading data from STDIN ...")
+		return verifyData(io.ReadAll(app.In))
+	} else if strings.HasPrefix(source, "https:") || strings.HasPrefix(source, "http:") {
 		// from web
 		app.Debug(fmt.Sprintf("Loading data from web resource '%v' ...", source))
-		return utils.DownloadFromUrl(source)
+		return utils.DownloadFromUrl(source, downloadOptions)
 	} else {
 		// local file system
 
 		filePath := source
+		if strings.HasPrefix(strings.ToLower(source), "file:") {
+			fileUrl, err := url.Parse(source)
+			if err != nil {
+				return nil, err
+			}
+			if fileUrl.Host != "" && fileUrl.Host != "localhost" {
+				return nil, fmt.Errorf("remote host '%v' is not supported in file URL '%v'", fileUrl.Host, source)
+			}
+
+			filePath = fileUrl.Path
+			if fileUrl.Opaque != "" {
+				filePath = fileUrl.Opaque // relative path like `file:foo.txt`
+			}
+			if utils.IsWindows() && regexp.MustCompile(`^/[a-zA-Z]:`).MatchString(filePath) {
+				filePath = filePath[1:] // `/C:/path` => `C:/path`
+			}
+		}
+
 		if !path.IsAbs(filePath) {
 			filePath = path.Join(app.Cwd, filePath)
 		}
 
 		app.Debug(fmt.Sprintf("Loading data from local resource '%v' ...", filePath))
-		return os.ReadFile(filePath)
+		return verifyData(os.ReadFile(filePath))
 	}
 }
 
@@ -1043,6 +1680,16 @@ func (app *AppContext) LoadGpmFileIfExist() bool {
 	gpm, err := LoadGpmFile(gpmFilePath)
 	utils.CheckForError(err)
 
+	GPM_SKIP_VALIDATION := strings.TrimSpace(strings.ToLower(os.Getenv("GPM_SKIP_VALIDATION")))
+	if GPM_SKIP_VALIDATION != "1" && GPM_SKIP_VALIDATION != "true" && GPM_SKIP_VALIDATION != "yes" {
+		yamlData, err := os.ReadFile(gpmFilePath)
+		if err == nil {
+			for _, issue := range ValidateGpmFile(yamlData) {
+				app.Warn(fmt.Sprintf("%v: %v", path.Base(gpmFilePath), issue.String()))
+			}
+		}
+	}
+
 	app.GpmFile = gpm
 
 	return true
@@ -1080,6 +1727,189 @@ func (app *AppContext) LoadProjectsFileIfExist() bool {
 	return true
 }
 
+// app.LoadSettingsFileIfExist() - Loads a settings.yaml file if it exists
+// and return `true` if file has been loaded successfully.
+func (app *AppContext) LoadSettingsFileIfExist() bool {
+	settingsFilePath, err := app.GetSettingsFilePath()
+	utils.CheckForError(err)
+
+	isExisting, err := utils.IsFileExisting(settingsFilePath)
+	utils.CheckForError(err)
+
+	if !isExisting {
+		return false
+	}
+
+	app.Debug(fmt.Sprintf("Loading '%v' file ...", settingsFilePath))
+
+	yamlData, err := os.ReadFile(settingsFilePath)
+	utils.CheckForError(err)
+
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal(yamlData, &values)
+	utils.CheckForError(err)
+
+	var settings SettingsFile
+	err = yaml.Unmarshal(yamlData, &settings)
+	if err != nil {
+		// do not exit, so invalid values can still be fixed
+		// with `config set` or `config unset`
+		app.Warn(fmt.Sprintf("%v: %v", path.Base(settingsFilePath), err))
+
+		settings = SettingsFile{}
+	}
+
+	settings.values = values
+
+	app.SettingsFile = settings
+	return true
+}
+
+// app.LoadTemplatesFileIfExist() - Loads a templates.yaml file if it exists
+// and return `true` if file has been loaded successfully.
+func (app *AppContext) LoadTemplatesFileIfExist() bool {
+	defer func() {
+		if app.TemplatesFile.Templates == nil {
+			app.TemplatesFile.Templates = map[string]string{}
+		}
+	}()
+
+	templatesFilePath, err := app.GetTemplatesFilePath()
+	utils.CheckForError(err)
+
+	isExisting, err := utils.IsFileExisting(templatesFilePath)
+	utils.CheckForError(err)
+
+	if !isExisting {
+		return false
+	}
+
+	app.Debug(fmt.Sprintf("Loading '%v' file ...", templatesFilePath))
+
+	yamlData, err := os.ReadFile(templatesFilePath)
+	utils.CheckForError(err)
+
+	var templates TemplatesFile
+	err = yaml.Unmarshal(yamlData, &templates)
+	utils.CheckForError(err)
+
+	app.TemplatesFile = templates
+	return true
+}
+
+// app.NewGitHubClient() - creates a new `GitHubClient` instance for the repository
+// of the `origin` remote using the token from `GITHUB_TOKEN`
+func (app *AppContext) NewGitHubClient(userAgent string) (*GitHubClient, error) {
+	GITHUB_TOKEN := strings.TrimSpace(os.Getenv("GITHUB_TOKEN"))
+	if GITHUB_TOKEN == "" {
+		return nil, fmt.Errorf("GITHUB_TOKEN is not defined")
+	}
+
+	remoteUrl, err := app.GetGitRemoteUrl("origin")
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repository, err := ParseGitHubRepositoryUrl(remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubClient{
+		Owner:      owner,
+		Repository: repository,
+		Token:      GITHUB_TOKEN,
+		UserAgent:  strings.TrimSpace(userAgent),
+	}, nil
+}
+
+// app.NewGitLabClient() - creates a new `GitLabClient` instance for the project
+// of the `origin` remote using the token from `GITLAB_TOKEN`
+func (app *AppContext) NewGitLabClient(userAgent string) (*GitLabClient, error) {
+	GITLAB_TOKEN := strings.TrimSpace(os.Getenv("GITLAB_TOKEN"))
+	if GITLAB_TOKEN == "" {
+		return nil, fmt.Errorf("GITLAB_TOKEN is not defined")
+	}
+
+	remoteUrl, err := app.GetGitRemoteUrl("origin")
+	if err != nil {
+		return nil, err
+	}
+
+	host, project, err := ParseGitRemoteUrl(remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := "https"
+	if strings.HasPrefix(strings.ToLower(remoteUrl), "http://") {
+		scheme = "http"
+	}
+
+	return &GitLabClient{
+		BaseUrl:   fmt.Sprintf("%v://%v", scheme, host),
+		Project:   project,
+		Token:     GITLAB_TOKEN,
+		UserAgent: strings.TrimSpace(userAgent),
+	}, nil
+}
+
+// app.NewProgressBar() - creates a new progress bar, which is silent in quiet mode
+func (app *AppContext) NewProgressBar(totalCount int, description string) *progressbar.ProgressBar {
+	if app.Quiet {
+		return progressbar.DefaultSilent(int64(totalCount), description)
+	}
+
+	return utils.CreateProgressBar(totalCount, description)
+}
+
+// app.NewReleaseProvider() - creates a new `ReleaseProvider` instance by its name
+// like `github` or `gitlab`, or detects it from the host of `origin` remote,
+// if `name` is empty
+func (app *AppContext) NewReleaseProvider(name string, userAgent string) (ReleaseProvider, error) {
+	providerName := strings.TrimSpace(strings.ToLower(name))
+	if providerName == "" {
+		remoteUrl, err := app.GetGitRemoteUrl("origin")
+		if err != nil {
+			return nil, err
+		}
+
+		providerName, err = DetectReleaseProviderName(remoteUrl)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	app.Debug(fmt.Sprintf("Release provider: %v", providerName))
+
+	var provider ReleaseProvider
+	var err error
+	switch providerName {
+	case "github":
+		provider, err = app.NewGitHubClient(userAgent)
+	case "gitlab":
+		provider, err = app.NewGitLabClient(userAgent)
+	default:
+		err = fmt.Errorf("release provider '%v' is not supported", providerName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// app.NewSpinner() - creates a new spinner with default settings,
+// which writes nothing in quiet mode
+func (app *AppContext) NewSpinner() *spinner.Spinner {
+	s := spinner.New(spinner.CharSets[24], 100*time.Millisecond)
+	if app.Quiet {
+		s.Writer = io.Discard
+	}
+
+	return s
+}
+
 // app.NewVersionManager() - creates a new `ProjectVersionManager` instance based on
 // this application context
 func (app *AppContext) NewVersionManager() *ProjectVersionManager {
@@ -1090,6 +1920,54 @@ func (app *AppContext) NewVersionManager() *ProjectVersionManager {
 	return pvm
 }
 
+// app.Now() - returns the current time from the custom clock
+// or `time.Now()` if not defined
+func (app *AppContext) Now() time.Time {
+	if app.Clock != nil {
+		return app.Clock()
+	}
+
+	return time.Now()
+}
+
+// app.printDryRun() - outputs a command that would be executed
+// if `--dry-run` is not set
+func (app *AppContext) printDryRun(cmd string, args ...string) {
+	cmdToOutput := strings.TrimSpace(
+		strings.Join(append([]string{cmd}, args...), " "),
+	)
+
+	fmt.Fprintf(app.Out, "[DRY-RUN] %v (in '%v')%v", cmdToOutput, app.Cwd, fmt.Sprintln())
+}
+
+// app.PrintTimings() - writes all recorded timings to error output
+// if `--timings` flag is set
+func (app *AppContext) PrintTimings() {
+	if !app.Timings || len(app.timings) == 0 {
+		return
+	}
+
+	fmt.Fprintln(app.ErrorOut)
+	fmt.Fprintln(app.ErrorOut, "Timings:")
+	for _, t := range app.timings {
+		name := t.Name
+		duration := t.Duration
+		if !t.startTime.IsZero() {
+			// still running, like if app exits early
+			name += " (unfinished)"
+			duration = time.Since(t.startTime)
+		}
+
+		fmt.Fprintf(app.ErrorOut, "\t%-40s %10v%s", name, duration.Round(time.Millisecond), fmt.Sprintln())
+	}
+	if !app.StartTime.IsZero() {
+		// phases can be nested, so use wall-clock time
+		total := time.Since(app.StartTime)
+
+		fmt.Fprintf(app.ErrorOut, "\t%-40s %10v%s", "total", total.Round(time.Millisecond), fmt.Sprintln())
+	}
+}
+
 // app.Read() - implementation for an io.Reader
 func (app *AppContext) Read(p []byte) (int, error) {
 	if app.In == nil {
@@ -1110,32 +1988,119 @@ func (app *AppContext) ReadAllInputs(files ...string) ([]byte, error) {
 
 // app.RunCurrentProject() - runs the current go project
 func (app *AppContext) RunCurrentProject(additionalArgs ...string) {
-	p := utils.CreateShellCommandByArgs("go", "run", ".")
+	p := app.CreateCurrentProjectCommand(additionalArgs...)
 
 	app.Debug(fmt.Sprintf("Running '%v' ...", "go run ."))
-	utils.RunCommand(p, additionalArgs...)
+	utils.RunCommand(p)
 }
 
-// app.RunScript() - runs a script defined in gpm.y(a)ml file
-func (app *AppContext) RunScript(scriptName string, additionalArgs ...string) {
-	finalScriptName := scriptName
+// app.resolveScriptName() - returns the name of the script that should be used
+// for `scriptName`, based on the current environment and operating system
+//
+// Order: `<env>:<name>:<os>` > `<name>:<os>` > `<env>:<name>` > `<name>`
+func (app *AppContext) resolveScriptName(scriptName string) string {
+	candidates := []string{}
 
 	// try to check if there is a script name with environment prefix
 	// like `dev:foo` if script is called `foo` and environment `dev` e.g.
 	envName := app.GetEnvironment()
 	if envName != "" {
-		scriptNameWithEnv := fmt.Sprintf("%s:%s", envName, scriptName)
+		candidates = append(candidates, fmt.Sprintf("%s:%s:%s", envName, scriptName, runtime.GOOS))
+	}
+	candidates = append(candidates, fmt.Sprintf("%s:%s", scriptName, runtime.GOOS))
+	if envName != "" {
+		candidates = append(candidates, fmt.Sprintf("%s:%s", envName, scriptName))
+	}
 
-		_, ok := app.GpmFile.Scripts[scriptNameWithEnv]
+	for _, c := range candidates {
+		_, ok := app.GpmFile.Scripts[c]
 		if ok {
-			finalScriptName = scriptNameWithEnv
+			return c
+		}
+	}
+
+	return scriptName
+}
+
+// app.ResolveScriptOrder() - returns the list of script names which have to be executed
+// for `scriptName`, dependencies from `needs` first
+func (app *AppContext) ResolveScriptOrder(scriptName string) ([]string, error) {
+	order := []string{}
+	done := map[string]bool{}
+	stack := []string{}
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if done[name] {
+			return nil
+		}
+		if utils.IndexOfString(stack, name) > -1 {
+			return fmt.Errorf("cycle detected in scripts: %s -> %s", strings.Join(stack, " -> "), name)
+		}
+
+		script, ok := app.GpmFile.Scripts[app.resolveScriptName(name)]
+		if !ok {
+			return fmt.Errorf("script '%v' not found", name)
+		}
+
+		stack = append(stack, name)
+		for _, need := range script.Needs {
+			err := visit(strings.TrimSpace(need))
+			if err != nil {
+				return err
+			}
 		}
+		stack = stack[:len(stack)-1]
+
+		done[name] = true
+		order = append(order, name)
+
+		return nil
 	}
 
-	cmdToExecute := app.GpmFile.Scripts[finalScriptName]
+	err := visit(scriptName)
+
+	return order, err
+}
+
+// app.RunScript() - runs a script defined in gpm.y(a)ml file,
+// including the scripts it needs
+func (app *AppContext) RunScript(scriptName string, additionalArgs ...string) {
+	scriptNames, err := app.ResolveScriptOrder(scriptName)
+	utils.CheckForError(err)
+
+	for i, name := range scriptNames {
+		if i < len(scriptNames)-1 {
+			// dependencies do not get the arguments
+			app.Debug(fmt.Sprintf("Script '%v' needs '%v' ...", scriptName, name))
+			app.runSingleScript(name)
+		} else {
+			app.runSingleScript(name, additionalArgs...)
+		}
+	}
+}
+
+func (app *AppContext) runSingleScript(scriptName string, additionalArgs ...string) {
+	finalScriptName := app.resolveScriptName(scriptName)
+
+	cmdToExecute, usedArgs := app.ExpandScriptVariables(
+		app.GpmFile.Scripts[finalScriptName].Run, additionalArgs,
+	)
+	if usedArgs {
+		// arguments are already part of the command
+		additionalArgs = []string{}
+	}
+
+	if app.DryRun {
+		app.printDryRun(cmdToExecute, additionalArgs...)
+		return
+	}
 
 	p := utils.CreateShellCommand(cmdToExecute)
 
+	stopTiming := app.StartTiming(fmt.Sprintf("script '%v'", finalScriptName))
+	defer stopTiming()
+
 	app.Debug(fmt.Sprintf("Running script '%v' ...", scriptName))
 	utils.RunCommand(p, additionalArgs...)
 }
@@ -1144,22 +2109,135 @@ func (app *AppContext) RunScript(scriptName string, additionalArgs ...string) {
 func (app *AppContext) RunShellCommand(cmd string) {
 	app.Debug(fmt.Sprintf("Running '%v' ...", cmd))
 
+	if app.DryRun {
+		app.printDryRun(cmd)
+		return
+	}
+
 	p := utils.CreateShellCommand(cmd)
 	p.Dir = app.Cwd
 
+	stopTiming := app.StartTiming(fmt.Sprintf("'%v'", cmd))
+	defer stopTiming()
+
 	utils.RunCommand(p)
 }
 
 // app.RunShellCommandByArgs() - runs a shell command by arguments in app's context
 func (app *AppContext) RunShellCommandByArgs(c string, a ...string) {
+	app.RunShellCommandByArgsWithEnv([]string{}, c, a...)
+}
+
+// app.RunShellCommandByArgsWithEnv() - runs a shell command by arguments in app's context
+// with additional environment variables in `KEY=VALUE` format
+func (app *AppContext) RunShellCommandByArgsWithEnv(env []string, c string, a ...string) {
 	app.Debug(fmt.Sprintf("Running '%v %v' ...", c, strings.Join(a, " ")))
 
+	if app.DryRun {
+		app.printDryRun(strings.TrimSpace(strings.Join(env, " ")+" "+c), a...)
+		return
+	}
+
 	p := utils.CreateShellCommandByArgs(c, a...)
 	p.Dir = app.Cwd
+	p.Env = append(p.Env, env...)
+
+	stopTiming := app.StartTiming(fmt.Sprintf("'%v %v'", c, strings.Join(a, " ")))
+	defer stopTiming()
 
 	utils.RunCommand(p)
 }
 
+// app.SaveSettingsFile() - writes all values of app's SettingsFile to
+// the settings.yaml file and reloads it, values which do not match the
+// types of `SettingsFile` are not written
+func (app *AppContext) SaveSettingsFile() error {
+	settingsFilePath, err := app.GetSettingsFilePath()
+	if err != nil {
+		return err
+	}
+
+	yamlData, err := yaml.Marshal(app.SettingsFile.GetValues())
+	if err != nil {
+		return err
+	}
+
+	// do not write values with invalid types
+	var settings SettingsFile
+	err = yaml.Unmarshal(yamlData, &settings)
+	if err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+
+	err = os.MkdirAll(path.Dir(settingsFilePath), constants.DefaultDirMode)
+	if err != nil {
+		return err
+	}
+
+	app.Debug(fmt.Sprintf("Writing '%v' file ...", settingsFilePath))
+	err = os.WriteFile(settingsFilePath, yamlData, constants.DefaultFileMode)
+	if err != nil {
+		return err
+	}
+
+	app.LoadSettingsFileIfExist()
+	return nil
+}
+
+// app.SetGpmFileValue() - sets a top-level value in the gpm.yaml file of the
+// current project, keeps all other values, comments and the order of keys
+// and reloads the file
+func (app *AppContext) SetGpmFileValue(key string, value interface{}) error {
+	gpmFilePath, err := app.GetGpmFilePath()
+	if err != nil {
+		return err
+	}
+
+	isExisting, err := utils.IsFileExisting(gpmFilePath)
+	if err != nil {
+		return err
+	}
+
+	var yamlData []byte
+	if isExisting {
+		yamlData, err = os.ReadFile(gpmFilePath)
+		if err != nil {
+			return err
+		}
+	}
+
+	yamlData, err = utils.SetYamlRootValue(yamlData, key, value)
+	if err != nil {
+		return err
+	}
+
+	app.Debug(fmt.Sprintf("Writing '%v' file ...", gpmFilePath))
+	err = os.WriteFile(gpmFilePath, yamlData, constants.DefaultFileMode)
+	if err != nil {
+		return err
+	}
+
+	app.LoadGpmFileIfExist()
+	return nil
+}
+
+// app.StartTiming() - starts measuring the duration of a phase and
+// returns the function which stops it
+func (app *AppContext) StartTiming(name string) func() {
+	index := len(app.timings)
+	app.timings = append(app.timings, AppTiming{
+		Name:      name,
+		startTime: time.Now(),
+	})
+
+	return func() {
+		t := &app.timings[index]
+
+		t.Duration = time.Since(t.startTime)
+		t.startTime = time.Time{}
+	}
+}
+
 // app.TidyUp() - runs 'go mod tidy' for the current project (folder)
 func (app *AppContext) TidyUp(options ...TidyUpOptions) {
 	args := []string{}
@@ -1175,7 +2253,7 @@ func (app *AppContext) TidyUp(options ...TidyUpOptions) {
 		}
 	}
 
-	_, ok := app.GpmFile.Scripts[constants.TidyScriptName]
+	ok := app.HasScript(constants.TidyScriptName)
 	if !noScript && ok {
 		app.RunScript(constants.TidyScriptName, args...)
 	} else {
@@ -1243,6 +2321,13 @@ func (app *AppContext) UpdateProjectsFile() error {
 	return os.WriteFile(projectsFilePath, yamlData, constants.DefaultFileMode)
 }
 
+// app.Warn() - writes a warning with the underlying logger
+func (app *AppContext) Warn(v ...any) *AppContext {
+	app.log(slog.LevelWarn, "WARN", v...)
+
+	return app
+}
+
 // app.Write() - implementation for an io.Writer
 func (app *AppContext) Write(p []byte) (int, error) {
 	if app.Out == nil {
@@ -1287,7 +2372,7 @@ func (app *AppContext) WriteAllInputsTo(w io.Writer, files ...string) (int64, er
 			// in this case `filePath` is a downloadable URL
 
 			readData = func() (int64, error) {
-				return utils.DownloadFromUrlTo(app.Out, filePathOrUrl)
+				return utils.DownloadFromUrlTo(w, filePathOrUrl, app.GetDownloadOptions())
 			}
 		} else {
 			filePath := app.GetFullPathOrDefault(filePathOrUrl, "")
@@ -1297,7 +2382,7 @@ func (app *AppContext) WriteAllInputsTo(w io.Writer, files ...string) (int64, er
 				utils.CheckForError(err)
 				defer file.Close()
 
-				return io.Copy(app.Out, file)
+				return io.Copy(w, file)
 			}
 		}
 
@@ -0,0 +1,627 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+func TestExpandScriptVariables(t *testing.T) {
+	t.Setenv("GPM_ENV", "")
+	t.Setenv("GPM_TEST_VALUE", "$(echo injected)")
+
+	app := &AppContext{
+		Cwd:         t.TempDir(),
+		Environment: "Dev",
+		GpmFile: GpmFile{
+			Name: "my-project",
+		},
+	}
+
+	tests := []struct {
+		cmd          string
+		args         []string
+		expected     string
+		expectedArgs bool
+	}{
+		{"echo ${name} ${environment}", nil, "echo my-project dev", false},
+		{"echo ${ name }", nil, "echo my-project", false},
+		{"echo ${1} and ${2}", []string{"a", "b c"}, "echo a and " + utils.QuoteShellArg("b c"), true},
+		{"echo ${3}", []string{"a"}, "echo ", true},
+		{"echo ${@}", []string{"a", "b"}, "echo a b", true},
+		{"echo ${@}", []string{"a b", "x; rm -rf ~"}, "echo " + utils.QuoteShellArg("a b") + " " + utils.QuoteShellArg("x; rm -rf ~"), true},
+		{"echo ${1}", []string{"$(whoami)"}, "echo " + utils.QuoteShellArg("$(whoami)"), true},
+		// environment variables are handled by the shell
+		{"echo ${GPM_TEST_VALUE}", nil, "echo ${GPM_TEST_VALUE}", false},
+		{"echo ${HOME} ${unknown}", nil, "echo ${HOME} ${unknown}", false},
+		{"echo $1", []string{"a"}, "echo $1", false},
+		// no git repository
+		{"echo ${version}", nil, "echo 0.0.0", false},
+	}
+
+	for _, test := range tests {
+		actual, usedArgs := app.ExpandScriptVariables(test.cmd, test.args)
+
+		if actual != test.expected {
+			t.Errorf("'%v': expected '%v', got '%v'", test.cmd, test.expected, actual)
+		}
+		if usedArgs != test.expectedArgs {
+			t.Errorf("'%v': expected used arguments to be %v", test.cmd, test.expectedArgs)
+		}
+	}
+
+	// values with spaces and shell metacharacters
+	app.Environment = "my env"
+	app.GpmFile.Name = "my project; rm -rf ~"
+
+	actual, _ := app.ExpandScriptVariables("echo ${name} ${environment}", nil)
+	expected := "echo " + utils.QuoteShellArg("my project; rm -rf ~") + " " + utils.QuoteShellArg("my env")
+	if actual != expected {
+		t.Errorf("expected '%v', got '%v'", expected, actual)
+	}
+}
+
+func TestRunScriptWithTemplatedCommand(t *testing.T) {
+	var out bytes.Buffer
+
+	app := &AppContext{
+		Cwd:    t.TempDir(),
+		DryRun: true,
+		GpmFile: GpmFile{
+			Name: "my-project",
+			Scripts: map[string]GpmFileScript{
+				"hello": {Run: "echo Hello from ${name}, ${1}! ${USER}"},
+				"plain": {Run: "echo plain"},
+			},
+		},
+		Out: &out,
+	}
+
+	app.RunScript("hello", "World")
+	app.RunScript("plain", "arg1", "arg2")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", lines)
+	}
+
+	// argument is part of the command and not appended anymore
+	if !strings.HasPrefix(lines[0], "[DRY-RUN] echo Hello from my-project, World! ${USER} (in ") {
+		t.Errorf("unexpected output '%v'", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "[DRY-RUN] echo plain arg1 arg2 (in ") {
+		t.Errorf("unexpected output '%v'", lines[1])
+	}
+}
+
+func TestRunScriptDoesNotEvaluateArguments(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("script uses POSIX shell")
+	}
+
+	dir := t.TempDir()
+	app := &AppContext{
+		Cwd: dir,
+		GpmFile: GpmFile{
+			Scripts: map[string]GpmFileScript{
+				"args": {Run: "cd " + utils.QuoteShellArg(dir) + " && echo ${1} ${@}"},
+			},
+		},
+	}
+
+	app.RunScript("args", "x; touch pwned1", "$(touch pwned2)")
+
+	for _, name := range []string{"pwned1", "pwned2"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			t.Errorf("argument has been evaluated and created '%v'", name)
+		}
+	}
+}
+
+func TestResolveScriptName(t *testing.T) {
+	t.Setenv("GPM_ENV", "")
+
+	goos := runtime.GOOS
+
+	tests := []struct {
+		name        string
+		environment string
+		scripts     []string
+		expected    string
+	}{
+		{"env and os first", "dev", []string{"build", "build:" + goos, "dev:build", "dev:build:" + goos}, "dev:build:" + goos},
+		{"os before env", "dev", []string{"build", "build:" + goos, "dev:build"}, "build:" + goos},
+		{"env before bare", "dev", []string{"build", "dev:build"}, "dev:build"},
+		{"bare", "dev", []string{"build"}, "build"},
+		{"other os is ignored", "", []string{"build", "build:other-os"}, "build"},
+		{"env scripts need environment", "", []string{"build", "dev:build"}, "build"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := &AppContext{
+				Environment: test.environment,
+				GpmFile: GpmFile{
+					Scripts: map[string]GpmFileScript{},
+				},
+			}
+			for _, s := range test.scripts {
+				app.GpmFile.Scripts[s] = GpmFileScript{Run: "echo " + s}
+			}
+
+			actual := app.resolveScriptName("build")
+			if actual != test.expected {
+				t.Errorf("expected '%v', got '%v'", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGitHelpersWithoutRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+
+	app := &AppContext{
+		Cwd: dir,
+	}
+
+	hash, err := app.GetGitCommitHash(false)
+	if err == nil {
+		t.Fatalf("expected error, got commit hash '%v'", hash)
+	}
+	if !strings.Contains(err.Error(), "no git repository") {
+		t.Errorf("unclear error message: %v", err)
+	}
+
+	_, err = app.GetGitDirty()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "no git repository") {
+		t.Errorf("unclear error message: %v", err)
+	}
+}
+
+func TestGitHelpers(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+
+	git := func(args ...string) {
+		p := exec.Command("git", append([]string{"-c", "user.name=gpm", "-c", "user.email=gpm@localhost"}, args...)...)
+		p.Dir = dir
+
+		output, err := p.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", strings.Join(args, " "), err, output)
+		}
+	}
+
+	git("init", "-q")
+	err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# test"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	git("add", "README.md")
+	git("commit", "-q", "-m", "initial commit")
+
+	app := &AppContext{
+		Cwd: dir,
+	}
+
+	hash, err := app.GetGitCommitHash(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hash) < 40 {
+		t.Errorf("unexpected commit hash '%v'", hash)
+	}
+
+	shortHash, err := app.GetGitCommitHash(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shortHash == "" || !strings.HasPrefix(hash, shortHash) {
+		t.Errorf("short hash '%v' does not match '%v'", shortHash, hash)
+	}
+
+	dirty, err := app.GetGitDirty()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dirty {
+		t.Error("expected clean working tree")
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dirty, err = app.GetGitDirty()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dirty {
+		t.Error("expected dirty working tree")
+	}
+}
+
+func TestSetGpmFileValue(t *testing.T) {
+	dir := t.TempDir()
+	gpmFilePath := filepath.Join(dir, "gpm.yaml")
+
+	err := os.WriteFile(gpmFilePath, []byte("# my project\nname: foo # the name\n\nscripts:\n  test: go test ./...\n"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app := &AppContext{
+		Cwd: dir,
+	}
+
+	err = app.SetGpmFileValue("tools", []string{"golang.org/x/tools/cmd/stringer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	yamlData, err := os.ReadFile(gpmFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "# my project\nname: foo # the name\n\nscripts:\n  test: go test ./...\ntools:\n- golang.org/x/tools/cmd/stringer\n"
+	if string(yamlData) != expected {
+		t.Errorf("expected %q, got %q", expected, string(yamlData))
+	}
+
+	if len(app.GpmFile.Tools) != 1 || app.GpmFile.Tools[0] != "golang.org/x/tools/cmd/stringer" {
+		t.Errorf("gpm.yaml has not been reloaded: %v", app.GpmFile.Tools)
+	}
+}
+
+func TestLoadDataFrom(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "data.txt")
+
+	err := os.WriteFile(filePath, []byte("from file"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("from web"))
+	}))
+	defer server.Close()
+
+	fileUrl := "file://" + filepath.ToSlash(filePath)
+	if runtime.GOOS == "windows" {
+		fileUrl = "file:///" + filepath.ToSlash(filePath)
+	}
+
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"data.txt", "from file"},
+		{" data.txt ", "from file"},
+		{filePath, "from file"},
+		{fileUrl, "from file"},
+		{"file:data.txt", "from file"},
+		{"-", "from stdin"},
+		{server.URL + "/data.txt", "from web"},
+	}
+
+	for _, test := range tests {
+		app := &AppContext{
+			Cwd: dir,
+			In:  strings.NewReader("from stdin"),
+		}
+
+		data, err := app.LoadDataFrom(test.source)
+		if err != nil {
+			t.Fatalf("'%v': %v", test.source, err)
+		}
+
+		if string(data) != test.expected {
+			t.Errorf("'%v': expected '%v', got '%v'", test.source, test.expected, string(data))
+		}
+	}
+}
+
+func TestLoadDataFromInvalidSources(t *testing.T) {
+	app := &AppContext{
+		Cwd: t.TempDir(),
+	}
+
+	for _, source := range []string{
+		"does-not-exist.txt",
+		"file://example.com/etc/passwd",
+	} {
+		if _, err := app.LoadDataFrom(source); err == nil {
+			t.Errorf("expected error for '%v'", source)
+		}
+	}
+}
+
+func TestLoadDataFromWithChecksum(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "data.txt"), []byte("from file"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("from web"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		source string
+		data   string
+	}{
+		{"data.txt", "from file"},
+		{"-", "from stdin"},
+		{server.URL, "from web"},
+	}
+
+	for _, test := range tests {
+		app := &AppContext{
+			Cwd: dir,
+			In:  strings.NewReader("from stdin"),
+		}
+
+		app.DownloadOptions.Checksum = utils.HashSHA256([]byte(test.data))
+		_, err := app.LoadDataFrom(test.source)
+		if err != nil {
+			t.Errorf("'%v': %v", test.source, err)
+		}
+
+		app.In = strings.NewReader("from stdin")
+		app.DownloadOptions.Checksum = utils.HashSHA256([]byte("tampered"))
+		_, err = app.LoadDataFrom(test.source)
+		if err == nil {
+			t.Errorf("'%v': expected checksum mismatch", test.source)
+		}
+	}
+}
+
+func TestGetAIChatTemperature(t *testing.T) {
+	t.Setenv("GPM_AI_CHAT_TEMPERATURE", "")
+
+	app := &AppContext{}
+
+	// default value of code
+	if temperature := app.GetAIChatTemperature(0.3); temperature != 0.3 {
+		t.Errorf("expected default value 0.3, got %v", temperature)
+	}
+
+	// settings file before default value
+	settingsTemperature := float32(0.7)
+	app.SettingsFile.AI.Temperature = &settingsTemperature
+	if temperature := app.GetAIChatTemperature(0.3); temperature != 0.7 {
+		t.Errorf("expected value 0.7 from settings, got %v", temperature)
+	}
+
+	// environment variable before settings file
+	t.Setenv("GPM_AI_CHAT_TEMPERATURE", "0.9")
+	if temperature := app.GetAIChatTemperature(0.3); temperature != 0.9 {
+		t.Errorf("expected value 0.9 from environment variable, got %v", temperature)
+	}
+
+	// invalid environment variable is ignored
+	t.Setenv("GPM_AI_CHAT_TEMPERATURE", "hot")
+	if temperature := app.GetAIChatTemperature(0.3); temperature != 0.7 {
+		t.Errorf("expected value 0.7 from settings, got %v", temperature)
+	}
+}
+
+func TestSaveSettingsFileWithInvalidType(t *testing.T) {
+	t.Setenv("GPM_SETTINGS_FILE", "")
+
+	dir := t.TempDir()
+	settingsFilePath := filepath.Join(dir, "settings.yaml")
+
+	var logs bytes.Buffer
+	app := &AppContext{
+		GpmRootPath: dir,
+		L:           log.New(&logs, "", 0),
+	}
+
+	err := utils.SetValueByDottedKey(app.SettingsFile.GetValues(), "ai.temperature", 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = app.SaveSettingsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = utils.SetValueByDottedKey(app.SettingsFile.GetValues(), "ai.temperature", "hot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = app.SaveSettingsFile()
+	if err == nil {
+		t.Fatal("expected error for invalid temperature")
+	}
+
+	yamlData, err := os.ReadFile(settingsFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(yamlData), "hot") {
+		t.Errorf("invalid value has been written: %q", string(yamlData))
+	}
+
+	// a file with invalid types is loaded with a warning only
+	err = os.WriteFile(settingsFilePath, []byte("ai:\n  temperature: hot\n"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !app.LoadSettingsFileIfExist() {
+		t.Fatal("settings file has not been loaded")
+	}
+	if !strings.Contains(logs.String(), "[WARN]") {
+		t.Errorf("expected warning, got %q", logs.String())
+	}
+	if value, ok := utils.GetValueByDottedKey(app.SettingsFile.GetValues(), "ai.temperature"); !ok || value != "hot" {
+		t.Errorf("expected raw value 'hot', got '%v'", value)
+	}
+}
+
+func TestCheckAIBudget(t *testing.T) {
+	t.Setenv("GPM_AI_LOG", "")
+
+	dir := t.TempDir()
+	now := time.Date(2024, 7, 15, 12, 0, 0, 0, time.UTC)
+
+	app := &AppContext{
+		Clock:       func() time.Time { return now },
+		GpmRootPath: dir,
+	}
+	app.SettingsFile.AI.Prices = map[string]SettingsFileAIPrice{
+		"gpt-4o-mini": {Input: 1, Output: 2},
+	}
+
+	// no budget, no log file
+	if logFile := app.GetAILogFile(); logFile != "" {
+		t.Errorf("expected no log file, got '%v'", logFile)
+	}
+
+	app.SettingsFile.AI.Budget.Daily.Tokens = 1000
+	app.SettingsFile.AI.Budget.Monthly.USD = 1
+
+	// usage is tracked in root directory
+	logFile := app.GetAILogFile()
+	if logFile != filepath.Join(dir, "ai.log.jsonl") {
+		t.Fatalf("unexpected log file '%v'", logFile)
+	}
+	if err := app.CheckAIBudget(); err != nil {
+		t.Errorf("expected no error without log file, got '%v'", err)
+	}
+
+	writeLog := func(entries ...string) {
+		err := os.WriteFile(logFile, []byte(strings.Join(entries, "\n")+"\n"), 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	entry := func(time string, inputTokens int, outputTokens int) string {
+		return fmt.Sprintf(
+			`{"method":"POST","time":"%v","url":"","request":{"model":"gpt-4o-mini"},"response":{"usage":{"prompt_tokens":%v,"completion_tokens":%v}}}`,
+			time, inputTokens, outputTokens,
+		)
+	}
+
+	// yesterday does not count for daily budget
+	writeLog(
+		entry("2024-07-14T10:00:00Z", 800, 200),
+		entry("2024-07-15T10:00:00Z", 400, 100),
+	)
+	if err := app.CheckAIBudget(); err != nil {
+		t.Errorf("expected no error, got '%v'", err)
+	}
+
+	// daily tokens
+	writeLog(
+		entry("2024-07-15T09:00:00Z", 400, 100),
+		entry("2024-07-15T10:00:00Z", 400, 100),
+	)
+	err := app.CheckAIBudget()
+	if err == nil || !strings.HasPrefix(err.Error(), "AI budget exceeded") {
+		t.Errorf("expected exceeded daily budget, got '%v'", err)
+	}
+
+	// monthly costs, last month does not count
+	writeLog(
+		entry("2024-06-30T10:00:00Z", 1000000, 0),
+		entry("2024-07-01T10:00:00Z", 400000, 300000),
+	)
+	err = app.CheckAIBudget()
+	if err == nil || !strings.Contains(err.Error(), "monthly") {
+		t.Errorf("expected exceeded monthly budget, got '%v'", err)
+	}
+
+	// override
+	app.IgnoreBudget = true
+	if err := app.CheckAIBudget(); err != nil {
+		t.Errorf("expected ignored budget, got '%v'", err)
+	}
+}
+
+func TestAILogTransportWithExceededBudget(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var budgetErr error
+	logFile := filepath.Join(t.TempDir(), "ai.log.jsonl")
+
+	client := &http.Client{
+		Transport: &AILogTransport{
+			CheckBudget: func() error {
+				return budgetErr
+			},
+			File: logFile,
+		},
+	}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	budgetErr = fmt.Errorf("AI budget exceeded")
+	_, err = client.Post(server.URL, "application/json", strings.NewReader(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "AI budget exceeded") {
+		t.Errorf("expected budget error, got '%v'", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %v", requests)
+	}
+}
@@ -24,7 +24,10 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
+
+	"github.com/mkloubert/go-package-manager/utils"
 )
 
 // ChatAI describes an object that provides abstract
@@ -34,6 +37,11 @@ type ChatAI interface {
 	ClearHistory()
 	// ChatAI.DescribeImage() - describes an image without adding using history
 	DescribeImage(message string, dataURI string) (DescribeImageResponse, error)
+	// ChatAI.DescribeImages() - describes several images individually in one request
+	// without adding using history
+	DescribeImages(message string, dataURIs []string) ([]DescribeImageResponse, error)
+	// ChatAI.ExportHistory() - returns a copy of the current chat history
+	ExportHistory() []ChatAIMessage
 	// ChatAI.GetModel() - get the name of the chat model
 	GetModel() string
 	// ChatAI.GetMoreInfo() - returns additional information, if available
@@ -42,6 +50,11 @@ type ChatAI interface {
 	GetPromptSuffix() string
 	// ChatAI.GetProvider() - get the name of the chat provider
 	GetProvider() string
+	// ChatAI.ImportHistory() - replaces the current chat history
+	ImportHistory(history []ChatAIMessage)
+	// ChatAI.RemoveLastTurn() - removes the last user message and all following
+	// answers from history and returns the removed user message, if found
+	RemoveLastTurn() (string, bool)
 	// ChatAI.SendMessage() - sends a new message
 	// to the API for the current chat conversation
 	SendMessage(message string, onUpdate ChatAIMessageChunkReceiver) error
@@ -58,15 +71,105 @@ type ChatAI interface {
 	WithJsonSchema(message string, schemaName string, schema map[string]interface{}, onUpdate ChatAIMessageChunkReceiver) error
 }
 
+// ChatAIMessage is a provider independent item
+// of a chat history
+type ChatAIMessage struct {
+	Content string `json:"content,omitempty" yaml:"content,omitempty"` // the message content
+	Role    string `json:"role,omitempty" yaml:"role,omitempty"`       // the role like user, assistant or system
+}
+
 type ChatAIMessageChunkReceiver = func(messageChunk string) error
 
-func get_ai_image_description_from_json(jsonStr string) (DescribeImageResponse, error) {
-	var imageDescription DescribeImageResponse
+// ChatWithJsonSchemaOptions stores settings for `ChatWithJsonSchema()` function
+type ChatWithJsonSchemaOptions struct {
+	MaxRetries *int                                       // maximum number of corrective follow-up messages, default 3
+	OnRetry    func(retry int, maxRetries int, err error) // optional function, which is called before a retry
+	Validate   func(jsonAnswer string) error              // optional, additional validation of an answer
+}
+
+// ChatWithJsonSchema() - sends a message with a JSON schema to a chat and returns the
+// JSON answer; if the answer is no valid JSON, does not match the schema or fails
+// custom validation, corrective follow-up messages are sent
+func ChatWithJsonSchema(chat ChatAI, message string, schemaName string, schema map[string]interface{}, options ...ChatWithJsonSchemaOptions) (string, error) {
+	maxRetries := 3
+	var onRetry func(retry int, maxRetries int, err error)
+	var validate func(jsonAnswer string) error
+	for _, o := range options {
+		if o.MaxRetries != nil {
+			maxRetries = *o.MaxRetries
+		}
+		if o.OnRetry != nil {
+			onRetry = o.OnRetry
+		}
+		if o.Validate != nil {
+			validate = o.Validate
+		}
+	}
+
+	var lastErr error
+	for retry := 0; retry <= maxRetries; retry++ {
+		if retry > 0 {
+			if onRetry != nil {
+				onRetry(retry, maxRetries, lastErr)
+			}
+
+			message = fmt.Sprintf("Your last response was invalid, because %v. Answer again with valid JSON, which matches the schema.", lastErr)
+		}
+
+		jsonAnswer := ""
+		err := chat.WithJsonSchema(message, schemaName, schema, func(messageChunk string) error {
+			jsonAnswer += messageChunk
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+
+		jsonAnswer = strings.TrimSpace(jsonAnswer)
+
+		var value interface{}
+		err = json.Unmarshal([]byte(jsonAnswer), &value)
+		if err != nil {
+			lastErr = fmt.Errorf("response is no valid JSON: %v", err)
+			continue
+		}
 
+		issues, err := utils.ValidateJsonSchema(value, schema)
+		if err != nil {
+			return "", err
+		}
+		if len(issues) > 0 {
+			lastErr = fmt.Errorf("response does not match the JSON schema: %v", strings.Join(issues, "; "))
+			continue
+		}
+
+		if validate != nil {
+			err = validate(jsonAnswer)
+			if err != nil {
+				lastErr = fmt.Errorf("response is not valid: %v", err)
+				continue
+			}
+		}
+
+		return jsonAnswer, nil
+	}
+
+	return "", lastErr
+}
+
+func get_ai_image_description_from_json(jsonStr string) (DescribeImageResponse, error) {
 	data := map[string]interface{}{}
 	err := json.Unmarshal([]byte(strings.TrimSpace(jsonStr)), &data)
 	if err != nil {
-		return imageDescription, err
+		return DescribeImageResponse{Raw: jsonStr}, err
+	}
+
+	return get_ai_image_description_from_map(data, jsonStr), nil
+}
+
+func get_ai_image_description_from_map(data map[string]interface{}, jsonStr string) DescribeImageResponse {
+	imageDescription := DescribeImageResponse{
+		Raw: jsonStr,
 	}
 
 	aria_attributes, ok := data["aria_attributes"]
@@ -91,5 +194,132 @@ func get_ai_image_description_from_json(jsonStr string) (DescribeImageResponse,
 		}
 	}
 
-	return imageDescription, nil
+	return imageDescription
+}
+
+func get_ai_image_descriptions_from_json(jsonStr string, count int) ([]DescribeImageResponse, error) {
+	data := map[string]interface{}{}
+	err := json.Unmarshal([]byte(strings.TrimSpace(jsonStr)), &data)
+	if err != nil {
+		return nil, err
+	}
+
+	var imageDescriptions []DescribeImageResponse
+
+	images, _ := data["images"].([]interface{})
+	for _, img := range images {
+		imageData, ok := img.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		imageDescriptions = append(imageDescriptions, get_ai_image_description_from_map(imageData, jsonStr))
+	}
+
+	if len(imageDescriptions) != count {
+		return imageDescriptions, fmt.Errorf("expected %v image descriptions but got %v", count, len(imageDescriptions))
+	}
+
+	return imageDescriptions, nil
+}
+
+func get_ai_image_description_schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"aria_attributes"},
+		"properties": map[string]interface{}{
+			"aria_attributes": map[string]interface{}{
+				"description": "HTML accessibility attributes which describe the image.",
+				"type":        "object",
+				"required":    []string{"aria_description", "aria_label"},
+				"properties": map[string]interface{}{
+					"aria_description": map[string]interface{}{
+						"description": "Defines a string value that describes or annotates the image in detail.",
+						"type":        "string",
+					},
+					"aria_label": map[string]interface{}{
+						"description": "Defines a string value that can be used to name the image.",
+						"type":        "string",
+					},
+				},
+			},
+		},
+	}
+}
+
+func get_ai_images_description_schema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"images"},
+		"properties": map[string]interface{}{
+			"images": map[string]interface{}{
+				"description": "One item for each image in the same order as the images have been submitted.",
+				"type":        "array",
+				"items":       get_ai_image_description_schema(),
+			},
+		},
+	}
+}
+
+// estimate_ai_tokens() - roughly estimates the number of tokens of a text
+func estimate_ai_tokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// trim_ai_chat_conversation() - keeps the system messages and the most recent
+// messages of a conversation, which fit into `maxMessages` and an estimated budget
+// of `maxTokens`, where values of 0 or less mean no limit; the last message is
+// always kept; returns the new conversation and the number of removed messages
+func trim_ai_chat_conversation[T any](conversation []T, maxMessages int, maxTokens int, getMessage func(item T) (string, string)) ([]T, int) {
+	if maxMessages <= 0 && maxTokens <= 0 {
+		return conversation, 0
+	}
+
+	usedTokens := 0
+	for _, item := range conversation {
+		role, content := getMessage(item)
+		if role == "system" {
+			usedTokens += estimate_ai_tokens(content)
+		}
+	}
+
+	keep := make([]bool, len(conversation))
+	keptMessages := 0
+	isFull := false
+	for i := len(conversation) - 1; i >= 0; i-- {
+		role, content := getMessage(conversation[i])
+		if role == "system" {
+			keep[i] = true
+			continue
+		}
+		if isFull {
+			continue
+		}
+
+		tokens := estimate_ai_tokens(content)
+		if keptMessages > 0 {
+			if maxMessages > 0 && keptMessages >= maxMessages {
+				isFull = true
+			} else if maxTokens > 0 && usedTokens+tokens > maxTokens {
+				isFull = true
+			}
+
+			if isFull {
+				continue
+			}
+		}
+
+		keep[i] = true
+		keptMessages++
+		usedTokens += tokens
+	}
+
+	trimmedConversation := make([]T, 0, len(conversation))
+	for i, item := range conversation {
+		if keep[i] {
+			trimmedConversation = append(trimmedConversation, item)
+		}
+	}
+
+	return trimmedConversation, len(conversation) - len(trimmedConversation)
 }
@@ -0,0 +1,165 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type testChatMessage struct {
+	role    string
+	content string
+}
+
+// testRoundTripper is an http.RoundTripper, which handles requests by a function
+type testRoundTripper func(req *http.Request) (*http.Response, error)
+
+func (f testRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTrimAIChatConversation(t *testing.T) {
+	getMessage := func(m testChatMessage) (string, string) {
+		return m.role, m.content
+	}
+
+	// each content has 1 estimated token
+	conversation := []testChatMessage{
+		{"system", "sys"},
+		{"user", "u1"},
+		{"assistant", "a1"},
+		{"user", "u2"},
+		{"assistant", "a2"},
+		{"user", "u3"},
+	}
+
+	tests := []struct {
+		name            string
+		maxMessages     int
+		maxTokens       int
+		expected        string
+		expectedRemoved int
+	}{
+		{"no limits", 0, 0, "sys u1 a1 u2 a2 u3", 0},
+		{"negative limits", -1, -1, "sys u1 a1 u2 a2 u3", 0},
+		{"max messages", 3, 0, "sys u2 a2 u3", 2},
+		{"max messages of 1", 1, 0, "sys u3", 4},
+		{"max tokens include system", 0, 3, "sys a2 u3", 3},
+		{"both limits", 4, 3, "sys a2 u3", 3},
+		{"last message is always kept", 0, 1, "sys u3", 4},
+		{"limits above size", 100, 100, "sys u1 a1 u2 a2 u3", 0},
+	}
+
+	for _, test := range tests {
+		trimmed, removed := trim_ai_chat_conversation(conversation, test.maxMessages, test.maxTokens, getMessage)
+
+		contents := []string{}
+		for _, m := range trimmed {
+			contents = append(contents, m.content)
+		}
+
+		if strings.Join(contents, " ") != test.expected {
+			t.Errorf("%v: expected '%v', got '%v'", test.name, test.expected, strings.Join(contents, " "))
+		}
+		if removed != test.expectedRemoved {
+			t.Errorf("%v: expected %v removed messages, got %v", test.name, test.expectedRemoved, removed)
+		}
+	}
+
+	if len(conversation) != 6 {
+		t.Errorf("original conversation has been changed: %v", conversation)
+	}
+
+	// a large older message stops trimming, even if smaller ones would fit
+	conversation = []testChatMessage{
+		{"user", "small"},
+		{"assistant", strings.Repeat("x", 400)},
+		{"user", "u"},
+	}
+	trimmed, removed := trim_ai_chat_conversation(conversation, 0, 10, getMessage)
+	if len(trimmed) != 1 || trimmed[0].content != "u" || removed != 2 {
+		t.Errorf("expected only last message, got %v", trimmed)
+	}
+}
+
+func TestOpenAIChatKeepsHistoryWhenTrimming(t *testing.T) {
+	sentMessages := [][]OpenAIChatMessage{}
+
+	chat := &OpenAIChat{
+		ApiKey: "test",
+		HttpClient: &http.Client{
+			Transport: testRoundTripper(func(req *http.Request) (*http.Response, error) {
+				var body struct {
+					Messages []OpenAIChatMessage `json:"messages"`
+				}
+				err := json.NewDecoder(req.Body).Decode(&body)
+				if err != nil {
+					return nil, err
+				}
+				sentMessages = append(sentMessages, body.Messages)
+
+				answer := fmt.Sprintf(`{"choices":[{"message":{"role":"assistant","content":"answer %v"}}],"usage":{"total_tokens":1}}`, len(sentMessages))
+
+				return &http.Response{
+					Body:       io.NopCloser(bytes.NewReader([]byte(answer))),
+					Header:     http.Header{},
+					StatusCode: 200,
+				}, nil
+			}),
+		},
+		MaxContextMessages: 2,
+		Model:              "gpt-test",
+	}
+	chat.UpdateSystem("system")
+
+	for _, m := range []string{"question 1", "question 2", "question 3"} {
+		err := chat.SendMessage(m, func(messageChunk string) error {
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// system, 3 questions and 3 answers
+	if len(chat.Conversation) != 7 {
+		t.Fatalf("expected 7 messages in history, got %v", chat.Conversation)
+	}
+	if chat.Conversation[1].Content != "question 1" || chat.Conversation[6].Content != "answer 3" {
+		t.Errorf("unexpected history: %v", chat.Conversation)
+	}
+
+	lastSentMessages := sentMessages[len(sentMessages)-1]
+	if len(lastSentMessages) != 3 || lastSentMessages[0].Role != "system" || lastSentMessages[1].Content != "answer 2" || lastSentMessages[2].Content != "question 3" {
+		t.Errorf("unexpected sent messages: %v", lastSentMessages)
+	}
+	if chat.TrimmedMessages != 3 {
+		t.Errorf("expected 3 trimmed messages, got %v", chat.TrimmedMessages)
+	}
+}
@@ -0,0 +1,65 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+// CycloneDXBom represents a CycloneDX 1.5 JSON document
+type CycloneDXBom struct {
+	BomFormat    string               `json:"bomFormat"`              // always `CycloneDX`
+	Components   []CycloneDXComponent `json:"components"`             // list of components
+	Metadata     *CycloneDXMetadata   `json:"metadata,omitempty"`     // metadata
+	SerialNumber string               `json:"serialNumber,omitempty"` // unique URN of the document
+	SpecVersion  string               `json:"specVersion"`            // the version of the specification
+	Version      int                  `json:"version"`                // the version of the document
+}
+
+// CycloneDXComponent represents an item in CycloneDXBom.Components array
+type CycloneDXComponent struct {
+	BomRef   string                      `json:"bom-ref,omitempty"`  // unique reference inside the document
+	Licenses []CycloneDXLicenseReference `json:"licenses,omitempty"` // list of licenses
+	Name     string                      `json:"name"`               // the name
+	Purl     string                      `json:"purl,omitempty"`     // the package URL
+	Type     string                      `json:"type"`               // the type like `application` or `library`
+	Version  string                      `json:"version,omitempty"`  // the version
+}
+
+// CycloneDXLicense represents value in CycloneDXLicenseReference.License property
+type CycloneDXLicense struct {
+	Id string `json:"id"` // the SPDX ID
+}
+
+// CycloneDXLicenseReference represents an item in CycloneDXComponent.Licenses array
+type CycloneDXLicenseReference struct {
+	License CycloneDXLicense `json:"license"` // the license
+}
+
+// CycloneDXMetadata represents value in CycloneDXBom.Metadata property
+type CycloneDXMetadata struct {
+	Component *CycloneDXComponent `json:"component,omitempty"` // the component the document describes
+	Timestamp string              `json:"timestamp,omitempty"` // creation time in ISO 8601 format
+	Tools     *CycloneDXTools     `json:"tools,omitempty"`     // the tools which created the document
+}
+
+// CycloneDXTools represents value in CycloneDXMetadata.Tools property
+type CycloneDXTools struct {
+	Components []CycloneDXComponent `json:"components"` // list of tools
+}
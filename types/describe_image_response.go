@@ -5,4 +5,5 @@ package types
 type DescribeImageResponse struct {
 	Description string `json:"description" yaml:"description"` // the long description for aria-description maybe
 	Label       string `json:"label" yaml:"label"`             // the label for aria-label maybe
+	Raw         string `json:"-" yaml:"-"`                     // the raw JSON content of the model before post-processing
 }
@@ -0,0 +1,89 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/mkloubert/go-package-manager/constants"
+)
+
+// GenerateProjectSession stores the state of a `generate project` session,
+// so it can be resumed later
+type GenerateProjectSession struct {
+	// the chat history
+	History []ChatAIMessage `json:"history,omitempty"`
+	// the last response of the AI
+	LastResponse *GenerateProjectStepsResponse `json:"last_response,omitempty"`
+	// the URL / module name of the project
+	ProjectUrl string `json:"project_url,omitempty"`
+	// the user messages, in the order they have been sent
+	UserMessages []string `json:"user_messages,omitempty"`
+}
+
+// app.GetGenerateProjectSessionFilePath() - returns the path of the file
+// inside `<GPM-ROOT>/sessions` folder, which stores the state of
+// a `generate project` session for a specific output directory
+func (app *AppContext) GetGenerateProjectSessionFilePath(outDir string) (string, error) {
+	rootPath, err := app.GetRootPath()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(outDir))
+
+	return path.Join(rootPath, "sessions", fmt.Sprintf("generate-%x.json", hash[:8])), nil
+}
+
+// LoadGenerateProjectSession() - loads a `GenerateProjectSession` from a JSON file
+func LoadGenerateProjectSession(sessionFilePath string) (GenerateProjectSession, error) {
+	var session GenerateProjectSession
+
+	jsonData, err := os.ReadFile(sessionFilePath)
+	if err != nil {
+		return session, err
+	}
+
+	err = json.Unmarshal(jsonData, &session)
+
+	return session, err
+}
+
+// s.SaveTo() - saves this session as JSON file
+func (s *GenerateProjectSession) SaveTo(sessionFilePath string) error {
+	jsonData, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(path.Dir(sessionFilePath), constants.DefaultDirMode)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(sessionFilePath, jsonData, constants.DefaultFileMode)
+}
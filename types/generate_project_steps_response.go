@@ -22,11 +22,83 @@
 
 package types
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// GenerateProjectStepTypeFile is the type of a step that creates a file
+	GenerateProjectStepTypeFile = "file"
+	// GenerateProjectStepTypeInstallModule is the type of a step that installs a module
+	GenerateProjectStepTypeInstallModule = "install_module"
+)
+
+// GenerateProjectStep stores a single step of a `GenerateProjectStepsResponse`
+type GenerateProjectStep struct {
+	// the content of the file, if type is `file`
+	Content string `json:"content,omitempty"`
+	// the description of the step
+	Description string `json:"description,omitempty"`
+	// the URL of the module, if type is `install_module`
+	ModuleUrl string `json:"module_url,omitempty"`
+	// the relative path of the file, if type is `file`
+	RelativeFilePath string `json:"relative_file_path,omitempty"`
+	// the title of the step
+	Title string `json:"title,omitempty"`
+	// the type
+	Type string `json:"type,omitempty"`
+}
+
 // GenerateProjectStepsResponse stores the response of a AI chat request
 // that stores the steps to generate and setup a project
 type GenerateProjectStepsResponse struct {
 	// the final summary from the AI
 	FinalSummary string `json:"final_summary,omitempty"`
 	// The steps
-	Steps []map[string]interface{} `json:"steps,omitempty"`
+	Steps []GenerateProjectStep `json:"steps,omitempty"`
+}
+
+// s.IsKnownType() - returns `true` if the type of the step is supported
+func (s *GenerateProjectStep) IsKnownType() bool {
+	switch s.Type {
+	case GenerateProjectStepTypeFile, GenerateProjectStepTypeInstallModule:
+		return true
+	}
+
+	return false
+}
+
+// s.Validate() - checks if all required fields of the step are set;
+// steps with unknown types are not checked
+func (s *GenerateProjectStep) Validate() error {
+	if strings.TrimSpace(s.Type) == "" {
+		return errors.New("missing 'type'")
+	}
+
+	switch s.Type {
+	case GenerateProjectStepTypeFile:
+		if strings.TrimSpace(s.RelativeFilePath) == "" {
+			return errors.New("missing 'relative_file_path'")
+		}
+	case GenerateProjectStepTypeInstallModule:
+		if strings.TrimSpace(s.ModuleUrl) == "" {
+			return errors.New("missing 'module_url'")
+		}
+	}
+
+	return nil
+}
+
+// r.Validate() - checks all steps of the response
+func (r *GenerateProjectStepsResponse) Validate() error {
+	for i, step := range r.Steps {
+		err := step.Validate()
+		if err != nil {
+			return fmt.Errorf("invalid step #%v: %w", i+1, err)
+		}
+	}
+
+	return nil
 }
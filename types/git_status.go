@@ -0,0 +1,95 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"bufio"
+	"strings"
+)
+
+// GitStatus stores the parsed output of `git status --porcelain`
+type GitStatus struct {
+	Files []GitStatusFile // list of changed files
+}
+
+// GitStatusFile represents an item in GitStatus.Files array
+type GitStatusFile struct {
+	Path   string // the relative path
+	Status string // the two letter status code like ` M` or `??`
+}
+
+// ParseGitStatus() - parses the output of `git status --porcelain`
+func ParseGitStatus(output string) *GitStatus {
+	status := &GitStatus{
+		Files: []GitStatusFile{},
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) < 4 {
+			continue
+		}
+
+		status.Files = append(status.Files, GitStatusFile{
+			Path:   strings.TrimSpace(line[3:]),
+			Status: line[0:2],
+		})
+	}
+
+	return status
+}
+
+// s.CountUncommitted() - returns the number of tracked files with uncommitted changes
+func (s *GitStatus) CountUncommitted() int {
+	count := 0
+	for _, f := range s.Files {
+		if !f.IsUntracked() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// s.CountUntracked() - returns the number of untracked files
+func (s *GitStatus) CountUntracked() int {
+	count := 0
+	for _, f := range s.Files {
+		if f.IsUntracked() {
+			count++
+		}
+	}
+
+	return count
+}
+
+// s.IsClean() - checks if working tree has no changes
+func (s *GitStatus) IsClean() bool {
+	return len(s.Files) == 0
+}
+
+// f.IsUntracked() - checks if file is not tracked by git
+func (f *GitStatusFile) IsUntracked() bool {
+	return f.Status == "??"
+}
@@ -0,0 +1,318 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GitHubClient - a simple client for the GitHub REST API,
+// which implements `ReleaseProvider`
+type GitHubClient struct {
+	Owner      string // the owner of the repository
+	Repository string // the name of the repository
+	Token      string // the API token
+	UserAgent  string // the user agent
+
+	releases map[string]*GitHubRelease // created or loaded releases by tag name
+}
+
+// GitHubRelease - a release of a GitHub repository
+type GitHubRelease struct {
+	Assets    []GitHubReleaseAsset `json:"assets,omitempty"`     // list of assets
+	HtmlUrl   string               `json:"html_url,omitempty"`   // the URL to the web page
+	Id        int64                `json:"id,omitempty"`         // the ID
+	Name      string               `json:"name,omitempty"`       // the name / title
+	TagName   string               `json:"tag_name,omitempty"`   // the name of the tag
+	UploadUrl string               `json:"upload_url,omitempty"` // the URL template for uploads
+}
+
+// GitHubReleaseAsset - an asset of a GitHubRelease
+type GitHubReleaseAsset struct {
+	BrowserDownloadUrl string `json:"browser_download_url,omitempty"` // the download URL
+	Id                 int64  `json:"id,omitempty"`                   // the ID
+	Name               string `json:"name,omitempty"`                 // the name of the file
+	Size               int64  `json:"size,omitempty"`                 // the size in bytes
+}
+
+// ParseGitHubRepositoryUrl() - extracts owner and name of a repository from
+// a git remote URL like `https://github.com/owner/repo.git` or
+// `git@github.com:owner/repo.git`
+func ParseGitHubRepositoryUrl(remoteUrl string) (string, string, error) {
+	r := regexp.MustCompile(`^(?:(?:https?|ssh|git)://(?:[^@/]+@)?github\.com(?::\d+)?/|[^@\s]+@github\.com:)([^/\s]+)/([^/\s]+?)(?:\.git)?/?$`)
+
+	matches := r.FindStringSubmatch(strings.TrimSpace(remoteUrl))
+	if len(matches) != 3 {
+		return "", "", fmt.Errorf("'%v' is no GitHub repository", remoteUrl)
+	}
+
+	return matches[1], matches[2], nil
+}
+
+// c.CreateRelease() - creates a new release for an existing tag
+func (c *GitHubClient) CreateRelease(options CreateReleaseOptions) (*ReleaseInfo, error) {
+	tagName := strings.TrimSpace(options.TagName)
+	if tagName == "" {
+		return nil, fmt.Errorf("no tag name defined")
+	}
+
+	name := strings.TrimSpace(options.Name)
+	if name == "" {
+		name = tagName
+	}
+
+	body := map[string]interface{}{
+		"body":       options.Body,
+		"draft":      options.Draft,
+		"name":       name,
+		"prerelease": options.Prerelease,
+		"tag_name":   tagName,
+	}
+
+	jsonData, err := json.Marshal(&body)
+	if err != nil {
+		return nil, err
+	}
+
+	var release GitHubRelease
+	err = c.sendRequest(
+		"POST", c.getRepositoryApiUrl("releases"),
+		"application/json", bytes.NewBuffer(jsonData), int64(len(jsonData)),
+		201, &release,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// drafts cannot be loaded by tag name later
+	c.setRelease(&release)
+
+	return &ReleaseInfo{
+		TagName: release.TagName,
+		Url:     release.HtmlUrl,
+	}, nil
+}
+
+// c.DeleteReleaseAsset() - deletes an asset of a release by its ID
+func (c *GitHubClient) DeleteReleaseAsset(assetId int64) error {
+	return c.sendRequest(
+		"DELETE", c.getRepositoryApiUrl(fmt.Sprintf("releases/assets/%v", assetId)),
+		"", nil, 0,
+		204, nil,
+	)
+}
+
+// c.GetName() - returns the name of the provider
+func (c *GitHubClient) GetName() string {
+	return "github"
+}
+
+// c.GetReleaseByTag() - returns an existing release by the name of its tag
+func (c *GitHubClient) GetReleaseByTag(tagName string) (*GitHubRelease, error) {
+	tagName = strings.TrimSpace(tagName)
+	if tagName == "" {
+		return nil, fmt.Errorf("no tag name defined")
+	}
+
+	var release GitHubRelease
+	err := c.sendRequest(
+		"GET", c.getRepositoryApiUrl("releases/tags/"+url.PathEscape(tagName)),
+		"", nil, 0,
+		200, &release,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.setRelease(&release)
+
+	return &release, nil
+}
+
+// c.GetRepository() - returns the full name of the repository
+func (c *GitHubClient) GetRepository() string {
+	return fmt.Sprintf("%v/%v", c.Owner, c.Repository)
+}
+
+func (c *GitHubClient) getRepositoryApiUrl(p string) string {
+	return fmt.Sprintf(
+		"https://api.github.com/repos/%v/%v/%v",
+		url.PathEscape(c.Owner), url.PathEscape(c.Repository), p,
+	)
+}
+
+func (c *GitHubClient) getRelease(tagName string) (*GitHubRelease, error) {
+	release, ok := c.releases[tagName]
+	if ok {
+		return release, nil
+	}
+
+	return c.GetReleaseByTag(tagName)
+}
+
+func (c *GitHubClient) setRelease(release *GitHubRelease) {
+	if c.releases == nil {
+		c.releases = map[string]*GitHubRelease{}
+	}
+
+	c.releases[release.TagName] = release
+}
+
+func (c *GitHubClient) sendRequest(
+	method string, requestUrl string,
+	contentType string, body io.Reader, contentLength int64,
+	expectedStatusCode int, result interface{},
+) error {
+	req, err := http.NewRequest(method, requestUrl, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.ContentLength = contentLength
+	}
+
+	// setup ...
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	// ... and finally send the request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != expectedStatusCode {
+		return fmt.Errorf("unexpected response from '%v': %v %v", requestUrl, resp.Status, strings.TrimSpace(string(responseData)))
+	}
+
+	if result != nil {
+		return json.Unmarshal(responseData, result)
+	}
+	return nil
+}
+
+// c.UploadAsset() - uploads a local file as asset to the release of a tag,
+// where an existing asset with the same name is deleted before
+func (c *GitHubClient) UploadAsset(tagName string, filePath string, options ...UploadReleaseAssetOptions) error {
+	release, err := c.getRelease(strings.TrimSpace(tagName))
+	if err != nil {
+		return err
+	}
+
+	assetName := filepath.Base(filePath)
+
+	remainingAssets := []GitHubReleaseAsset{}
+	for _, existingAsset := range release.Assets {
+		if existingAsset.Name == assetName {
+			err := c.DeleteReleaseAsset(existingAsset.Id)
+			if err != nil {
+				return err
+			}
+		} else {
+			remainingAssets = append(remainingAssets, existingAsset)
+		}
+	}
+	release.Assets = remainingAssets
+
+	asset, err := c.UploadReleaseAsset(release, filePath, options...)
+	if err != nil {
+		return err
+	}
+
+	release.Assets = append(release.Assets, *asset)
+	return nil
+}
+
+// c.UploadReleaseAsset() - uploads a local file as asset to a release
+func (c *GitHubClient) UploadReleaseAsset(release *GitHubRelease, filePath string, options ...UploadReleaseAssetOptions) (*GitHubReleaseAsset, error) {
+	var progress io.Writer
+	for _, o := range options {
+		if o.Progress != nil {
+			progress = o.Progress
+		}
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// remove templates like `{?name,label}`
+	uploadUrl := release.UploadUrl
+	templateStart := strings.Index(uploadUrl, "{")
+	if templateStart > -1 {
+		uploadUrl = uploadUrl[:templateStart]
+	}
+	if uploadUrl == "" {
+		uploadUrl = fmt.Sprintf(
+			"https://uploads.github.com/repos/%v/%v/releases/%v/assets",
+			url.PathEscape(c.Owner), url.PathEscape(c.Repository), release.Id,
+		)
+	}
+	uploadUrl += "?name=" + url.QueryEscape(filepath.Base(filePath))
+
+	var body io.Reader = file
+	if progress != nil {
+		body = io.TeeReader(file, progress)
+	}
+
+	var asset GitHubReleaseAsset
+	err = c.sendRequest(
+		"POST", uploadUrl,
+		"application/octet-stream", body, stat.Size(),
+		201, &asset,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
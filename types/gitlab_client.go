@@ -0,0 +1,271 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GitLabClient - a simple client for the GitLab REST API,
+// which implements `ReleaseProvider`
+type GitLabClient struct {
+	BaseUrl   string // the base URL of the instance, like `https://gitlab.com`
+	Project   string // the path of the project with namespace, like `group/project`
+	Token     string // the API token
+	UserAgent string // the user agent
+}
+
+// GitLabReleaseLink - a link of a GitLab release, which represents an asset
+type GitLabReleaseLink struct {
+	Id       int64  `json:"id,omitempty"`        // the ID
+	LinkType string `json:"link_type,omitempty"` // the type like `other` or `package`
+	Name     string `json:"name,omitempty"`      // the name
+	Url      string `json:"url,omitempty"`       // the URL
+}
+
+// c.CreateRelease() - creates a new release for an existing tag,
+// where `Draft` and `Prerelease` are not supported by GitLab
+func (c *GitLabClient) CreateRelease(options CreateReleaseOptions) (*ReleaseInfo, error) {
+	tagName := strings.TrimSpace(options.TagName)
+	if tagName == "" {
+		return nil, fmt.Errorf("no tag name defined")
+	}
+
+	name := strings.TrimSpace(options.Name)
+	if name == "" {
+		name = tagName
+	}
+
+	body := map[string]interface{}{
+		"description": options.Body,
+		"name":        name,
+		"tag_name":    tagName,
+	}
+
+	jsonData, err := json.Marshal(&body)
+	if err != nil {
+		return nil, err
+	}
+
+	var release struct {
+		Links struct {
+			Self string `json:"self,omitempty"`
+		} `json:"_links,omitempty"`
+		TagName string `json:"tag_name,omitempty"`
+	}
+	err = c.sendRequest(
+		"POST", c.getProjectApiUrl("releases"),
+		"application/json", bytes.NewBuffer(jsonData),
+		201, &release,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReleaseInfo{
+		TagName: release.TagName,
+		Url:     release.Links.Self,
+	}, nil
+}
+
+// c.DeleteReleaseLink() - deletes a link of a release by its ID
+func (c *GitLabClient) DeleteReleaseLink(tagName string, linkId int64) error {
+	return c.sendRequest(
+		"DELETE", c.getProjectApiUrl(fmt.Sprintf("releases/%v/assets/links/%v", url.PathEscape(tagName), linkId)),
+		"", nil,
+		200, nil,
+	)
+}
+
+// c.GetName() - returns the name of the provider
+func (c *GitLabClient) GetName() string {
+	return "gitlab"
+}
+
+// c.GetReleaseLinks() - returns the links of the release of a tag
+func (c *GitLabClient) GetReleaseLinks(tagName string) ([]GitLabReleaseLink, error) {
+	links := []GitLabReleaseLink{}
+	err := c.sendRequest(
+		"GET", c.getProjectApiUrl(fmt.Sprintf("releases/%v/assets/links", url.PathEscape(tagName))),
+		"", nil,
+		200, &links,
+	)
+
+	return links, err
+}
+
+// c.GetRepository() - returns the full name of the repository
+func (c *GitLabClient) GetRepository() string {
+	return c.Project
+}
+
+func (c *GitLabClient) getProjectApiUrl(p string) string {
+	return fmt.Sprintf(
+		"%v/api/v4/projects/%v/%v",
+		strings.TrimSuffix(c.BaseUrl, "/"), url.PathEscape(c.Project), p,
+	)
+}
+
+func (c *GitLabClient) sendRequest(
+	method string, requestUrl string,
+	contentType string, body io.Reader,
+	expectedStatusCode int, result interface{},
+) error {
+	req, err := http.NewRequest(method, requestUrl, body)
+	if err != nil {
+		return err
+	}
+
+	// setup ...
+	req.Header.Set("PRIVATE-TOKEN", c.Token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	// ... and finally send the request
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != expectedStatusCode {
+		return fmt.Errorf("unexpected response from '%v': %v %v", requestUrl, resp.Status, strings.TrimSpace(string(responseData)))
+	}
+
+	if result != nil {
+		return json.Unmarshal(responseData, result)
+	}
+	return nil
+}
+
+// c.UploadAsset() - uploads a local file to the project and links it
+// to the release of a tag, where an existing link with the same name is
+// deleted before
+func (c *GitLabClient) UploadAsset(tagName string, filePath string, options ...UploadReleaseAssetOptions) error {
+	var progress io.Writer
+	for _, o := range options {
+		if o.Progress != nil {
+			progress = o.Progress
+		}
+	}
+
+	tagName = strings.TrimSpace(tagName)
+	assetName := filepath.Base(filePath)
+
+	existingLinks, err := c.GetReleaseLinks(tagName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var fileReader io.Reader = file
+	if progress != nil {
+		fileReader = io.TeeReader(file, progress)
+	}
+
+	// stream file as multipart data
+	bodyReader, bodyWriter := io.Pipe()
+	multipartWriter := multipart.NewWriter(bodyWriter)
+	go func() {
+		part, err := multipartWriter.CreateFormFile("file", assetName)
+		if err != nil {
+			bodyWriter.CloseWithError(err)
+			return
+		}
+
+		_, err = io.Copy(part, fileReader)
+		if err != nil {
+			bodyWriter.CloseWithError(err)
+			return
+		}
+
+		bodyWriter.CloseWithError(multipartWriter.Close())
+	}()
+
+	var upload struct {
+		FullPath string `json:"full_path,omitempty"`
+		Url      string `json:"url,omitempty"`
+	}
+	err = c.sendRequest(
+		"POST", c.getProjectApiUrl("uploads"),
+		multipartWriter.FormDataContentType(), bodyReader,
+		201, &upload,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, l := range existingLinks {
+		if l.Name == assetName {
+			err := c.DeleteReleaseLink(tagName, l.Id)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	uploadPath := upload.FullPath
+	if uploadPath == "" {
+		uploadPath = fmt.Sprintf("/%v%v", c.Project, upload.Url)
+	}
+
+	link := map[string]interface{}{
+		"link_type": "package",
+		"name":      assetName,
+		"url":       strings.TrimSuffix(c.BaseUrl, "/") + uploadPath,
+	}
+
+	jsonData, err := json.Marshal(&link)
+	if err != nil {
+		return err
+	}
+
+	return c.sendRequest(
+		"POST", c.getProjectApiUrl(fmt.Sprintf("releases/%v/assets/links", url.PathEscape(tagName))),
+		"application/json", bytes.NewBuffer(jsonData),
+		201, nil,
+	)
+}
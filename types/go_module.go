@@ -25,7 +25,9 @@ package types
 // OsvDevResponse stores information about a successful response
 // from osv.dev API
 type GoModule struct {
+	Dir      *string `json:"Dir,omitempty"`      // the directory with the files, if downloaded
 	Indirect *bool   `json:"Indirect,omitempty"` // indirect module or not
+	Main     *bool   `json:"Main,omitempty"`     // is main module or not
 	Path     *string `json:"Path,omitempty"`     // the path
 	Version  *string `json:"Version,omitempty"`  // the version
 }
@@ -0,0 +1,87 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"bufio"
+	"strings"
+)
+
+// GoModuleGraph stores the parsed output of `go mod graph`
+type GoModuleGraph struct {
+	Children map[string][]string // the requirements of a module, in the order of the output
+	Edges    []GoModuleGraphEdge // all edges in the order of the output
+	Root     string              // the main module
+}
+
+// GoModuleGraphEdge represents an item in GoModuleGraph.Edges array
+type GoModuleGraphEdge struct {
+	From string // the module with version, which requires `To`
+	To   string // the required module with version
+}
+
+// ParseGoModuleGraph() - parses the output of `go mod graph`
+func ParseGoModuleGraph(output string) (*GoModuleGraph, error) {
+	graph := &GoModuleGraph{
+		Children: map[string][]string{},
+		Edges:    []GoModuleGraphEdge{},
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		// read line and split into
+		// parts from space as separator
+		parts := strings.Fields(scanner.Text())
+		if len(parts) != 2 {
+			continue
+		}
+
+		from := strings.TrimSpace(parts[0])
+		to := strings.TrimSpace(parts[1])
+
+		if graph.Root == "" {
+			graph.Root = from // first module is the main module
+		}
+
+		graph.Children[from] = append(graph.Children[from], to)
+		graph.Edges = append(graph.Edges, GoModuleGraphEdge{
+			From: from,
+			To:   to,
+		})
+	}
+
+	return graph, scanner.Err()
+}
+
+// SplitGoModuleAndVersion() - splits a `<module>@<version>` string
+// from `go mod graph` into name and version
+func SplitGoModuleAndVersion(nameAndVersion string) (string, string) {
+	nameAndVersion = strings.TrimSpace(nameAndVersion)
+
+	sepIndex := strings.Index(nameAndVersion, "@")
+	if sepIndex > -1 {
+		return strings.TrimSpace(nameAndVersion[0:sepIndex]), strings.TrimSpace(nameAndVersion[sepIndex+1:])
+	}
+
+	return nameAndVersion, ""
+}
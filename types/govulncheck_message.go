@@ -0,0 +1,118 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// GovulncheckMessage represents a single JSON message
+// of the output of `govulncheck -json`
+type GovulncheckMessage struct {
+	Finding *GovulncheckFinding `json:"finding,omitempty"` // a finding
+	Osv     *GovulncheckOsv     `json:"osv,omitempty"`     // an OSV entry
+}
+
+// GovulncheckFinding represents value in GovulncheckMessage.Finding property
+type GovulncheckFinding struct {
+	FixedVersion string             `json:"fixed_version,omitempty"` // the version, which fixes the issue
+	Osv          string             `json:"osv,omitempty"`           // the OSV ID
+	Trace        []GovulncheckFrame `json:"trace,omitempty"`         // the trace from vulnerable symbol to the module
+}
+
+// GovulncheckFrame represents an item in GovulncheckFinding.Trace array
+type GovulncheckFrame struct {
+	Function string `json:"function,omitempty"` // the name of the function
+	Module   string `json:"module,omitempty"`   // the module path
+	Package  string `json:"package,omitempty"`  // the package path
+	Receiver string `json:"receiver,omitempty"` // the receiver type
+	Version  string `json:"version,omitempty"`  // the module version
+}
+
+// GovulncheckOsv represents value in GovulncheckMessage.Osv property
+type GovulncheckOsv struct {
+	Aliases []string `json:"aliases,omitempty"` // aliases like CVE or GHSA IDs
+	Id      string   `json:"id,omitempty"`      // the ID
+	Summary string   `json:"summary,omitempty"` // summary
+}
+
+// GovulncheckResult stores the merged findings of a `govulncheck -json` run
+type GovulncheckResult struct {
+	Findings map[string][]GovulncheckFinding // findings grouped by OSV ID
+	Osvs     map[string]GovulncheckOsv       // OSV entries by ID
+}
+
+// f.IsReachable() - checks if the vulnerable code is called by the project
+func (f *GovulncheckFinding) IsReachable() bool {
+	return len(f.Trace) > 0 && strings.TrimSpace(f.Trace[0].Function) != ""
+}
+
+// r.GetReachableIds() - returns the IDs of all OSV entries with at least
+// one reachable finding
+func (r *GovulncheckResult) GetReachableIds() []string {
+	ids := []string{}
+
+	for id, findings := range r.Findings {
+		for _, f := range findings {
+			if f.IsReachable() {
+				ids = append(ids, id)
+				break
+			}
+		}
+	}
+
+	return ids
+}
+
+// ParseGovulncheckResult() - reads the stream of JSON messages from the
+// output of `govulncheck -json`
+func ParseGovulncheckResult(r io.Reader) (*GovulncheckResult, error) {
+	result := &GovulncheckResult{
+		Findings: map[string][]GovulncheckFinding{},
+		Osvs:     map[string]GovulncheckOsv{},
+	}
+
+	decoder := json.NewDecoder(r)
+	for {
+		var msg GovulncheckMessage
+
+		err := decoder.Decode(&msg)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		if msg.Osv != nil {
+			result.Osvs[msg.Osv.Id] = *msg.Osv
+		}
+		if msg.Finding != nil {
+			result.Findings[msg.Finding.Osv] = append(result.Findings[msg.Finding.Osv], *msg.Finding)
+		}
+	}
+
+	return result, nil
+}
@@ -31,16 +31,18 @@ import (
 
 // GpmFile stores all data of a gpm.y(a)ml file.
 type GpmFile struct {
-	Contributors []GpmFileContributor `yaml:"contributors,omitempty"` // list of contributors
-	Description  string               `yaml:"description,omitempty"`  // the description
-	DisplayName  string               `yaml:"display_name,omitempty"` // the display name
-	Donations    map[string]string    `yaml:"donations,omitempty"`    // one or more donation links
-	Files        []string             `yaml:"files,omitempty"`        // whitelist of file patterns which are used by pack command for example
-	Homepage     string               `yaml:"homepage,omitempty"`     // the homepage
-	License      string               `yaml:"license,omitempty"`      // the license
-	Name         string               `yaml:"name,omitempty"`         // the name
-	Repositories []GpmFileRepository  `yaml:"repositories,omitempty"` // source code repository information
-	Scripts      map[string]string    `yaml:"scripts,omitempty"`      // one or more scripts
+	Contributors []GpmFileContributor     `yaml:"contributors,omitempty"` // list of contributors
+	Description  string                   `yaml:"description,omitempty"`  // the description
+	DisplayName  string                   `yaml:"display_name,omitempty"` // the display name
+	Donations    map[string]string        `yaml:"donations,omitempty"`    // one or more donation links
+	Files        []string                 `yaml:"files,omitempty"`        // whitelist of file patterns which are used by pack command for example
+	Homepage     string                   `yaml:"homepage,omitempty"`     // the homepage
+	Hooks        map[string]string        `yaml:"hooks,omitempty"`        // names of scripts by module path, which are executed after installing the module
+	License      string                   `yaml:"license,omitempty"`      // the license
+	Name         string                   `yaml:"name,omitempty"`         // the name
+	Repositories []GpmFileRepository      `yaml:"repositories,omitempty"` // source code repository information
+	Scripts      map[string]GpmFileScript `yaml:"scripts,omitempty"`      // one or more scripts
+	Tools        []string                 `yaml:"tools,omitempty"`        // development tools like `golang.org/x/tools/cmd/stringer@latest`, installed by `go install`
 }
 
 // GpmFileContributor is an item inside `Contributors` of a
@@ -59,6 +61,14 @@ type GpmFileRepository struct {
 	Url  string `yaml:"url,omitempty"`  // the url
 }
 
+// GpmFileScript is an item inside `Scripts` of a
+// `GpmFile` instance, which can be a plain string
+// or an object with `run` and `needs` fields
+type GpmFileScript struct {
+	Needs []string `yaml:"needs,omitempty"` // names of scripts which have to be executed before
+	Run   string   `yaml:"run,omitempty"`   // the command to execute
+}
+
 // GetFilesSectionByEnvSafe() - will return environment specific `files` section in `gpm.yaml`
 // file, if exists, otherwise the default one
 func (g *GpmFile) GetFilesSectionByEnvSafe(envName string) []string {
@@ -84,6 +94,43 @@ func (g *GpmFile) GetFilesSectionByEnvSafe(envName string) []string {
 	return g.Files
 }
 
+// s.MarshalYAML() - writes a script as plain string if it has no dependencies
+func (s GpmFileScript) MarshalYAML() (interface{}, error) {
+	if len(s.Needs) == 0 {
+		return s.Run, nil
+	}
+
+	type gpmFileScriptObject GpmFileScript
+	return gpmFileScriptObject(s), nil
+}
+
+// s.UnmarshalYAML() - reads a script from a plain string or an object
+func (s *GpmFileScript) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var run string
+	err := unmarshal(&run)
+	if err == nil {
+		s.Needs = []string{}
+		s.Run = run
+
+		return nil
+	}
+
+	type gpmFileScriptObject GpmFileScript
+
+	var obj gpmFileScriptObject
+	err = unmarshal(&obj)
+	if err != nil {
+		return err
+	}
+
+	*s = GpmFileScript(obj)
+	if s.Needs == nil {
+		s.Needs = []string{}
+	}
+
+	return nil
+}
+
 // LoadGpmFile() - Loads a gpm.yaml file via a file path
 func LoadGpmFile(gpmFilePath string) (GpmFile, error) {
 	var gpm GpmFile
@@ -97,11 +144,17 @@ func LoadGpmFile(gpmFilePath string) (GpmFile, error) {
 		if gpm.Files == nil {
 			gpm.Files = []string{}
 		}
+		if gpm.Hooks == nil {
+			gpm.Hooks = map[string]string{}
+		}
 		if gpm.Repositories == nil {
 			gpm.Repositories = []GpmFileRepository{}
 		}
 		if gpm.Scripts == nil {
-			gpm.Scripts = map[string]string{}
+			gpm.Scripts = map[string]GpmFileScript{}
+		}
+		if gpm.Tools == nil {
+			gpm.Tools = []string{}
 		}
 	}()
 
@@ -0,0 +1,203 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package types
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+)
+
+func TestGpmFileScriptUnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name          string
+		yaml          string
+		expectedRun   string
+		expectedNeeds []string
+	}{
+		{
+			name:          "legacy string",
+			yaml:          `scripts: {build: "go build ."}`,
+			expectedRun:   "go build .",
+			expectedNeeds: []string{},
+		},
+		{
+			name:          "object without needs",
+			yaml:          "scripts:\n  build:\n    run: go build .\n",
+			expectedRun:   "go build .",
+			expectedNeeds: []string{},
+		},
+		{
+			name:          "object with needs",
+			yaml:          "scripts:\n  build:\n    run: go build .\n    needs: [lint, test]\n",
+			expectedRun:   "go build .",
+			expectedNeeds: []string{"lint", "test"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gpm GpmFile
+
+			err := yaml.Unmarshal([]byte(test.yaml), &gpm)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			script, ok := gpm.Scripts["build"]
+			if !ok {
+				t.Fatal("script 'build' not found")
+			}
+
+			if script.Run != test.expectedRun {
+				t.Errorf("expected run '%v', got '%v'", test.expectedRun, script.Run)
+			}
+			if !slices.Equal(script.Needs, test.expectedNeeds) {
+				t.Errorf("expected needs %v, got %v", test.expectedNeeds, script.Needs)
+			}
+		})
+	}
+}
+
+func TestGpmFileScriptMarshalYAML(t *testing.T) {
+	gpm := GpmFile{
+		Scripts: map[string]GpmFileScript{
+			"build": {Run: "go build .", Needs: []string{"lint"}},
+			"lint":  {Run: "go vet ./..."},
+		},
+	}
+
+	yamlData, err := yaml.Marshal(&gpm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// scripts without dependencies keep the plain string form
+	if !strings.Contains(string(yamlData), "lint: go vet ./...") {
+		t.Errorf("script without needs is not a plain string:\n%v", string(yamlData))
+	}
+
+	var roundTrip GpmFile
+	err = yaml.Unmarshal(yamlData, &roundTrip)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if roundTrip.Scripts["build"].Run != "go build ." || !slices.Equal(roundTrip.Scripts["build"].Needs, []string{"lint"}) {
+		t.Errorf("unexpected script after round trip: %+v", roundTrip.Scripts["build"])
+	}
+}
+
+func TestResolveScriptOrder(t *testing.T) {
+	t.Setenv("GPM_ENV", "")
+
+	tests := []struct {
+		name          string
+		scripts       map[string]GpmFileScript
+		scriptName    string
+		expectedOrder []string
+		expectedError string
+	}{
+		{
+			name: "single",
+			scripts: map[string]GpmFileScript{
+				"build": {Run: "go build ."},
+			},
+			scriptName:    "build",
+			expectedOrder: []string{"build"},
+		},
+		{
+			name: "linear chain",
+			scripts: map[string]GpmFileScript{
+				"build": {Run: "go build .", Needs: []string{"test"}},
+				"lint":  {Run: "go vet ./..."},
+				"test":  {Run: "go test ./...", Needs: []string{"lint"}},
+			},
+			scriptName:    "build",
+			expectedOrder: []string{"lint", "test", "build"},
+		},
+		{
+			name: "shared dependency runs once",
+			scripts: map[string]GpmFileScript{
+				"all":   {Run: "echo done", Needs: []string{"build", "test"}},
+				"build": {Run: "go build .", Needs: []string{"lint"}},
+				"lint":  {Run: "go vet ./..."},
+				"test":  {Run: "go test ./...", Needs: []string{"lint"}},
+			},
+			scriptName:    "all",
+			expectedOrder: []string{"lint", "build", "test", "all"},
+		},
+		{
+			name: "cycle",
+			scripts: map[string]GpmFileScript{
+				"a": {Run: "echo a", Needs: []string{"b"}},
+				"b": {Run: "echo b", Needs: []string{"c"}},
+				"c": {Run: "echo c", Needs: []string{"a"}},
+			},
+			scriptName:    "a",
+			expectedError: "cycle detected in scripts: a -> b -> c -> a",
+		},
+		{
+			name: "self reference",
+			scripts: map[string]GpmFileScript{
+				"a": {Run: "echo a", Needs: []string{"a"}},
+			},
+			scriptName:    "a",
+			expectedError: "cycle detected",
+		},
+		{
+			name: "missing dependency",
+			scripts: map[string]GpmFileScript{
+				"build": {Run: "go build .", Needs: []string{"lint"}},
+			},
+			scriptName:    "build",
+			expectedError: "script 'lint' not found",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			app := &AppContext{
+				GpmFile: GpmFile{
+					Scripts: test.scripts,
+				},
+			}
+
+			order, err := app.ResolveScriptOrder(test.scriptName)
+			if test.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+					t.Errorf("expected error '%v', got %v", test.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(order, test.expectedOrder) {
+				t.Errorf("expected %v, got %v", test.expectedOrder, order)
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+var gpmFileEnvNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_\-\.]+$`)
+
+// known top-level keys of a gpm.yaml file
+var gpmFileKeys = map[string]bool{
+	"contributors": true,
+	"description":  true,
+	"display_name": true,
+	"donations":    true,
+	"files":        true,
+	"homepage":     true,
+	"hooks":        true,
+	"license":      true,
+	"name":         true,
+	"repositories": true,
+	"scripts":      true,
+	"tools":        true,
+}
+
+// GpmFileValidationIssue - an issue found by `ValidateGpmFile()`
+type GpmFileValidationIssue struct {
+	Key     string // the path of the key, like `scripts.build`
+	Line    int    // the line number or 0 if unknown
+	Message string // the message
+}
+
+// gpmFileValidator - helper to collect issues of gpm.yaml data
+type gpmFileValidator struct {
+	issues []GpmFileValidationIssue
+	lines  []string
+}
+
+// i.String() - returns the issue as human readable string
+func (i GpmFileValidationIssue) String() string {
+	s := ""
+	if i.Line > 0 {
+		s += fmt.Sprintf("line %v: ", i.Line)
+	}
+	if i.Key != "" {
+		s += fmt.Sprintf("%v: ", i.Key)
+	}
+
+	return s + i.Message
+}
+
+// ValidateGpmFile() - checks the data of a gpm.yaml file for structural
+// correctness and returns the list of found issues
+func ValidateGpmFile(yamlData []byte) []GpmFileValidationIssue {
+	v := &gpmFileValidator{
+		issues: []GpmFileValidationIssue{},
+		lines:  strings.Split(strings.ReplaceAll(string(yamlData), "\r\n", "\n"), "\n"),
+	}
+
+	var data map[string]interface{}
+	err := yaml.Unmarshal(yamlData, &data)
+	if err != nil {
+		v.issues = append(v.issues, GpmFileValidationIssue{
+			Message: fmt.Sprintf("invalid YAML: %v", err),
+		})
+		return v.issues
+	}
+
+	for _, key := range getSortedMapKeys(data) {
+		value := data[key]
+
+		baseKey, envName, hasEnv := strings.Cut(key, ":")
+		if hasEnv {
+			if baseKey != "files" {
+				v.add(key, "", "environment specific keys are only supported for 'files'")
+				continue
+			}
+			if !gpmFileEnvNameRegex.MatchString(envName) {
+				v.add(key, "", fmt.Sprintf("invalid environment name '%v'", envName))
+				continue
+			}
+		}
+
+		if !gpmFileKeys[baseKey] {
+			v.add(key, "", "unknown key")
+			continue
+		}
+		if value == nil {
+			continue
+		}
+
+		switch baseKey {
+		case "contributors":
+			v.validateListOfObjects(key, value, []string{"homepage", "name", "role"})
+		case "description", "display_name", "homepage", "license", "name":
+			_, ok := value.(string)
+			if !ok {
+				v.add(key, "", "must be a string")
+			}
+		case "donations":
+			v.validateMapOfStrings(key, value)
+		case "files":
+			v.validateFiles(key, value)
+		case "hooks":
+			v.validateHooks(key, value, data["scripts"])
+		case "repositories":
+			v.validateListOfObjects(key, value, []string{"name", "type", "url"})
+		case "scripts":
+			v.validateScripts(key, value)
+		case "tools":
+			v.validateTools(key, value)
+		}
+	}
+
+	return v.issues
+}
+
+func getSortedMapKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (v *gpmFileValidator) add(key string, childKey string, message string) {
+	fullKey := key
+	if childKey != "" {
+		fullKey += "." + childKey
+	}
+
+	v.issues = append(v.issues, GpmFileValidationIssue{
+		Key:     fullKey,
+		Line:    v.findLine(key, childKey),
+		Message: message,
+	})
+}
+
+// v.findLine() - tries to find the line number of a top-level key or
+// of a key inside the block of a top-level key
+func (v *gpmFileValidator) findLine(key string, childKey string) int {
+	isKeyLine := func(line string, k string) bool {
+		trimmed := strings.TrimLeft(line, " \t-")
+		for _, prefix := range []string{k + ":", `"` + k + `":`, "'" + k + "':"} {
+			if strings.HasPrefix(trimmed, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, line := range v.lines {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || !isKeyLine(line, key) {
+			continue
+		}
+		if childKey == "" {
+			return i + 1
+		}
+
+		// search inside the block of the key
+		for j := i + 1; j < len(v.lines); j++ {
+			child := v.lines[j]
+			if child != "" && child[0] != ' ' && child[0] != '\t' && child[0] != '#' {
+				break // next top-level key
+			}
+
+			if isKeyLine(child, childKey) {
+				return j + 1
+			}
+		}
+		return i + 1
+	}
+
+	return 0
+}
+
+func (v *gpmFileValidator) validateFiles(key string, value interface{}) {
+	list, ok := value.([]interface{})
+	if !ok {
+		v.add(key, "", "must be a list of regular expressions or glob patterns")
+		return
+	}
+
+	for i, item := range list {
+		pattern, ok := item.(string)
+		if !ok {
+			v.add(key, fmt.Sprint(i), "must be a string")
+			continue
+		}
+
+		if strings.HasPrefix(pattern, utils.GlobPatternPrefix) {
+			_, err := utils.GlobToRegex(strings.TrimPrefix(pattern, utils.GlobPatternPrefix))
+			if err != nil {
+				v.add(key, fmt.Sprint(i), fmt.Sprintf("invalid glob pattern '%v': %v", pattern, err))
+			}
+			continue
+		}
+
+		_, err := regexp.Compile(pattern)
+		if err != nil {
+			v.add(key, fmt.Sprint(i), fmt.Sprintf("invalid regular expression '%v': %v", pattern, err))
+		}
+	}
+}
+
+func (v *gpmFileValidator) validateHooks(key string, value interface{}, scriptsValue interface{}) {
+	hooks, ok := value.(map[string]interface{})
+	if !ok {
+		v.add(key, "", "must be an object with module paths as keys and script names as values")
+		return
+	}
+
+	scripts, _ := scriptsValue.(map[string]interface{})
+
+	for _, modulePath := range getSortedMapKeys(hooks) {
+		scriptName, ok := hooks[modulePath].(string)
+		if !ok {
+			v.add(key, modulePath, "must be the name of a script")
+			continue
+		}
+
+		if _, ok := scripts[scriptName]; !ok {
+			v.add(key, modulePath, fmt.Sprintf("script '%v' does not exist", scriptName))
+		}
+	}
+}
+
+func (v *gpmFileValidator) validateListOfObjects(key string, value interface{}, knownFields []string) {
+	list, ok := value.([]interface{})
+	if !ok {
+		v.add(key, "", "must be a list")
+		return
+	}
+
+	for i, item := range list {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			v.add(key, fmt.Sprint(i), "must be an object")
+			continue
+		}
+
+		for _, field := range getSortedMapKeys(obj) {
+			isKnown := false
+			for _, kf := range knownFields {
+				if kf == field {
+					isKnown = true
+					break
+				}
+			}
+
+			if !isKnown {
+				v.add(key, fmt.Sprintf("%v.%v", i, field), "unknown key")
+			} else if _, ok := obj[field].(string); !ok && obj[field] != nil {
+				v.add(key, fmt.Sprintf("%v.%v", i, field), "must be a string")
+			}
+		}
+	}
+}
+
+func (v *gpmFileValidator) validateMapOfStrings(key string, value interface{}) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		v.add(key, "", "must be an object")
+		return
+	}
+
+	for _, k := range getSortedMapKeys(obj) {
+		_, ok := obj[k].(string)
+		if !ok {
+			v.add(key, k, "must be a string")
+		}
+	}
+}
+
+func (v *gpmFileValidator) validateScripts(key string, value interface{}) {
+	scripts, ok := value.(map[string]interface{})
+	if !ok {
+		v.add(key, "", "must be an object")
+		return
+	}
+
+	for _, scriptName := range getSortedMapKeys(scripts) {
+		name := scriptName
+		envName, nameWithoutEnv, hasEnv := strings.Cut(scriptName, ":")
+		if hasEnv {
+			if !gpmFileEnvNameRegex.MatchString(envName) {
+				v.add(key, scriptName, fmt.Sprintf("invalid environment name '%v'", envName))
+				continue
+			}
+			name = nameWithoutEnv
+		}
+		if strings.TrimSpace(name) == "" || strings.Contains(name, ":") {
+			v.add(key, scriptName, "invalid script name")
+			continue
+		}
+
+		switch script := scripts[scriptName].(type) {
+		case string:
+			if strings.TrimSpace(script) == "" {
+				v.add(key, scriptName, "empty command")
+			}
+		case map[string]interface{}:
+			for _, field := range getSortedMapKeys(script) {
+				switch field {
+				case "needs":
+					needs, ok := script[field].([]interface{})
+					if !ok {
+						v.add(key, scriptName, "'needs' must be a list of script names")
+						continue
+					}
+
+					for _, n := range needs {
+						neededScript, ok := n.(string)
+						if !ok {
+							v.add(key, scriptName, "'needs' must be a list of script names")
+							continue
+						}
+
+						_, existsDefault := scripts[neededScript]
+						_, existsForEnv := scripts[fmt.Sprintf("%v:%v", envName, neededScript)]
+						if !existsDefault && !(hasEnv && existsForEnv) {
+							v.add(key, scriptName, fmt.Sprintf("needed script '%v' does not exist", neededScript))
+						}
+					}
+				case "run":
+					run, ok := script[field].(string)
+					if !ok {
+						v.add(key, scriptName, "'run' must be a string")
+					} else if strings.TrimSpace(run) == "" {
+						v.add(key, scriptName, "empty command")
+					}
+				default:
+					v.add(key, scriptName, fmt.Sprintf("unknown key '%v'", field))
+				}
+			}
+		default:
+			v.add(key, scriptName, "must be a string or an object with 'run' and 'needs'")
+		}
+	}
+}
+
+func (v *gpmFileValidator) validateTools(key string, value interface{}) {
+	list, ok := value.([]interface{})
+	if !ok {
+		v.add(key, "", "must be a list of module paths")
+		return
+	}
+
+	for i, item := range list {
+		tool, ok := item.(string)
+		if !ok || strings.TrimSpace(tool) == "" {
+			v.add(key, fmt.Sprint(i), "must be a module path like 'golang.org/x/tools/cmd/stringer@latest'")
+		}
+	}
+}
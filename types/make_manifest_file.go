@@ -0,0 +1,112 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/goccy/go-yaml"
+	"github.com/mkloubert/go-package-manager/constants"
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+// MakeManifestFileName is the name of the file inside `<GPM-ROOT>` folder,
+// which stores the sources of executables installed by `make` command
+const MakeManifestFileName = "make.yaml"
+
+// MakeManifestFile stores information of a `make.yaml` file from home folder
+type MakeManifestFile struct {
+	Executables map[string]MakeManifestEntry `yaml:"executables"` // executables in bin folder and their sources
+}
+
+// MakeManifestEntry stores the source of an executable
+// inside a `MakeManifestFile`
+type MakeManifestEntry struct {
+	Name            string `yaml:"name,omitempty"`              // custom name of the output executable of the build
+	NoAutoExtension bool   `yaml:"no_auto_extension,omitempty"` // do not add file extension automatically
+	Ref             string `yaml:"ref,omitempty"`               // the pinned branch, tag or commit
+	Source          string `yaml:"source"`                      // the Git resource
+}
+
+// app.GetMakeManifestFilePath() - returns the path of the `make.yaml` file
+// inside `<GPM-ROOT>` folder
+func (app *AppContext) GetMakeManifestFilePath() (string, error) {
+	rootDir, err := app.GetRootPath()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(rootDir, MakeManifestFileName), nil
+}
+
+// app.LoadMakeManifestFile() - loads the `make.yaml` file
+// or returns an empty one if it does not exist
+func (app *AppContext) LoadMakeManifestFile() (MakeManifestFile, error) {
+	manifest := MakeManifestFile{
+		Executables: map[string]MakeManifestEntry{},
+	}
+
+	manifestFilePath, err := app.GetMakeManifestFilePath()
+	if err != nil {
+		return manifest, err
+	}
+
+	isExisting, err := utils.IsFileExisting(manifestFilePath)
+	if err != nil || !isExisting {
+		return manifest, err
+	}
+
+	app.Debug(fmt.Sprintf("Loading '%v' file ...", manifestFilePath))
+
+	yamlData, err := os.ReadFile(manifestFilePath)
+	if err != nil {
+		return manifest, err
+	}
+
+	err = yaml.Unmarshal(yamlData, &manifest)
+	if manifest.Executables == nil {
+		manifest.Executables = map[string]MakeManifestEntry{}
+	}
+
+	return manifest, err
+}
+
+// app.UpdateMakeManifestFile() - writes the `make.yaml` file
+func (app *AppContext) UpdateMakeManifestFile(manifest MakeManifestFile) error {
+	rootDir, err := app.EnsureRootFolder()
+	if err != nil {
+		return err
+	}
+
+	yamlData, err := yaml.Marshal(&manifest)
+	if err != nil {
+		return err
+	}
+
+	manifestFilePath := path.Join(rootDir, MakeManifestFileName)
+
+	app.Debug(fmt.Sprintf("Updating '%v' file ...", manifestFilePath))
+	return os.WriteFile(manifestFilePath, yamlData, constants.DefaultFileMode)
+}
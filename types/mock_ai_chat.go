@@ -0,0 +1,164 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mkloubert/go-package-manager/constants"
+)
+
+// MockAIChat is an implementation of ChatAI interface
+// with scripted responses, which is useful for tests
+type MockAIChat struct {
+	Conversation []ChatAIMessage // the conversation
+	Model        string          // the current model
+	Requests     []string        // all messages and prompts, which have been sent
+	Responses    []string        // the scripted responses, which are returned in this order
+	SystemPrompt string          // the current system prompt
+	Temperature  float32         // the current temperature
+}
+
+func (c *MockAIChat) nextResponse(message string) (string, error) {
+	c.Requests = append(c.Requests, message)
+
+	if len(c.Responses) == 0 {
+		return "", fmt.Errorf("no more mock responses")
+	}
+
+	response := c.Responses[0]
+	c.Responses = c.Responses[1:]
+
+	return response, nil
+}
+
+func (c *MockAIChat) ClearHistory() {
+	c.Conversation = []ChatAIMessage{}
+}
+
+func (c *MockAIChat) DescribeImage(message string, dataURI string) (DescribeImageResponse, error) {
+	content, err := c.nextResponse(message)
+	if err != nil {
+		return DescribeImageResponse{}, err
+	}
+
+	return get_ai_image_description_from_json(content)
+}
+
+func (c *MockAIChat) DescribeImages(message string, dataURIs []string) ([]DescribeImageResponse, error) {
+	content, err := c.nextResponse(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return get_ai_image_descriptions_from_json(content, len(dataURIs))
+}
+
+func (c *MockAIChat) ExportHistory() []ChatAIMessage {
+	history := make([]ChatAIMessage, 0, len(c.Conversation))
+	history = append(history, c.Conversation...)
+
+	return history
+}
+
+func (c *MockAIChat) GetModel() string {
+	return c.Model
+}
+
+func (c *MockAIChat) GetMoreInfo() string {
+	return ""
+}
+
+func (c *MockAIChat) GetPromptSuffix() string {
+	return ""
+}
+
+func (c *MockAIChat) GetProvider() string {
+	return constants.AIApiMock
+}
+
+func (c *MockAIChat) ImportHistory(history []ChatAIMessage) {
+	c.Conversation = make([]ChatAIMessage, 0, len(history))
+	c.Conversation = append(c.Conversation, history...)
+}
+
+func (c *MockAIChat) RemoveLastTurn() (string, bool) {
+	for i := len(c.Conversation) - 1; i >= 0; i-- {
+		if c.Conversation[i].Role == "user" {
+			message := c.Conversation[i].Content
+			c.Conversation = c.Conversation[:i]
+
+			return message, true
+		}
+	}
+
+	return "", false
+}
+
+func (c *MockAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkReceiver) error {
+	content, err := c.nextResponse(message)
+	if err != nil {
+		return err
+	}
+
+	c.Conversation = append(
+		c.Conversation,
+		ChatAIMessage{Content: message, Role: "user"},
+		ChatAIMessage{Content: content, Role: "assistant"},
+	)
+
+	return onUpdate(content)
+}
+
+func (c *MockAIChat) SendPrompt(prompt string, onUpdate ChatAIMessageChunkReceiver) error {
+	content, err := c.nextResponse(prompt)
+	if err != nil {
+		return err
+	}
+
+	return onUpdate(content)
+}
+
+func (c *MockAIChat) UpdateModel(modelName string) {
+	c.Model = strings.TrimSpace(modelName)
+}
+
+func (c *MockAIChat) UpdateSystem(systemPrompt string) {
+	c.SystemPrompt = systemPrompt
+
+	c.Conversation = []ChatAIMessage{
+		{
+			Role:    "system",
+			Content: systemPrompt,
+		},
+	}
+}
+
+func (c *MockAIChat) UpdateTemperature(newValue float32) {
+	c.Temperature = newValue
+}
+
+func (c *MockAIChat) WithJsonSchema(message string, schemaName string, schema map[string]interface{}, onUpdate ChatAIMessageChunkReceiver) error {
+	return c.SendMessage(message, onUpdate)
+}
@@ -36,11 +36,15 @@ import (
 // OllamaAIChat is an implementation of ChatAI interface
 // using local Ollama REST API
 type OllamaAIChat struct {
-	Conversation []OllamaAIChatMessage // the conversation
-	Model        string                // the current model
-	SystemPrompt string                // the current system prompt
-	Temperature  float32               // the current temperature
-	Verbose      bool                  // running in verbose mode or not
+	Conversation       []OllamaAIChatMessage // the conversation
+	HttpClient         *http.Client          // custom HTTP client
+	MaxContextMessages int                   // maximum number of non-system messages to send, 0 for no limit
+	MaxContextTokens   int                   // maximum number of estimated tokens to send, 0 for no limit
+	Model              string                // the current model
+	SystemPrompt       string                // the current system prompt
+	Temperature        float32               // the current temperature
+	TrimmedMessages    int                   // number of messages, which have not been sent with the last request
+	Verbose            bool                  // running in verbose mode or not
 }
 
 // OllamaAIChatMessage is an item inside
@@ -60,11 +64,32 @@ func (c *OllamaAIChat) ClearHistory() {
 }
 
 func (c *OllamaAIChat) DescribeImage(message string, dataURI string) (DescribeImageResponse, error) {
-	var imageDescription DescribeImageResponse
+	content, err := c.describeImages(message, []string{dataURI}, get_ai_image_description_schema())
+	if err != nil {
+		return DescribeImageResponse{}, err
+	}
 
-	base64Content, err := utils.Base64FromDataURI(dataURI)
+	return get_ai_image_description_from_json(content)
+}
+
+func (c *OllamaAIChat) DescribeImages(message string, dataURIs []string) ([]DescribeImageResponse, error) {
+	content, err := c.describeImages(message, dataURIs, get_ai_images_description_schema())
 	if err != nil {
-		return imageDescription, err
+		return nil, err
+	}
+
+	return get_ai_image_descriptions_from_json(content, len(dataURIs))
+}
+
+func (c *OllamaAIChat) describeImages(message string, dataURIs []string, schema map[string]interface{}) (string, error) {
+	images := make([]string, 0, len(dataURIs))
+	for _, dataURI := range dataURIs {
+		base64Content, err := utils.Base64FromDataURI(dataURI)
+		if err != nil {
+			return "", err
+		}
+
+		images = append(images, base64Content)
 	}
 
 	url := "http://localhost:11434/api/chat"
@@ -74,33 +99,13 @@ func (c *OllamaAIChat) DescribeImage(message string, dataURI string) (DescribeIm
 	messages = append(messages, map[string]interface{}{
 		"role":    "user",
 		"content": message,
-		"images":  []string{base64Content},
+		"images":  images,
 	})
 
 	body := map[string]interface{}{
-		"model":  c.GetModel(),
-		"stream": false,
-		"format": map[string]interface{}{
-			"type":     "object",
-			"required": []string{"aria_attributes"},
-			"properties": map[string]interface{}{
-				"aria_attributes": map[string]interface{}{
-					"description": "HTML accessibility attributes which describe the image.",
-					"type":        "object",
-					"required":    []string{"aria_description", "aria_label"},
-					"properties": map[string]interface{}{
-						"aria_description": map[string]interface{}{
-							"description": "Defines a string value that describes or annotates the image in detail.",
-							"type":        "string",
-						},
-						"aria_label": map[string]interface{}{
-							"description": "Defines a string value that can be used to name the image.",
-							"type":        "string",
-						},
-					},
-				},
-			},
-		},
+		"model":    c.GetModel(),
+		"stream":   false,
+		"format":   schema,
 		"messages": messages,
 	}
 
@@ -110,41 +115,53 @@ func (c *OllamaAIChat) DescribeImage(message string, dataURI string) (DescribeIm
 
 	jsonData, err := json.Marshal(&body)
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer([]byte(jsonData)))
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 
 	// setup ...
 	req.Header.Set("Content-Type", "application/json")
 	// ... and finally send the JSON data
-	client := &http.Client{}
+	client := c.getHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return imageDescription, fmt.Errorf("unexpected response: %v", resp.StatusCode)
+		return "", fmt.Errorf("unexpected response: %v", resp.StatusCode)
 	}
 
 	// load the response
 	responseData, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 
 	var completionResponse OllamaApiChatCompletionResponse
 	err = json.Unmarshal(responseData, &completionResponse)
 	if err != nil {
-		return imageDescription, err
+		return "", err
+	}
+
+	return completionResponse.Message.Content, nil
+}
+
+func (c *OllamaAIChat) ExportHistory() []ChatAIMessage {
+	history := make([]ChatAIMessage, 0, len(c.Conversation))
+	for _, m := range c.Conversation {
+		history = append(history, ChatAIMessage{
+			Content: m.Content,
+			Role:    m.Role,
+		})
 	}
 
-	return get_ai_image_description_from_json(completionResponse.Message.Content)
+	return history
 }
 
 func (c *OllamaAIChat) GetModel() string {
@@ -156,6 +173,10 @@ func (c *OllamaAIChat) GetMoreInfo() string {
 }
 
 func (c *OllamaAIChat) GetPromptSuffix() string {
+	if c.Verbose && c.TrimmedMessages > 0 {
+		return fmt.Sprintf(" (%v trimmed)", c.TrimmedMessages)
+	}
+
 	return ""
 }
 
@@ -163,6 +184,55 @@ func (c *OllamaAIChat) GetProvider() string {
 	return "ollama"
 }
 
+func (c *OllamaAIChat) getHttpClient() *http.Client {
+	if c.HttpClient != nil {
+		return c.HttpClient
+	}
+
+	return &http.Client{}
+}
+
+func (c *OllamaAIChat) getTrimmedConversation(userMessage OllamaAIChatMessage) []OllamaAIChatMessage {
+	conversation := make([]OllamaAIChatMessage, 0, len(c.Conversation)+1)
+	conversation = append(conversation, c.Conversation...)
+	conversation = append(conversation, userMessage)
+
+	conversation, trimmedMessages := trim_ai_chat_conversation(
+		conversation, c.MaxContextMessages, c.MaxContextTokens,
+		func(m OllamaAIChatMessage) (string, string) {
+			return m.Role, m.Content
+		},
+	)
+	c.TrimmedMessages = trimmedMessages
+
+	return conversation
+}
+
+func (c *OllamaAIChat) ImportHistory(history []ChatAIMessage) {
+	conversation := make([]OllamaAIChatMessage, 0, len(history))
+	for _, m := range history {
+		conversation = append(conversation, OllamaAIChatMessage{
+			Content: m.Content,
+			Role:    m.Role,
+		})
+	}
+
+	c.Conversation = conversation
+}
+
+func (c *OllamaAIChat) RemoveLastTurn() (string, bool) {
+	for i := len(c.Conversation) - 1; i >= 0; i-- {
+		if c.Conversation[i].Role == "user" {
+			message := c.Conversation[i].Content
+			c.Conversation = c.Conversation[:i]
+
+			return message, true
+		}
+	}
+
+	return "", false
+}
+
 func (c *OllamaAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkReceiver) error {
 	url := "http://localhost:11434/api/chat"
 
@@ -171,9 +241,7 @@ func (c *OllamaAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkRe
 		Role:    "user",
 	}
 
-	messages := []OllamaAIChatMessage{}
-	messages = append(messages, c.Conversation...)
-	messages = append(messages, userMessage)
+	messages := c.getTrimmedConversation(userMessage)
 
 	body := map[string]interface{}{
 		"model":       c.Model,
@@ -195,7 +263,7 @@ func (c *OllamaAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkRe
 	// setup ...
 	req.Header.Set("Content-Type", "application/json")
 	// ... and finally send the JSON data
-	client := &http.Client{}
+	client := c.getHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -223,10 +291,8 @@ func (c *OllamaAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkRe
 		Role:    chatResponse.Message.Role,
 	}
 
-	c.Conversation = append(
-		c.Conversation,
-		userMessage, assistantMessage,
-	)
+	// keep the whole history, only the sent messages are trimmed
+	c.Conversation = append(c.Conversation, userMessage, assistantMessage)
 
 	return onUpdate(assistantMessage.Content)
 }
@@ -262,7 +328,7 @@ func (c *OllamaAIChat) SendPrompt(prompt string, onUpdate ChatAIMessageChunkRece
 	// setup ...
 	req.Header.Set("Content-Type", "application/json")
 	// ... and finally send the JSON data
-	client := &http.Client{}
+	client := c.getHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -332,8 +398,8 @@ func (c *OllamaAIChat) WithJsonSchema(message string, schemaName string, schema
 		messages = append(messages, systemMessage)
 	}
 
-	messages = append(messages, c.Conversation...)
-	messages = append(messages, userMessage)
+	conversation := c.getTrimmedConversation(userMessage)
+	messages = append(messages, conversation...)
 
 	body := map[string]interface{}{
 		"model":       model,
@@ -356,7 +422,7 @@ func (c *OllamaAIChat) WithJsonSchema(message string, schemaName string, schema
 	// setup ...
 	req.Header.Set("Content-Type", "application/json")
 	// ... and finally send the JSON data
-	client := &http.Client{}
+	client := c.getHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -384,10 +450,8 @@ func (c *OllamaAIChat) WithJsonSchema(message string, schemaName string, schema
 		Role:    chatResponse.Message.Role,
 	}
 
-	c.Conversation = append(
-		c.Conversation,
-		userMessage, assistantMessage,
-	)
+	// keep the whole history, only the sent messages are trimmed
+	c.Conversation = append(c.Conversation, userMessage, assistantMessage)
 
 	return onUpdate(assistantMessage.Content)
 }
@@ -34,13 +34,17 @@ import (
 // OpenAIChat is an implementation of ChatAI interface
 // using remote ChatGPT REST API by OpenAI
 type OpenAIChat struct {
-	ApiKey       string              // the API key to use
-	Conversation []OpenAIChatMessage // the conversation
-	Model        string              // the current model
-	SystemPrompt string              // the current system prompt
-	Temperature  float32             // the current temperature
-	TotalTokens  int32               // number of total used tokens in this session
-	Verbose      bool                // running in verbose mode or not
+	ApiKey             string              // the API key to use
+	Conversation       []OpenAIChatMessage // the conversation
+	HttpClient         *http.Client        // custom HTTP client
+	MaxContextMessages int                 // maximum number of non-system messages to send, 0 for no limit
+	MaxContextTokens   int                 // maximum number of estimated tokens to send, 0 for no limit
+	Model              string              // the current model
+	SystemPrompt       string              // the current system prompt
+	Temperature        float32             // the current temperature
+	TotalTokens        int32               // number of total used tokens in this session
+	TrimmedMessages    int                 // number of messages, which have not been sent with the last request
+	Verbose            bool                // running in verbose mode or not
 }
 
 // OpenAIChatMessage is an item inside
@@ -55,16 +59,32 @@ func (c *OpenAIChat) ClearHistory() {
 }
 
 func (c *OpenAIChat) DescribeImage(message string, dataURI string) (DescribeImageResponse, error) {
-	var imageDescription DescribeImageResponse
+	content, err := c.describeImages(message, []string{dataURI}, "JSONAriaSchema", get_ai_image_description_schema())
+	if err != nil {
+		return DescribeImageResponse{}, err
+	}
+
+	return get_ai_image_description_from_json(content)
+}
+
+func (c *OpenAIChat) DescribeImages(message string, dataURIs []string) ([]DescribeImageResponse, error) {
+	content, err := c.describeImages(message, dataURIs, "JSONAriaListSchema", get_ai_images_description_schema())
+	if err != nil {
+		return nil, err
+	}
 
+	return get_ai_image_descriptions_from_json(content, len(dataURIs))
+}
+
+func (c *OpenAIChat) describeImages(message string, dataURIs []string, schemaName string, schema map[string]interface{}) (string, error) {
 	apiKey := strings.TrimSpace(c.ApiKey)
 	if apiKey == "" {
-		return imageDescription, fmt.Errorf("no OpenAI api key defined")
+		return "", fmt.Errorf("no OpenAI api key defined")
 	}
 
 	model := strings.TrimSpace(strings.ToLower(c.Model))
 	if model == "" {
-		return imageDescription, fmt.Errorf("no chat ai model defined")
+		return "", fmt.Errorf("no chat ai model defined")
 	}
 
 	url := "https://api.openai.com/v1/chat/completions"
@@ -84,12 +104,14 @@ func (c *OpenAIChat) DescribeImage(message string, dataURI string) (DescribeImag
 		"type": "text",
 		"text": message,
 	})
-	userContents = append(userContents, map[string]interface{}{
-		"type": "image_url",
-		"image_url": map[string]interface{}{
-			"url": dataURI,
-		},
-	})
+	for _, dataURI := range dataURIs {
+		userContents = append(userContents, map[string]interface{}{
+			"type": "image_url",
+			"image_url": map[string]interface{}{
+				"url": dataURI,
+			},
+		})
+	}
 
 	messages = append(messages, map[string]interface{}{
 		"role":    "user",
@@ -102,28 +124,8 @@ func (c *OpenAIChat) DescribeImage(message string, dataURI string) (DescribeImag
 		"response_format": map[string]interface{}{
 			"type": "json_schema",
 			"json_schema": map[string]interface{}{
-				"name": "JSONAriaSchema",
-				"schema": map[string]interface{}{
-					"type":     "object",
-					"required": []string{"aria_attributes"},
-					"properties": map[string]interface{}{
-						"aria_attributes": map[string]interface{}{
-							"description": "HTML accessibility attributes which describe the image.",
-							"type":        "object",
-							"required":    []string{"aria_description", "aria_label"},
-							"properties": map[string]interface{}{
-								"aria_description": map[string]interface{}{
-									"description": "Defines a string value that describes or annotates the image in detail.",
-									"type":        "string",
-								},
-								"aria_label": map[string]interface{}{
-									"description": "Defines a string value that can be used to name the image.",
-									"type":        "string",
-								},
-							},
-						},
-					},
-				},
+				"name":   schemaName,
+				"schema": schema,
 			},
 		},
 		"stream":      false,
@@ -132,53 +134,61 @@ func (c *OpenAIChat) DescribeImage(message string, dataURI string) (DescribeImag
 
 	jsonData, err := json.Marshal(&body)
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 
 	req, err := http.NewRequest("POST", url, bytes.NewBuffer([]byte(jsonData)))
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 
 	// setup ...
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
 	// ... and finally send the JSON data
-	client := &http.Client{}
+	client := c.getHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return imageDescription, fmt.Errorf("unexpected response %v", resp.StatusCode)
+		return "", fmt.Errorf("unexpected response %v", resp.StatusCode)
 	}
 
 	// load the response
 	responseData, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 
 	var chatResponse OpenAIChatCompletionResponseV1
 	err = json.Unmarshal(responseData, &chatResponse)
 	if err != nil {
-		return imageDescription, err
+		return "", err
 	}
 
-	assistantMessage := OpenAIChatMessage{
-		Content: "",
-		Role:    "assistant",
-	}
+	content := ""
 	if len(chatResponse.Choices) > 0 {
-		assistantMessage.Content = chatResponse.Choices[0].Message.Content
-		assistantMessage.Role = chatResponse.Choices[0].Message.Role
+		content = chatResponse.Choices[0].Message.Content
 	}
 
 	c.TotalTokens += chatResponse.Usage.TotalTokens
 
-	return get_ai_image_description_from_json(assistantMessage.Content)
+	return content, nil
+}
+
+func (c *OpenAIChat) ExportHistory() []ChatAIMessage {
+	history := make([]ChatAIMessage, 0, len(c.Conversation))
+	for _, m := range c.Conversation {
+		history = append(history, ChatAIMessage{
+			Content: m.Content,
+			Role:    m.Role,
+		})
+	}
+
+	return history
 }
 
 func (c *OpenAIChat) GetModel() string {
@@ -195,6 +205,10 @@ func (c *OpenAIChat) GetMoreInfo() string {
 
 func (c *OpenAIChat) GetPromptSuffix() string {
 	if c.Verbose {
+		if c.TrimmedMessages > 0 {
+			return fmt.Sprintf(" (%v, %v trimmed)", c.TotalTokens, c.TrimmedMessages)
+		}
+
 		return fmt.Sprintf(" (%v)", c.TotalTokens)
 	}
 
@@ -205,6 +219,55 @@ func (c *OpenAIChat) GetProvider() string {
 	return "openai"
 }
 
+func (c *OpenAIChat) getHttpClient() *http.Client {
+	if c.HttpClient != nil {
+		return c.HttpClient
+	}
+
+	return &http.Client{}
+}
+
+func (c *OpenAIChat) getTrimmedConversation(userMessage OpenAIChatMessage) []OpenAIChatMessage {
+	conversation := make([]OpenAIChatMessage, 0, len(c.Conversation)+1)
+	conversation = append(conversation, c.Conversation...)
+	conversation = append(conversation, userMessage)
+
+	conversation, trimmedMessages := trim_ai_chat_conversation(
+		conversation, c.MaxContextMessages, c.MaxContextTokens,
+		func(m OpenAIChatMessage) (string, string) {
+			return m.Role, m.Content
+		},
+	)
+	c.TrimmedMessages = trimmedMessages
+
+	return conversation
+}
+
+func (c *OpenAIChat) ImportHistory(history []ChatAIMessage) {
+	conversation := make([]OpenAIChatMessage, 0, len(history))
+	for _, m := range history {
+		conversation = append(conversation, OpenAIChatMessage{
+			Content: m.Content,
+			Role:    m.Role,
+		})
+	}
+
+	c.Conversation = conversation
+}
+
+func (c *OpenAIChat) RemoveLastTurn() (string, bool) {
+	for i := len(c.Conversation) - 1; i >= 0; i-- {
+		if c.Conversation[i].Role == "user" {
+			message := c.Conversation[i].Content
+			c.Conversation = c.Conversation[:i]
+
+			return message, true
+		}
+	}
+
+	return "", false
+}
+
 func (c *OpenAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkReceiver) error {
 	apiKey := strings.TrimSpace(c.ApiKey)
 	if apiKey == "" {
@@ -223,9 +286,7 @@ func (c *OpenAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkRece
 		Role:    "user",
 	}
 
-	messages := []OpenAIChatMessage{}
-	messages = append(messages, c.Conversation...)
-	messages = append(messages, userMessage)
+	messages := c.getTrimmedConversation(userMessage)
 
 	body := map[string]interface{}{
 		"model":       model,
@@ -248,7 +309,7 @@ func (c *OpenAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkRece
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
 	// ... and finally send the JSON data
-	client := &http.Client{}
+	client := c.getHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -280,10 +341,8 @@ func (c *OpenAIChat) SendMessage(message string, onUpdate ChatAIMessageChunkRece
 		assistantMessage.Role = chatResponse.Choices[0].Message.Role
 	}
 
-	c.Conversation = append(
-		c.Conversation,
-		userMessage, assistantMessage,
-	)
+	// keep the whole history, only the sent messages are trimmed
+	c.Conversation = append(c.Conversation, userMessage, assistantMessage)
 
 	err = onUpdate(assistantMessage.Content)
 	if err != nil {
@@ -348,7 +407,7 @@ func (c *OpenAIChat) SendPrompt(prompt string, onUpdate ChatAIMessageChunkReceiv
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
 	// ... and finally send the JSON data
-	client := &http.Client{}
+	client := c.getHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -426,8 +485,8 @@ func (c *OpenAIChat) WithJsonSchema(message string, schemaName string, schema ma
 		Role:    "user",
 	}
 
-	messages = append(messages, c.Conversation...)
-	messages = append(messages, userMessage)
+	conversation := c.getTrimmedConversation(userMessage)
+	messages = append(messages, conversation...)
 
 	url := "https://api.openai.com/v1/chat/completions"
 
@@ -459,7 +518,7 @@ func (c *OpenAIChat) WithJsonSchema(message string, schemaName string, schema ma
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", apiKey))
 	// ... and finally send the JSON data
-	client := &http.Client{}
+	client := c.getHttpClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -491,10 +550,8 @@ func (c *OpenAIChat) WithJsonSchema(message string, schemaName string, schema ma
 		assistantMessage.Role = chatResponse.Choices[0].Message.Role
 	}
 
-	c.Conversation = append(
-		c.Conversation,
-		userMessage, assistantMessage,
-	)
+	// keep the whole history, only the sent messages are trimmed
+	c.Conversation = append(c.Conversation, userMessage, assistantMessage)
 
 	err = onUpdate(assistantMessage.Content)
 	if err != nil {
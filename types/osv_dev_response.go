@@ -38,6 +38,7 @@ type OsvDevResponse struct {
 // OsvDevResponseVulnerabilityItem represents an item
 // in OsvDevResponse.Vulnerabilities array
 type OsvDevResponseVulnerabilityItem struct {
+	Aliases          *[]string                                        `json:"aliases,omitempty"`           // aliases like CVE or GHSA IDs
 	DatabaseSpecific *OsvDevResponseVulnerabilityItemDataSpecificInfo `json:"database_specific,omitempty"` // database specific information
 	Details          string                                           `json:"details,omitempty"`           // details
 	Id               string                                           `json:"id,omitempty"`                // ID
@@ -83,7 +84,7 @@ func (v *OsvDevResponseVulnerabilityItem) GetSeverityDisplayValues() (string, in
 			return color.New(color.FgRed, color.Bold).Sprint("HIGH"), 2
 		}
 		if v.IsCritical() {
-			return color.New(color.BgRed, color.FgYellow, color.Bold).Sprint("CRITICAL"), 2
+			return color.New(color.BgRed, color.FgYellow, color.Bold).Sprint("CRITICAL"), 3
 		}
 	}
 
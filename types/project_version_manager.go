@@ -40,6 +40,7 @@ type ProjectVersionManager struct {
 // of `ProjectVersionManager“ instance
 type BumpProjectVersionOptions struct {
 	Breaking *bool   // increase major part
+	DryRun   *bool   // only calculate the new version without creating a tag
 	Feature  *bool   // increase minor part
 	Fix      *bool   // increase patch part
 	Force    *bool   // force bump even if latest version is newer
@@ -58,6 +59,7 @@ func (pvm *ProjectVersionManager) Bump(options ...BumpProjectVersionOptions) (*v
 	}
 
 	breaking := false
+	dryRun := false
 	feature := false
 	fix := false
 	force := false
@@ -69,6 +71,9 @@ func (pvm *ProjectVersionManager) Bump(options ...BumpProjectVersionOptions) (*v
 		if o.Breaking != nil {
 			breaking = *o.Breaking
 		}
+		if o.DryRun != nil {
+			dryRun = *o.DryRun
+		}
 		if o.Feature != nil {
 			feature = *o.Feature
 		}
@@ -154,6 +159,10 @@ func (pvm *ProjectVersionManager) Bump(options ...BumpProjectVersionOptions) (*v
 		return nextVersion, fmt.Errorf("new version is not greater than latest one")
 	}
 
+	if dryRun {
+		return nextVersion, nil
+	}
+
 	gitMessage := strings.TrimSpace(message)
 	if gitMessage == "" {
 		gitMessage = fmt.Sprintf("version %v", nextVersion.String())
@@ -0,0 +1,101 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ReleaseProvider describes an object that provides abstract
+// methods to handle releases of a hosted git repository
+type ReleaseProvider interface {
+	// ReleaseProvider.CreateRelease() - creates a new release for an existing tag
+	CreateRelease(options CreateReleaseOptions) (*ReleaseInfo, error)
+	// ReleaseProvider.GetName() - returns the name of the provider
+	GetName() string
+	// ReleaseProvider.GetRepository() - returns the full name of the repository
+	GetRepository() string
+	// ReleaseProvider.UploadAsset() - uploads a local file as asset to the
+	// release of a tag, where an existing asset with the same name is replaced
+	UploadAsset(tagName string, filePath string, options ...UploadReleaseAssetOptions) error
+}
+
+// CreateReleaseOptions - options for ReleaseProvider.CreateRelease() method
+type CreateReleaseOptions struct {
+	Body       string // the description
+	Draft      bool   // create as draft, if supported
+	Name       string // the name / title
+	Prerelease bool   // mark as pre-release, if supported
+	TagName    string // the name of the existing tag
+}
+
+// ReleaseInfo - provider independent information about a release
+type ReleaseInfo struct {
+	TagName string // the name of the tag
+	Url     string // the URL to the web page
+}
+
+// UploadReleaseAssetOptions - options for ReleaseProvider.UploadAsset() method
+type UploadReleaseAssetOptions struct {
+	Progress io.Writer // if defined, receives the uploaded data, e.g. a progress bar
+}
+
+// DetectReleaseProviderName() - returns the name of the release provider
+// like `github` or `gitlab` by the host of a git remote URL
+func DetectReleaseProviderName(remoteUrl string) (string, error) {
+	host, _, err := ParseGitRemoteUrl(remoteUrl)
+	if err != nil {
+		return "", err
+	}
+
+	host = strings.ToLower(host)
+	if strings.Contains(host, "github") {
+		return "github", nil
+	}
+	if strings.Contains(host, "gitlab") {
+		return "gitlab", nil
+	}
+
+	return "", fmt.Errorf("could not detect release provider for host '%v'", host)
+}
+
+// ParseGitRemoteUrl() - extracts host and repository path of a git remote URL
+// like `https://host/group/repo.git` or `git@host:group/repo.git`
+func ParseGitRemoteUrl(remoteUrl string) (string, string, error) {
+	r := regexp.MustCompile(`^(?:(?:https?|ssh|git)://(?:[^@/]+@)?([^/:]+)(?::\d+)?/|[^@\s]+@([^:/\s]+):)(\S+?)(?:\.git)?/?$`)
+
+	matches := r.FindStringSubmatch(strings.TrimSpace(remoteUrl))
+	if len(matches) != 4 {
+		return "", "", fmt.Errorf("'%v' is no supported git remote URL", remoteUrl)
+	}
+
+	host := matches[1]
+	if host == "" {
+		host = matches[2]
+	}
+
+	return host, matches[3], nil
+}
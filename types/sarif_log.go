@@ -0,0 +1,106 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+// SarifSchemaUrl is the URL of the JSON schema of SARIF 2.1.0
+const SarifSchemaUrl = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// SarifVersion is the supported version of SARIF
+const SarifVersion = "2.1.0"
+
+// SarifLog represents a SARIF 2.1.0 document
+type SarifLog struct {
+	Schema  string     `json:"$schema"` // the URL of the schema
+	Runs    []SarifRun `json:"runs"`    // list of runs
+	Version string     `json:"version"` // the SARIF version
+}
+
+// SarifArtifactLocation represents value in SarifPhysicalLocation.ArtifactLocation property
+type SarifArtifactLocation struct {
+	Uri string `json:"uri"` // the relative URI of the file
+}
+
+// SarifLocation represents an item in SarifResult.Locations array
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"` // the physical location
+}
+
+// SarifMessage represents a text message
+type SarifMessage struct {
+	Text string `json:"text"` // the text
+}
+
+// SarifPhysicalLocation represents value in SarifLocation.PhysicalLocation property
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"` // the file
+	Region           *SarifRegion          `json:"region,omitempty"` // the optional region inside the file
+}
+
+// SarifRegion represents value in SarifPhysicalLocation.Region property
+type SarifRegion struct {
+	StartLine int `json:"startLine"` // the 1-based line number
+}
+
+// SarifReportingConfiguration represents value in SarifRule.DefaultConfiguration property
+type SarifReportingConfiguration struct {
+	Level string `json:"level"` // the level like `error`, `warning` or `note`
+}
+
+// SarifResult represents an item in SarifRun.Results array
+type SarifResult struct {
+	Level      string                 `json:"level,omitempty"`      // the level like `error`, `warning` or `note`
+	Locations  []SarifLocation        `json:"locations"`            // list of locations
+	Message    SarifMessage           `json:"message"`              // the message
+	Properties map[string]interface{} `json:"properties,omitempty"` // custom properties
+	RuleId     string                 `json:"ruleId"`               // the ID of the rule
+}
+
+// SarifRule represents an item in SarifToolDriver.Rules array
+type SarifRule struct {
+	DefaultConfiguration *SarifReportingConfiguration `json:"defaultConfiguration,omitempty"` // the default configuration
+	FullDescription      *SarifMessage                `json:"fullDescription,omitempty"`      // the full description
+	HelpUri              string                       `json:"helpUri,omitempty"`              // URL to more information
+	Id                   string                       `json:"id"`                             // the ID
+	Name                 string                       `json:"name,omitempty"`                 // the name
+	Properties           map[string]interface{}       `json:"properties,omitempty"`           // additional properties like `security-severity`
+	ShortDescription     *SarifMessage                `json:"shortDescription,omitempty"`     // the short description
+}
+
+// SarifRun represents an item in SarifLog.Runs array
+type SarifRun struct {
+	Results []SarifResult `json:"results"` // list of results
+	Tool    SarifTool     `json:"tool"`    // the tool which created the results
+}
+
+// SarifTool represents value in SarifRun.Tool property
+type SarifTool struct {
+	Driver SarifToolDriver `json:"driver"` // the driver
+}
+
+// SarifToolDriver represents value in SarifTool.Driver property
+type SarifToolDriver struct {
+	InformationUri string      `json:"informationUri,omitempty"` // URL to more information
+	Name           string      `json:"name"`                     // the name of the tool
+	Rules          []SarifRule `json:"rules"`                    // list of rules
+	Version        string      `json:"version,omitempty"`        // the version of the tool
+}
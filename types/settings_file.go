@@ -0,0 +1,249 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mkloubert/go-package-manager/utils"
+)
+
+// DefaultExecuteBlocklist stores the default regular expressions of
+// shell commands, which are handled as dangerous by `execute` command
+var DefaultExecuteBlocklist = []string{
+	// remove root or home with any flags
+	`\brm\s+(-{1,2}[a-zA-Z-]*\s+)*(-[a-zA-Z]*[rR][a-zA-Z]*|--recursive)\s+(-{1,2}[a-zA-Z-]*\s+)*["']?(/|~|\$HOME|\$\{HOME\})["']?/?\*?["']?(\s|[;&|]|$)`,
+	`:\(\)\s*\{\s*:\s*\|\s*:\s*&\s*\}\s*;\s*:`,        // fork bomb
+	`\bmkfs(\.[a-zA-Z0-9]+)?\b`,                       // format filesystem
+	`\bdd\b.*\bof=/dev/`,                              // overwrite devices
+	`>\s*/dev/(sd|hd|nvme|disk)`,                      // overwrite devices
+	`\bchmod\s+(-[a-zA-Z]*\s+)*0?777\s+/(\s|$)`,       // open permissions of root
+	`\b(shutdown|reboot|halt|poweroff)\b`,             // stop system
+	`(?i)\bformat\s+[a-z]:`,                           // format Windows drive
+	`(?i)\b(del|rd|rmdir)\s+(/[a-z]\s+)*[a-z]:\\\s*$`, // remove Windows drive
+}
+
+// SettingsFile stores information of a `settings.yaml` file from home folder
+type SettingsFile struct {
+	AI      SettingsFileAISection      `yaml:"ai,omitempty"`      // settings for AI features
+	Audit   SettingsFileAuditSection   `yaml:"audit,omitempty"`   // settings for `audit` command
+	Execute SettingsFileExecuteSection `yaml:"execute,omitempty"` // settings for `execute` command
+	Prompt  SettingsFilePromptSection  `yaml:"prompt,omitempty"`  // settings for `prompt` command
+
+	values map[string]interface{} // all raw values of the file
+}
+
+// SettingsFileAISection stores settings for AI features
+// inside a `SettingsFile`
+type SettingsFileAISection struct {
+	Budget      SettingsFileAIBudget           `yaml:"budget,omitempty"`      // limits for the usage of AI APIs
+	Models      map[string]string              `yaml:"models,omitempty"`      // default models by provider, like `openai` or `ollama`
+	Prices      map[string]SettingsFileAIPrice `yaml:"prices,omitempty"`      // prices by model for cost estimations
+	Temperature *float32                       `yaml:"temperature,omitempty"` // default temperature for AI chats and prompts
+}
+
+// SettingsFileAIBudget stores the daily and monthly
+// limits for the usage of AI APIs
+type SettingsFileAIBudget struct {
+	Daily   SettingsFileAIBudgetLimit `yaml:"daily,omitempty"`   // limit for the current day
+	Monthly SettingsFileAIBudgetLimit `yaml:"monthly,omitempty"` // limit for the current month
+}
+
+// SettingsFileAIBudgetLimit stores a limit of a `SettingsFileAIBudget`,
+// values of `0` mean no limit
+type SettingsFileAIBudgetLimit struct {
+	Tokens int64   `yaml:"tokens,omitempty"` // maximum number of prompt and generated tokens
+	USD    float64 `yaml:"usd,omitempty"`    // maximum costs in USD, based on `ai.prices`
+}
+
+// SettingsFileAIPrice stores the price of an AI model
+// in USD per 1 million tokens
+type SettingsFileAIPrice struct {
+	Input  float64 `yaml:"input,omitempty"`  // price of 1 million prompt tokens
+	Output float64 `yaml:"output,omitempty"` // price of 1 million generated tokens
+}
+
+// SettingsFileAuditSection stores settings for `audit` command
+// inside a `SettingsFile`
+type SettingsFileAuditSection struct {
+	Ignore []string `yaml:"ignore,omitempty"` // IDs of advisories which should be ignored
+}
+
+// SettingsFileExecuteSection stores settings for `execute` command
+// inside a `SettingsFile`
+type SettingsFileExecuteSection struct {
+	Blocklist []string `yaml:"blocklist,omitempty"` // regular expressions of commands which are handled as dangerous
+}
+
+// SettingsFilePromptSection stores settings for `prompt` command
+// inside a `SettingsFile`
+type SettingsFilePromptSection struct {
+	Templates map[string]string `yaml:"templates,omitempty"` // named prompt templates with `{{.Var}}` placeholders
+}
+
+// s.CheckAIBudget() - returns an error if the usage of `entries` exceeds the
+// daily or monthly AI budget relative to `now`, costs are only summed up for
+// models with a price
+func (s *SettingsFile) CheckAIBudget(entries []AILogEntry, now time.Time) error {
+	if !s.HasAIBudget() {
+		return nil
+	}
+
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	var dailyTokens, monthlyTokens int64
+	var dailyCost, monthlyCost float64
+	for _, e := range entries {
+		t, err := time.Parse(time.RFC3339, e.Time)
+		if err != nil || t.Before(startOfMonth) {
+			continue
+		}
+
+		usage := e.GetUsage()
+
+		tokens := int64(usage.InputTokens + usage.OutputTokens)
+		cost := 0.0
+		if price, ok := s.GetAIPrice(usage.Model); ok {
+			cost = float64(usage.InputTokens)/1000000.0*price.Input +
+				float64(usage.OutputTokens)/1000000.0*price.Output
+		}
+
+		monthlyTokens += tokens
+		monthlyCost += cost
+		if !t.Before(startOfDay) {
+			dailyTokens += tokens
+			dailyCost += cost
+		}
+	}
+
+	checkLimit := func(name string, limit SettingsFileAIBudgetLimit, tokens int64, cost float64) error {
+		if limit.Tokens > 0 && tokens >= limit.Tokens {
+			return fmt.Errorf("AI budget exceeded: %v of %v %v tokens used", tokens, limit.Tokens, name)
+		}
+		if limit.USD > 0 && cost >= limit.USD {
+			return fmt.Errorf("AI budget exceeded: $%.4f of $%.4f %v costs used", cost, limit.USD, name)
+		}
+
+		return nil
+	}
+
+	err := checkLimit("daily", s.AI.Budget.Daily, dailyTokens, dailyCost)
+	if err != nil {
+		return err
+	}
+
+	return checkLimit("monthly", s.AI.Budget.Monthly, monthlyTokens, monthlyCost)
+}
+
+// s.GetAIModel() - returns the default AI model for a provider
+// or an empty string if not defined
+func (s *SettingsFile) GetAIModel(provider string) string {
+	if s.AI.Models == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(s.AI.Models[strings.TrimSpace(strings.ToLower(provider))])
+}
+
+// s.GetAIPrice() - returns the price of an AI model and `false` if not defined,
+// versioned names like `gpt-4o-mini-2024-07-18` also match `gpt-4o-mini`
+func (s *SettingsFile) GetAIPrice(model string) (SettingsFileAIPrice, bool) {
+	model = strings.TrimSpace(model)
+
+	price, ok := s.AI.Prices[model]
+	if ok {
+		return price, true
+	}
+
+	bestMatch := ""
+	for name, p := range s.AI.Prices {
+		if strings.HasPrefix(model, name+"-") && len(name) > len(bestMatch) {
+			bestMatch = name
+			price = p
+		}
+	}
+
+	return price, bestMatch != ""
+}
+
+// s.GetAITemperature() - returns the default AI temperature
+// or `defaultValue` if not defined
+func (s *SettingsFile) GetAITemperature(defaultValue float32) float32 {
+	if s.AI.Temperature != nil {
+		return *s.AI.Temperature
+	}
+
+	return defaultValue
+}
+
+// s.GetExecuteBlocklist() - returns the custom blocklist for `execute` command
+// or the default one
+func (s *SettingsFile) GetExecuteBlocklist() []string {
+	if s.Execute.Blocklist != nil {
+		return s.Execute.Blocklist
+	}
+
+	return DefaultExecuteBlocklist
+}
+
+// s.GetPromptTemplate() - returns a named prompt template
+// and `false` if not defined
+func (s *SettingsFile) GetPromptTemplate(name string) (string, bool) {
+	if s.Prompt.Templates == nil {
+		return "", false
+	}
+
+	template, ok := s.Prompt.Templates[name]
+	return template, ok
+}
+
+// s.GetString() - returns a value as string by a dotted key like `execute.shell`
+// or `defaultValue` if not defined
+func (s *SettingsFile) GetString(key string, defaultValue string) string {
+	value, ok := utils.GetValueByDottedKey(s.GetValues(), key)
+	if !ok || value == nil {
+		return defaultValue
+	}
+
+	return fmt.Sprint(value)
+}
+
+// s.GetValues() - returns all raw values of the file
+func (s *SettingsFile) GetValues() map[string]interface{} {
+	if s.values == nil {
+		s.values = map[string]interface{}{}
+	}
+
+	return s.values
+}
+
+// s.HasAIBudget() - returns `true` if a daily or monthly AI budget is defined
+func (s *SettingsFile) HasAIBudget() bool {
+	budget := s.AI.Budget
+
+	return budget.Daily.Tokens > 0 || budget.Daily.USD > 0 ||
+		budget.Monthly.Tokens > 0 || budget.Monthly.USD > 0
+}
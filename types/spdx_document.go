@@ -0,0 +1,67 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package types
+
+// SpdxDocument represents a SPDX 2.3 JSON document
+type SpdxDocument struct {
+	CreationInfo      SpdxCreationInfo   `json:"creationInfo"`      // information about the creation
+	DataLicense       string             `json:"dataLicense"`       // always `CC0-1.0`
+	DocumentNamespace string             `json:"documentNamespace"` // unique URI of the document
+	Name              string             `json:"name"`              // the name
+	Packages          []SpdxPackage      `json:"packages"`          // list of packages
+	Relationships     []SpdxRelationship `json:"relationships"`     // list of relationships between elements
+	SpdxId            string             `json:"SPDXID"`            // always `SPDXRef-DOCUMENT`
+	SpdxVersion       string             `json:"spdxVersion"`       // the version of the specification
+}
+
+// SpdxCreationInfo represents value in SpdxDocument.CreationInfo property
+type SpdxCreationInfo struct {
+	Created  string   `json:"created"`  // creation time in ISO 8601 format
+	Creators []string `json:"creators"` // list of creators like `Tool: gpm-1.0.0`
+}
+
+// SpdxExternalRef represents an item in SpdxPackage.ExternalRefs array
+type SpdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"` // the category like `PACKAGE-MANAGER`
+	ReferenceLocator  string `json:"referenceLocator"`  // the locator like a package URL
+	ReferenceType     string `json:"referenceType"`     // the type like `purl`
+}
+
+// SpdxPackage represents an item in SpdxDocument.Packages array
+type SpdxPackage struct {
+	DownloadLocation string            `json:"downloadLocation"`       // the download location or `NOASSERTION`
+	ExternalRefs     []SpdxExternalRef `json:"externalRefs,omitempty"` // list of external references
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`          // files have been analyzed or not
+	LicenseConcluded string            `json:"licenseConcluded"`       // SPDX ID of the license or `NOASSERTION`
+	LicenseDeclared  string            `json:"licenseDeclared"`        // SPDX ID of the license or `NOASSERTION`
+	Name             string            `json:"name"`                   // the name
+	SpdxId           string            `json:"SPDXID"`                 // unique ID inside the document
+	VersionInfo      string            `json:"versionInfo,omitempty"`  // the version
+}
+
+// SpdxRelationship represents an item in SpdxDocument.Relationships array
+type SpdxRelationship struct {
+	RelatedSpdxElement string `json:"relatedSpdxElement"` // the ID of the related element
+	RelationshipType   string `json:"relationshipType"`   // the type like `DESCRIBES` or `DEPENDS_ON`
+	SpdxElementId      string `json:"spdxElementId"`      // the ID of the element
+}
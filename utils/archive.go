@@ -0,0 +1,414 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveFormatTar is the name of the (compressed) tar archive format
+const ArchiveFormatTar = "tar"
+
+// ArchiveFormatZip is the name of the zip archive format
+const ArchiveFormatZip = "zip"
+
+// IgnoreFileName is the name of the file with patterns of files,
+// which should be ignored when archiving directories
+const IgnoreFileName = ".gpmignore"
+
+var zipMagic = []byte{'P', 'K', 0x03, 0x04}
+
+// ArchiveEntry describes a file which should be written to an archive
+type ArchiveEntry struct {
+	FilePath string // the full path of the file in the file system
+	Name     string // the name / relative path inside the archive
+}
+
+// AddFileToArchiveOptions stores additional options for
+// `AddFileToTar()` and `AddFileToZip()`
+type AddFileToArchiveOptions struct {
+	Reproducible bool // use fixed timestamps and owners for the entry
+}
+
+// AddFileToTar() - writes a file from the file system to a tar archive
+func AddFileToTar(tarWriter *tar.Writer, filePath string, name string, options ...AddFileToArchiveOptions) error {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(fileInfo, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+	if isReproducibleArchiveEntry(options...) {
+		header.ModTime = GetReproducibleModTime()
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+		header.Uid = 0
+		header.Gid = 0
+		header.Uname = ""
+		header.Gname = ""
+		header.Format = tar.FormatPAX
+	}
+	if fileInfo.IsDir() {
+		header.Name = strings.TrimSuffix(header.Name, "/") + "/"
+
+		return tarWriter.WriteHeader(header)
+	}
+
+	err = tarWriter.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
+
+// AddFileToZip() - writes a file from the file system to a zip archive
+func AddFileToZip(zipWriter *zip.Writer, filePath string, name string, options ...AddFileToArchiveOptions) error {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(fileInfo)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(name)
+	header.Modified = fileInfo.ModTime()
+	if isReproducibleArchiveEntry(options...) {
+		header.Modified = GetReproducibleModTime()
+	}
+	if fileInfo.IsDir() {
+		header.Name = strings.TrimSuffix(header.Name, "/") + "/"
+
+		_, err = zipWriter.CreateHeader(header)
+		return err
+	}
+	header.Method = zip.Deflate
+
+	fileWriter, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(fileWriter, file)
+	return err
+}
+
+// CollectArchiveEntries() - collects all files of a directory recursively
+// honoring patterns of an optional `.gpmignore` file inside of it;
+// names of entries are based on `baseName`, which is the path as
+// submitted by the user
+func CollectArchiveEntries(dir string, baseName string, stripPrefix string) ([]ArchiveEntry, error) {
+	var entries []ArchiveEntry
+
+	ignorePatterns, err := LoadIgnorePatterns(dir)
+	if err != nil {
+		return entries, err
+	}
+
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if relPath == "." {
+			return nil
+		}
+
+		if IsIgnoredPath(relPath, info.IsDir(), ignorePatterns) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() || !info.Mode().IsRegular() {
+			return nil
+		}
+
+		entries = append(entries, ArchiveEntry{
+			FilePath: p,
+			Name:     ToArchiveEntryName(path.Join(filepath.ToSlash(baseName), relPath), stripPrefix),
+		})
+		return nil
+	})
+
+	return entries, err
+}
+
+// ExtractTar() - extracts a tar archive from a stream into a directory
+func ExtractTar(r io.Reader, targetDir string) ([]string, error) {
+	var extractedFiles []string
+
+	tarReader := tar.NewReader(r)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return extractedFiles, err
+		}
+
+		targetPath, err := SafeJoin(targetDir, header.Name)
+		if err != nil {
+			return extractedFiles, err
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(targetPath, 0750)
+		case tar.TypeReg:
+			err = writeArchiveEntryTo(targetPath, tarReader, os.FileMode(header.Mode).Perm())
+			if err == nil {
+				extractedFiles = append(extractedFiles, targetPath)
+			}
+		default:
+			continue // links and special files are not supported
+		}
+
+		if err != nil {
+			return extractedFiles, err
+		}
+	}
+
+	return extractedFiles, nil
+}
+
+// ExtractZip() - extracts a zip archive into a directory
+func ExtractZip(r io.ReaderAt, size int64, targetDir string) ([]string, error) {
+	var extractedFiles []string
+
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		return extractedFiles, err
+	}
+
+	for _, f := range zipReader.File {
+		targetPath, err := SafeJoin(targetDir, f.Name)
+		if err != nil {
+			return extractedFiles, err
+		}
+
+		if f.FileInfo().IsDir() {
+			err = os.MkdirAll(targetPath, 0750)
+			if err != nil {
+				return extractedFiles, err
+			}
+
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue // links and special files are not supported
+		}
+
+		err = func() error {
+			fileReader, err := f.Open()
+			if err != nil {
+				return err
+			}
+			defer fileReader.Close()
+
+			return writeArchiveEntryTo(targetPath, fileReader, f.Mode().Perm())
+		}()
+		if err != nil {
+			return extractedFiles, err
+		}
+
+		extractedFiles = append(extractedFiles, targetPath)
+	}
+
+	return extractedFiles, nil
+}
+
+// GetReproducibleModTime() - returns the fixed timestamp for entries of
+// reproducible archives, which is taken from `SOURCE_DATE_EPOCH` or
+// 1980-01-01 00:00:00 UTC, the lowest date supported by zip
+func GetReproducibleModTime() time.Time {
+	sourceDateEpoch := strings.TrimSpace(os.Getenv("SOURCE_DATE_EPOCH"))
+	if sourceDateEpoch != "" {
+		seconds, err := strconv.ParseInt(sourceDateEpoch, 10, 64)
+		if err == nil {
+			return time.Unix(seconds, 0).UTC()
+		}
+	}
+
+	return time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// IsIgnoredPath() - checks if a relative path matches one of the
+// patterns of an ignore file
+func IsIgnoredPath(relPath string, isDir bool, patterns []string) bool {
+	relPath = filepath.ToSlash(relPath)
+	baseName := path.Base(relPath)
+
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "/") {
+			if !isDir {
+				continue
+			}
+
+			p = strings.TrimSuffix(p, "/")
+		}
+
+		if strings.Contains(p, "/") {
+			if ok, _ := path.Match(strings.TrimPrefix(p, "/"), relPath); ok {
+				return true
+			}
+		} else if ok, _ := path.Match(p, baseName); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsTarArchive() - checks if the header of data looks like a tar archive
+func IsTarArchive(header []byte) bool {
+	return len(header) >= 262 && string(header[257:262]) == "ustar"
+}
+
+// IsZipArchive() - checks if the header of data looks like a zip archive
+func IsZipArchive(header []byte) bool {
+	return bytes.HasPrefix(header, zipMagic)
+}
+
+// LoadIgnorePatterns() - loads the patterns of a `.gpmignore` file in
+// a directory, if it exists
+func LoadIgnorePatterns(dir string) ([]string, error) {
+	var patterns []string
+
+	ignoreFilePath := filepath.Join(dir, IgnoreFileName)
+
+	isExisting, err := IsFileExisting(ignoreFilePath)
+	if err != nil || !isExisting {
+		return patterns, err
+	}
+
+	file, err := os.Open(ignoreFilePath)
+	if err != nil {
+		return patterns, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		patterns = append(patterns, line)
+	}
+
+	return patterns, scanner.Err()
+}
+
+// ToArchiveEntryName() - converts a file path to a name inside an
+// archive, removes an optional prefix and leading `..` segments from it
+func ToArchiveEntryName(filePath string, stripPrefix string) string {
+	name := path.Clean(filepath.ToSlash(filePath))
+
+	stripPrefix = strings.TrimSpace(stripPrefix)
+	if stripPrefix != "" {
+		// only whole path segments, so `fo` does not strip `foo/x`
+		prefix := path.Clean(filepath.ToSlash(stripPrefix))
+		if prefix != "." && prefix != "/" {
+			if name == prefix {
+				name = ""
+			} else if strings.HasPrefix(name, prefix+"/") {
+				name = name[len(prefix)+1:]
+			}
+		}
+	}
+
+	// like tar, do not store entries outside of the archive root
+	name = strings.TrimLeft(name, "/")
+	for name == ".." || strings.HasPrefix(name, "../") {
+		name = strings.TrimLeft(strings.TrimPrefix(name, ".."), "/")
+	}
+
+	return name
+}
+
+func isReproducibleArchiveEntry(options ...AddFileToArchiveOptions) bool {
+	for _, o := range options {
+		if o.Reproducible {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeArchiveEntryTo(targetPath string, r io.Reader, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(targetPath), 0750)
+	if err != nil {
+		return err
+	}
+
+	if mode == 0 {
+		mode = 0640
+	}
+
+	file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(file, r)
+	return err
+}
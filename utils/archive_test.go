@@ -0,0 +1,365 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package utils
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+var maliciousArchiveEntryNames = []string{
+	"../../etc/passwd",
+	"sub/../../../outside.txt",
+	"/etc/passwd",
+}
+
+func createTestTar(t *testing.T, names ...string) []byte {
+	var buffer bytes.Buffer
+
+	tarWriter := tar.NewWriter(&buffer)
+	for _, name := range names {
+		content := []byte("content of " + name)
+
+		err := tarWriter.WriteHeader(&tar.Header{
+			Mode:     0640,
+			Name:     name,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = tarWriter.Write(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := tarWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func createTestZip(t *testing.T, names ...string) []byte {
+	var buffer bytes.Buffer
+
+	zipWriter := zip.NewWriter(&buffer)
+	for _, name := range names {
+		// `Create()` would not keep names like `/etc/passwd` unchanged
+		fileWriter, err := zipWriter.CreateHeader(&zip.FileHeader{
+			Method: zip.Deflate,
+			Name:   name,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = fileWriter.Write([]byte("content of " + name))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := zipWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+// assertNothingOutside() - checks that `parentDir` contains only `targetDir`
+func assertNothingOutside(t *testing.T, parentDir string, targetDir string) {
+	entries, err := os.ReadDir(parentDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range entries {
+		if filepath.Join(parentDir, e.Name()) != targetDir {
+			t.Errorf("'%v' has been written outside of target directory", e.Name())
+		}
+	}
+}
+
+func TestExtractTar(t *testing.T) {
+	targetDir := filepath.Join(t.TempDir(), "target")
+
+	extractedFiles, err := ExtractTar(bytes.NewReader(createTestTar(t, "a.txt", "sub/b.txt")), targetDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(extractedFiles) != 2 {
+		t.Fatalf("expected 2 extracted files, got %v", len(extractedFiles))
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetDir, "sub", "b.txt"))
+	if err != nil || string(data) != "content of sub/b.txt" {
+		t.Errorf("unexpected content '%v' (%v)", string(data), err)
+	}
+}
+
+func TestExtractTarRejectsMaliciousEntries(t *testing.T) {
+	for _, name := range maliciousArchiveEntryNames {
+		t.Run(name, func(t *testing.T) {
+			parentDir := t.TempDir()
+			targetDir := filepath.Join(parentDir, "target")
+
+			_, err := ExtractTar(bytes.NewReader(createTestTar(t, name)), targetDir)
+			if err == nil {
+				t.Errorf("expected error for entry '%v'", name)
+			}
+
+			assertNothingOutside(t, parentDir, targetDir)
+		})
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	targetDir := filepath.Join(t.TempDir(), "target")
+
+	zipData := createTestZip(t, "a.txt", "sub/b.txt")
+
+	extractedFiles, err := ExtractZip(bytes.NewReader(zipData), int64(len(zipData)), targetDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(extractedFiles) != 2 {
+		t.Fatalf("expected 2 extracted files, got %v", len(extractedFiles))
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetDir, "a.txt"))
+	if err != nil || string(data) != "content of a.txt" {
+		t.Errorf("unexpected content '%v' (%v)", string(data), err)
+	}
+}
+
+func TestExtractZipRejectsMaliciousEntries(t *testing.T) {
+	for _, name := range maliciousArchiveEntryNames {
+		t.Run(name, func(t *testing.T) {
+			parentDir := t.TempDir()
+			targetDir := filepath.Join(parentDir, "target")
+
+			zipData := createTestZip(t, name)
+
+			_, err := ExtractZip(bytes.NewReader(zipData), int64(len(zipData)), targetDir)
+			if err == nil {
+				t.Errorf("expected error for entry '%v'", name)
+			}
+
+			assertNothingOutside(t, parentDir, targetDir)
+		})
+	}
+}
+
+func TestToArchiveEntryName(t *testing.T) {
+	tests := []struct {
+		filePath    string
+		stripPrefix string
+		expected    string
+	}{
+		{"src/main.go", "", "src/main.go"},
+		{"./src/main.go", "", "src/main.go"},
+		{"src/main.go", "src", "main.go"},
+		{"src/main.go", "src/", "main.go"},
+		{"src/main.go", "./src", "main.go"},
+		{"foo/x", "fo", "foo/x"},
+		{"foo/x", "foo/x", ""},
+		{"/abs/path/file", "", "abs/path/file"},
+		{"src/main.go", ".", "src/main.go"},
+		{"../src/main.go", "", "src/main.go"},
+		{"../../src/main.go", "", "src/main.go"},
+		{"/../src/main.go", "", "src/main.go"},
+		{"src/../../main.go", "", "main.go"},
+		{"..", "", ""},
+		{"../src/main.go", "../src", "main.go"},
+	}
+
+	for _, test := range tests {
+		actual := ToArchiveEntryName(test.filePath, test.stripPrefix)
+		if actual != test.expected {
+			t.Errorf("'%v' without '%v': expected '%v', got '%v'", test.filePath, test.stripPrefix, test.expected, actual)
+		}
+	}
+}
+
+func TestCollectArchiveEntries(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"a.txt":       "a",
+		"sub/b.txt":   "b",
+		"debug.log":   "log",
+		".gpmignore":  "*.log\n",
+		"tmp/c.txt":   "c",
+		"sub/d.log":   "d",
+		"sub/e/f.txt": "f",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+
+		err := os.MkdirAll(filepath.Dir(p), 0750)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(p, []byte(content), 0640)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := CollectArchiveEntries(dir, "project", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for _, e := range entries {
+		names = append(names, e.Name)
+
+		if !filepath.IsAbs(e.FilePath) {
+			t.Errorf("file path '%v' is not based on the resolved directory", e.FilePath)
+		}
+	}
+	slices.Sort(names)
+
+	expected := []string{
+		"project/.gpmignore",
+		"project/a.txt",
+		"project/sub/b.txt",
+		"project/sub/e/f.txt",
+		"project/tmp/c.txt",
+	}
+	if !slices.Equal(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
+
+func createReproducibleTestArchives(t *testing.T, dir string, names []string) ([]byte, []byte) {
+	var tarBuffer bytes.Buffer
+	var zipBuffer bytes.Buffer
+
+	tarWriter := tar.NewWriter(&tarBuffer)
+	zipWriter := zip.NewWriter(&zipBuffer)
+
+	options := AddFileToArchiveOptions{
+		Reproducible: true,
+	}
+	for _, name := range names {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+
+		err := AddFileToTar(tarWriter, filePath, name, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = AddFileToZip(zipWriter, filePath, name, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := tarWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = zipWriter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return tarBuffer.Bytes(), zipBuffer.Bytes()
+}
+
+func TestReproducibleArchives(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+
+	dir := t.TempDir()
+
+	names := []string{"a.txt", "sub", "sub/b.txt"}
+	for _, name := range names {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+
+		var err error
+		if filepath.Ext(name) == "" {
+			err = os.Mkdir(p, 0750)
+		} else {
+			err = os.WriteFile(p, []byte("content of "+name), 0640)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tar1, zip1 := createReproducibleTestArchives(t, dir, names)
+
+	// same content, but other timestamps
+	otherTime := time.Date(2001, time.February, 3, 4, 5, 6, 0, time.UTC)
+	for _, name := range names {
+		err := os.Chtimes(filepath.Join(dir, filepath.FromSlash(name)), otherTime, otherTime)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tar2, zip2 := createReproducibleTestArchives(t, dir, names)
+
+	if !bytes.Equal(tar1, tar2) {
+		t.Error("tar archives of same tree are different")
+	}
+	if !bytes.Equal(zip1, zip2) {
+		t.Error("zip archives of same tree are different")
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(zip2), int64(len(zip2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range zipReader.File {
+		if !f.Modified.Equal(GetReproducibleModTime()) {
+			t.Errorf("entry '%v' has timestamp %v", f.Name, f.Modified)
+		}
+	}
+}
+
+func TestGetReproducibleModTime(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	expected := time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+	if modTime := GetReproducibleModTime(); !modTime.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, modTime)
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	expected = time.Unix(1700000000, 0).UTC()
+	if modTime := GetReproducibleModTime(); !modTime.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, modTime)
+	}
+}
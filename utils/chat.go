@@ -11,8 +11,11 @@ type ChatPromptSuggestion struct {
 // in a very generic and platform independent form
 func GetChatPromptSugesstions() []ChatPromptSuggestion {
 	return []ChatPromptSuggestion{
+		{Text: "/attach <file or url>", Description: "add content of a text file to conversation"},
 		{Text: "/cls", Description: "clear screen"},
+		{Text: "/edit [text]", Description: "edit last message and send it again"},
 		{Text: "/exit", Description: "exit application"},
+		{Text: "/export <file>", Description: "write conversation to a Markdown file"},
 		{Text: "/format <name>", Description: "formatter for console output"},
 		{Text: "/info", Description: "print information about current chat settings and status"},
 		{Text: "/model <name>", Description: "switch to another model"},
@@ -21,5 +24,6 @@ func GetChatPromptSugesstions() []ChatPromptSuggestion {
 		{Text: "/style <name>", Description: "console style"},
 		{Text: "/system <text>", Description: "reset conversation and update system prompt"},
 		{Text: "/temp <value>", Description: "custom temperature value"},
+		{Text: "/undo", Description: "remove last message and its answer from conversation"},
 	}
 }
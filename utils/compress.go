@@ -0,0 +1,190 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// CompressionAlgorithmGzip is the name of the gzip algorithm
+const CompressionAlgorithmGzip = "gzip"
+
+// CompressionAlgorithmXz is the name of the xz algorithm
+const CompressionAlgorithmXz = "xz"
+
+// CompressionAlgorithmZlib is the name of the zlib algorithm
+const CompressionAlgorithmZlib = "zlib"
+
+// CompressionAlgorithmZstd is the name of the zstd algorithm
+const CompressionAlgorithmZstd = "zstd"
+
+var gzipMagic = []byte{0x1f, 0x8b}
+var xzMagic = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// CreateCompressor() - creates a new io.WriteCloser which compresses
+// all data written to it with a specific algorithm to `w`
+func CreateCompressor(w io.Writer, algorithm string, level int) (io.WriteCloser, error) {
+	algorithm, err := NormalizeCompressionAlgorithm(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	switch algorithm {
+	case CompressionAlgorithmGzip:
+		return gzip.NewWriterLevel(w, level)
+	case CompressionAlgorithmXz:
+		return xz.NewWriter(w)
+	case CompressionAlgorithmZlib:
+		return zlib.NewWriterLevel(w, level)
+	case CompressionAlgorithmZstd:
+		encoderLevel := zstd.SpeedDefault
+		if level > 0 {
+			encoderLevel = zstd.EncoderLevelFromZstd(level)
+		}
+
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(encoderLevel))
+	}
+
+	return nil, fmt.Errorf("compression with '%v' is not supported", algorithm)
+}
+
+// CreateDecompressor() - creates a new io.ReadCloser which decompresses
+// data from `r`; if `algorithm` is empty or `auto`, the algorithm is
+// detected from the magic bytes of the input or the extensions of
+// optional `fileNames`
+func CreateDecompressor(r io.Reader, algorithm string, fileNames ...string) (io.ReadCloser, error) {
+	algorithm = strings.TrimSpace(strings.ToLower(algorithm))
+
+	bufferedReader := bufio.NewReader(r)
+
+	if algorithm == "" || algorithm == "auto" {
+		header, _ := bufferedReader.Peek(6)
+
+		algorithm = DetectCompressionAlgorithm(header)
+		if algorithm == "" || algorithm == CompressionAlgorithmZlib {
+			// zlib header is only a checksum, so a known extension wins
+			for _, fn := range fileNames {
+				algorithmByName := DetectCompressionAlgorithmByFileName(fn)
+				if algorithmByName != "" {
+					algorithm = algorithmByName
+					break
+				}
+			}
+		}
+		if algorithm == "" {
+			return nil, fmt.Errorf("could not detect compression algorithm of input")
+		}
+	}
+
+	algorithm, err := NormalizeCompressionAlgorithm(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	switch algorithm {
+	case CompressionAlgorithmGzip:
+		return gzip.NewReader(bufferedReader)
+	case CompressionAlgorithmXz:
+		xzReader, err := xz.NewReader(bufferedReader)
+		if err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(xzReader), nil
+	case CompressionAlgorithmZlib:
+		return zlib.NewReader(bufferedReader)
+	case CompressionAlgorithmZstd:
+		zstdDecoder, err := zstd.NewReader(bufferedReader)
+		if err != nil {
+			return nil, err
+		}
+
+		return zstdDecoder.IOReadCloser(), nil
+	}
+
+	return nil, fmt.Errorf("decompression of '%v' is not supported", algorithm)
+}
+
+// DetectCompressionAlgorithm() - detects the compression algorithm
+// by the magic bytes of `header` or returns an empty string if unknown
+func DetectCompressionAlgorithm(header []byte) string {
+	if bytes.HasPrefix(header, gzipMagic) {
+		return CompressionAlgorithmGzip
+	}
+	if bytes.HasPrefix(header, zstdMagic) {
+		return CompressionAlgorithmZstd
+	}
+	if bytes.HasPrefix(header, xzMagic) {
+		return CompressionAlgorithmXz
+	}
+	if len(header) >= 2 && header[0]&0x0f == 0x08 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return CompressionAlgorithmZlib
+	}
+
+	return ""
+}
+
+// DetectCompressionAlgorithmByFileName() - detects the compression algorithm
+// by the extension of a file name or returns an empty string if unknown
+func DetectCompressionAlgorithmByFileName(fileName string) string {
+	switch strings.ToLower(filepath.Ext(strings.TrimSpace(fileName))) {
+	case ".gz", ".tgz":
+		return CompressionAlgorithmGzip
+	case ".txz", ".xz":
+		return CompressionAlgorithmXz
+	case ".zlib", ".zz":
+		return CompressionAlgorithmZlib
+	case ".tzst", ".zst":
+		return CompressionAlgorithmZstd
+	}
+
+	return ""
+}
+
+// NormalizeCompressionAlgorithm() - returns the normalized name of
+// a compression algorithm or an error if it is unknown
+func NormalizeCompressionAlgorithm(algorithm string) (string, error) {
+	switch strings.TrimSpace(strings.ToLower(algorithm)) {
+	case "", "gz", "gzip":
+		return CompressionAlgorithmGzip, nil
+	case "xz":
+		return CompressionAlgorithmXz, nil
+	case "zlib", "zz":
+		return CompressionAlgorithmZlib, nil
+	case "zst", "zstd":
+		return CompressionAlgorithmZstd, nil
+	}
+
+	return "", fmt.Errorf("unknown compression algorithm '%v'", algorithm)
+}
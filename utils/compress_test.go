@@ -0,0 +1,131 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package utils
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("Go Package Manager "), 1000)
+
+	algorithms := []string{
+		CompressionAlgorithmGzip,
+		CompressionAlgorithmXz,
+		CompressionAlgorithmZlib,
+		CompressionAlgorithmZstd,
+	}
+
+	for _, algorithm := range algorithms {
+		t.Run(algorithm, func(t *testing.T) {
+			var compressed bytes.Buffer
+
+			compressor, err := CreateCompressor(&compressed, algorithm, -1)
+			if err != nil {
+				t.Fatalf("could not create compressor: %v", err)
+			}
+
+			_, err = io.Copy(compressor, bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("could not compress: %v", err)
+			}
+			err = compressor.Close()
+			if err != nil {
+				t.Fatalf("could not close compressor: %v", err)
+			}
+
+			if compressed.Len() >= len(data) {
+				t.Errorf("compressed size %v is not smaller than %v", compressed.Len(), len(data))
+			}
+
+			if detected := DetectCompressionAlgorithm(compressed.Bytes()); detected != algorithm {
+				t.Errorf("detected algorithm is '%v', expected '%v'", detected, algorithm)
+			}
+
+			for _, a := range []string{algorithm, "auto"} {
+				decompressor, err := CreateDecompressor(bytes.NewReader(compressed.Bytes()), a)
+				if err != nil {
+					t.Fatalf("could not create decompressor with '%v': %v", a, err)
+				}
+
+				uncompressed, err := io.ReadAll(decompressor)
+				decompressor.Close()
+				if err != nil {
+					t.Fatalf("could not decompress with '%v': %v", a, err)
+				}
+
+				if !bytes.Equal(uncompressed, data) {
+					t.Errorf("uncompressed data with '%v' does not match original", a)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectCompressionAlgorithmByFileName(t *testing.T) {
+	tests := []struct {
+		fileName string
+		expected string
+	}{
+		{"file.txt.gz", CompressionAlgorithmGzip},
+		{"archive.tgz", CompressionAlgorithmGzip},
+		{"file.txt.xz", CompressionAlgorithmXz},
+		{"archive.TXZ", CompressionAlgorithmXz},
+		{"file.zz", CompressionAlgorithmZlib},
+		{"file.txt.zst", CompressionAlgorithmZstd},
+		{"archive.tzst", CompressionAlgorithmZstd},
+		{"file.txt", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		actual := DetectCompressionAlgorithmByFileName(test.fileName)
+		if actual != test.expected {
+			t.Errorf("'%v': expected '%v', got '%v'", test.fileName, test.expected, actual)
+		}
+	}
+}
+
+func TestCreateDecompressorAutoDetection(t *testing.T) {
+	_, err := CreateDecompressor(bytes.NewReader([]byte("plain text")), "auto")
+	if err == nil {
+		t.Fatalf("expected error for undetectable input")
+	}
+
+	var compressed bytes.Buffer
+	compressor, _ := CreateCompressor(&compressed, CompressionAlgorithmZlib, -1)
+	compressor.Write([]byte("Hello"))
+	compressor.Close()
+
+	decompressor, err := CreateDecompressor(bytes.NewReader(compressed.Bytes()), "auto", "file.zz")
+	if err != nil {
+		t.Fatalf("could not create decompressor: %v", err)
+	}
+	defer decompressor.Close()
+
+	uncompressed, err := io.ReadAll(decompressor)
+	if err != nil || string(uncompressed) != "Hello" {
+		t.Errorf("unexpected result '%v' (%v)", string(uncompressed), err)
+	}
+}
@@ -0,0 +1,71 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// CronSchedule stores a parsed cron expression
+type CronSchedule struct {
+	Location *time.Location // the time zone in which the schedule is evaluated
+
+	schedule cron.Schedule
+}
+
+// cronParser parses expressions with 5 fields or 6 fields with leading seconds
+// and descriptors like `@daily` or `@every 5m`
+var cronParser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+// ParseCronExpression() - parses a cron expression with 5 fields
+// (`minute hour day-of-month month day-of-week`), 6 fields (with leading
+// seconds) or a descriptor like `@hourly` and evaluates it in `loc`
+func ParseCronExpression(expr string, loc *time.Location) (*CronSchedule, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	schedule, err := cronParser.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec, ok := schedule.(*cron.SpecSchedule); ok {
+		spec.Location = loc
+	}
+
+	return &CronSchedule{
+		Location: loc,
+		schedule: schedule,
+	}, nil
+}
+
+// s.Next() - returns the next time after `t` on which the schedule matches
+// or the zero time if there is no such time
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t)
+}
@@ -0,0 +1,104 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronExpressionInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * FOO",
+		"@foo",
+	} {
+		if _, err := ParseCronExpression(expr, time.UTC); err == nil {
+			t.Errorf("expected error for '%v'", expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2024, time.January, 31, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, time.January, 31, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2024, time.February, 1, 9, 0, 0, 0, time.UTC)},
+		{"30 * * * * *", time.Date(2024, time.January, 31, 10, 18, 30, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * JUN SUN", time.Date(2024, time.June, 2, 12, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 5m", time.Date(2024, time.January, 31, 10, 22, 30, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		schedule, err := ParseCronExpression(test.expr, time.UTC)
+		if err != nil {
+			t.Fatalf("'%v': %v", test.expr, err)
+		}
+
+		next := schedule.Next(from)
+		if !next.Equal(test.expected) {
+			t.Errorf("'%v': expected %v, got %v", test.expr, test.expected, next)
+		}
+	}
+}
+
+func TestCronScheduleNextWithLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+
+	schedule, err := ParseCronExpression("0 9 * * *", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 08:00 UTC is 10:00 in UTC+2, so 09:00 has passed on that day
+	next := schedule.Next(time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC))
+
+	expected := time.Date(2024, time.March, 2, 7, 0, 0, 0, time.UTC)
+	if !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+}
+
+func TestCronScheduleNextNeverMatches(t *testing.T) {
+	schedule, err := ParseCronExpression("0 0 30 2 *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if next := schedule.Next(time.Now()); !next.IsZero() {
+		t.Errorf("expected zero time, got %v", next)
+	}
+}
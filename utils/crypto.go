@@ -24,7 +24,9 @@ package utils
 
 import (
 	"crypto/sha256"
+	"crypto/subtle"
 	"fmt"
+	"strings"
 )
 
 // HashSHA256() - hashes a byte array with SHA256 and
@@ -38,3 +40,32 @@ func HashSHA256(data []byte) string {
 
 	return fmt.Sprintf("%x", hashBytes)
 }
+
+// ParseSHA256ChecksumFile() - extracts the hex hash from the content
+// of a checksum file like the output of `sha256sum`
+func ParseSHA256ChecksumFile(content []byte) (string, error) {
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("checksum file is empty")
+	}
+
+	checksum := strings.ToLower(fields[0])
+	if len(checksum) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid SHA256 checksum '%v'", checksum)
+	}
+
+	return checksum, nil
+}
+
+// VerifySHA256() - checks if the SHA256 hash of data matches
+// an expected hex string
+func VerifySHA256(data []byte, expectedChecksum string) error {
+	expectedChecksum = strings.TrimSpace(strings.ToLower(expectedChecksum))
+	actualChecksum := HashSHA256(data)
+
+	if subtle.ConstantTimeCompare([]byte(actualChecksum), []byte(expectedChecksum)) != 1 {
+		return fmt.Errorf("SHA256 checksum mismatch: expected '%v' but got '%v'", expectedChecksum, actualChecksum)
+	}
+
+	return nil
+}
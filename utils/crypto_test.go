@@ -0,0 +1,67 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifySHA256(t *testing.T) {
+	data := []byte("foo")
+	checksum := "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	if err := VerifySHA256(data, checksum); err != nil {
+		t.Error(err)
+	}
+	if err := VerifySHA256(data, strings.ToUpper(checksum)); err != nil {
+		t.Error(err)
+	}
+	if err := VerifySHA256([]byte("bar"), checksum); err == nil {
+		t.Error("expected checksum mismatch")
+	}
+}
+
+func TestParseSHA256ChecksumFile(t *testing.T) {
+	checksum := "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+
+	for _, content := range []string{
+		checksum,
+		checksum + "\n",
+		strings.ToUpper(checksum) + "  gpm_linux_amd64.tar.gz\n",
+	} {
+		parsed, err := ParseSHA256ChecksumFile([]byte(content))
+		if err != nil {
+			t.Fatalf("%q: %v", content, err)
+		}
+		if parsed != checksum {
+			t.Errorf("%q: expected '%v', got '%v'", content, checksum, parsed)
+		}
+	}
+
+	for _, content := range []string{"", "  \n", "abc  file.txt"} {
+		if _, err := ParseSHA256ChecksumFile([]byte(content)); err == nil {
+			t.Errorf("%q: expected error", content)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// UnifiedDiffOptions stores settings for `UnifiedDiff()` function
+type UnifiedDiffOptions struct {
+	ContextLines *int // number of unchanged lines around changes, default 3
+}
+
+// UnifiedDiff() - creates a unified diff of two texts, like `diff -u` does,
+// or an empty string if both are equal
+func UnifiedDiff(nameA string, textA string, nameB string, textB string, options ...UnifiedDiffOptions) (string, error) {
+	contextLines := 3
+	for _, o := range options {
+		if o.ContextLines != nil {
+			contextLines = *o.ContextLines
+		}
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitDiffLines(textA),
+		B:        splitDiffLines(textB),
+		FromFile: nameA,
+		ToFile:   nameB,
+		Context:  contextLines,
+	})
+}
+
+// splitDiffLines() - splits a text into lines for a diff, where each line
+// keeps its line break and a missing one at the end is marked like `diff` does
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	lastLine := lines[len(lines)-1]
+	if !strings.HasSuffix(lastLine, "\n") {
+		lines[len(lines)-1] = lastLine + "\n\\ No newline at end of file\n"
+	}
+
+	return lines
+}
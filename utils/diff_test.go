@@ -0,0 +1,126 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		textA    string
+		textB    string
+		expected string
+	}{
+		{"equal", "a\nb\n", "a\nb\n", ""},
+		{"both empty", "", "", ""},
+		{"old empty", "", "a\nb\n", "--- a.txt\n+++ b.txt\n@@ -0,0 +1,2 @@\n+a\n+b\n"},
+		{"new empty", "a\n", "", "--- a.txt\n+++ b.txt\n@@ -1 +0,0 @@\n-a\n"},
+		{"changed line", "a\nb\nc\n", "a\nB\nc\n", "--- a.txt\n+++ b.txt\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n"},
+		{"CRLF", "a\r\nb\r\n", "a\nb\n", ""},
+		{"no trailing newline in old", "a\nb", "a\nb\n", "--- a.txt\n+++ b.txt\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n"},
+		{"no trailing newline in new", "a\n", "a\nb", "--- a.txt\n+++ b.txt\n@@ -1 +1,2 @@\n a\n+b\n\\ No newline at end of file\n"},
+		{"no trailing newline in both", "a", "a", ""},
+	}
+
+	for _, test := range tests {
+		actual, err := UnifiedDiff("a.txt", test.textA, "b.txt", test.textB)
+		if err != nil {
+			t.Fatalf("%v: %v", test.name, err)
+		}
+
+		if actual != test.expected {
+			t.Errorf("%v: expected %q, got %q", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestUnifiedDiffHunks(t *testing.T) {
+	var linesA []string
+	for i := 1; i <= 20; i++ {
+		linesA = append(linesA, fmt.Sprint(i))
+	}
+	textA := strings.Join(linesA, "\n") + "\n"
+
+	// changes in line 3 and 8 are close enough to share their context
+	textB := strings.Replace(strings.Replace(textA, "\n3\n", "\nthree\n", 1), "\n8\n", "\neight\n", 1)
+
+	actual, err := UnifiedDiff("a.txt", textA, "b.txt", textB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "--- a.txt\n+++ b.txt\n" +
+		"@@ -1,11 +1,11 @@\n 1\n 2\n-3\n+three\n 4\n 5\n 6\n 7\n-8\n+eight\n 9\n 10\n 11\n"
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	// a change in line 18 gets its own hunk
+	textB = strings.Replace(textB, "\n18\n", "\neighteen\n", 1)
+
+	actual, err = UnifiedDiff("a.txt", textA, "b.txt", textB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected += "@@ -15,6 +15,6 @@\n 15\n 16\n 17\n-18\n+eighteen\n 19\n 20\n"
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+
+	// custom number of context lines
+	contextLines := 1
+	actual, err = UnifiedDiff("a.txt", textA, "b.txt", textB, UnifiedDiffOptions{ContextLines: &contextLines})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Count(actual, "@@ -") != 3 {
+		t.Errorf("expected 3 hunks, got %q", actual)
+	}
+	if !strings.Contains(actual, "@@ -2,3 +2,3 @@\n 2\n-3\n+three\n 4\n") {
+		t.Errorf("expected hunk with 1 context line, got %q", actual)
+	}
+}
+
+func TestUnifiedDiffWithLargeInput(t *testing.T) {
+	var lines []string
+	for i := 0; i < 20000; i++ {
+		lines = append(lines, fmt.Sprintf("line %v", i))
+	}
+	textA := strings.Join(lines, "\n") + "\n"
+	textB := strings.Replace(textA, "line 10000\n", "changed\n", 1)
+
+	actual, err := UnifiedDiff("a.txt", textA, "b.txt", textB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(actual, "-line 10000\n+changed\n") {
+		t.Errorf("expected changed line in diff, got %q", actual)
+	}
+}
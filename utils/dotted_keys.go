@@ -0,0 +1,175 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FlattenMapByDottedKeys() - converts a nested map to a flat one
+// with dotted keys like `execute.blocklist`
+func FlattenMapByDottedKeys(m map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+
+	var flatten func(prefix string, value interface{})
+	flatten = func(prefix string, value interface{}) {
+		nestedMap, ok := toStringMap(value)
+		if ok && len(nestedMap) > 0 {
+			for k, v := range nestedMap {
+				key := k
+				if prefix != "" {
+					key = prefix + "." + k
+				}
+
+				flatten(key, v)
+			}
+			return
+		}
+
+		result[prefix] = value
+	}
+
+	for k, v := range m {
+		flatten(k, v)
+	}
+
+	return result
+}
+
+// GetValueByDottedKey() - returns a value of a nested map by a dotted key like `audit.ignore`
+func GetValueByDottedKey(m map[string]interface{}, key string) (interface{}, bool) {
+	var current interface{} = m
+
+	for _, part := range splitDottedKey(key) {
+		currentMap, ok := toStringMap(current)
+		if !ok {
+			return nil, false
+		}
+
+		current, ok = currentMap[part]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// GetSortedKeys() - returns the keys of a map in sorted order
+func GetSortedKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// SetValueByDottedKey() - sets a value of a nested map by a dotted key like `audit.ignore`
+// and creates missing parents
+func SetValueByDottedKey(m map[string]interface{}, key string, value interface{}) error {
+	parts := splitDottedKey(key)
+	if len(parts) == 0 {
+		return fmt.Errorf("key is empty")
+	}
+
+	currentMap := m
+	for i, part := range parts[:len(parts)-1] {
+		next, ok := currentMap[part]
+		if !ok || next == nil {
+			next = map[string]interface{}{}
+			currentMap[part] = next
+		}
+
+		nextMap, ok := toStringMap(next)
+		if !ok {
+			return fmt.Errorf("'%v' is no object", strings.Join(parts[:i+1], "."))
+		}
+
+		currentMap[part] = nextMap
+		currentMap = nextMap
+	}
+
+	currentMap[parts[len(parts)-1]] = value
+	return nil
+}
+
+// UnsetValueByDottedKey() - removes a value of a nested map by a dotted key
+// like `audit.ignore` and returns `true` if it has existed
+func UnsetValueByDottedKey(m map[string]interface{}, key string) bool {
+	parts := splitDottedKey(key)
+	if len(parts) == 0 {
+		return false
+	}
+
+	if len(parts) == 1 {
+		_, ok := m[parts[0]]
+		delete(m, parts[0])
+
+		return ok
+	}
+
+	next, ok := toStringMap(m[parts[0]])
+	if !ok {
+		return false
+	}
+
+	isRemoved := UnsetValueByDottedKey(next, strings.Join(parts[1:], "."))
+	if len(next) == 0 {
+		delete(m, parts[0]) // remove empty parents
+	} else {
+		m[parts[0]] = next
+	}
+
+	return isRemoved
+}
+
+func splitDottedKey(key string) []string {
+	parts := []string{}
+	for _, p := range strings.Split(key, ".") {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return parts
+}
+
+func toStringMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, val := range v {
+			m[fmt.Sprint(k)] = val
+		}
+		return m, true
+	}
+
+	return nil, false
+}
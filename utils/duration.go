@@ -0,0 +1,85 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"fmt"
+	"math"
+	mathRand "math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AddJitter() - adds a random offset between 0 and `jitter` to a duration
+func AddJitter(d time.Duration, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+
+	maxOffset := int64(jitter)
+	if maxOffset < math.MaxInt64 {
+		maxOffset++ // include `jitter` itself
+	}
+
+	offset := time.Duration(mathRand.Int63n(maxOffset))
+	if d > time.Duration(math.MaxInt64)-offset {
+		return time.Duration(math.MaxInt64) // would overflow
+	}
+
+	return d + offset
+}
+
+// ParseDuration() - parses a duration like `500ms`, `2m` or `1h30m`,
+// plain numbers are handled as seconds
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	seconds, err := strconv.ParseFloat(s, 64)
+	if err == nil {
+		if math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+			return 0, fmt.Errorf("invalid duration '%v'", s)
+		}
+		if seconds < 0 {
+			return 0, fmt.Errorf("negative duration '%v'", s)
+		}
+		if seconds*float64(time.Second) >= math.MaxInt64 {
+			return 0, fmt.Errorf("duration '%v' is out of range", s)
+		}
+
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%v'", s)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration '%v'", s)
+	}
+
+	return d, nil
+}
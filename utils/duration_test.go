@@ -0,0 +1,98 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package utils
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	valid := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"0", 0},
+		{"5", 5 * time.Second},
+		{" 1.5 ", 1500 * time.Millisecond},
+		{"500ms", 500 * time.Millisecond},
+		{"2m", 2 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+	}
+	for _, test := range valid {
+		actual, err := ParseDuration(test.input)
+		if err != nil {
+			t.Errorf("'%v': unexpected error: %v", test.input, err)
+			continue
+		}
+
+		if actual != test.expected {
+			t.Errorf("'%v': expected %v, got %v", test.input, test.expected, actual)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"   ",
+		"abc",
+		"-1",
+		"-5s",
+		"inf",
+		"+Inf",
+		"-inf",
+		"NaN",
+		"1e300",
+		"9223372037",
+		"10ms5",
+		"99999999999h",
+	}
+	for _, input := range invalid {
+		actual, err := ParseDuration(input)
+		if err == nil {
+			t.Errorf("'%v': expected error, got %v", input, actual)
+		}
+	}
+}
+
+func TestAddJitter(t *testing.T) {
+	d := 10 * time.Second
+
+	if AddJitter(d, 0) != d {
+		t.Error("duration without jitter has been changed")
+	}
+	if AddJitter(d, -time.Second) != d {
+		t.Error("duration with negative jitter has been changed")
+	}
+
+	for i := 0; i < 100; i++ {
+		actual := AddJitter(d, time.Second)
+		if actual < d || actual > d+time.Second {
+			t.Fatalf("%v is not between %v and %v", actual, d, d+time.Second)
+		}
+	}
+
+	actual := AddJitter(time.Duration(math.MaxInt64), time.Duration(math.MaxInt64))
+	if actual < 0 {
+		t.Errorf("jitter overflows to %v", actual)
+	}
+}
@@ -0,0 +1,171 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// GlobPatternPrefix - prefix for patterns in `files` sections, which
+// should be handled as glob instead of regular expression
+const GlobPatternPrefix = "glob:"
+
+// CompileFilePattern() - compiles a pattern of a `files` section, which is
+// a regular expression or a glob with `glob:` prefix, like `glob:**/*.go`
+func CompileFilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, GlobPatternPrefix) {
+		regexPattern, err := GlobToRegex(strings.TrimPrefix(pattern, GlobPatternPrefix))
+		if err != nil {
+			return nil, err
+		}
+
+		pattern = regexPattern
+	}
+
+	return regexp.Compile(pattern)
+}
+
+// GlobToRegex() - converts a glob pattern with `**` support, like `**/*.go`,
+// to a regular expression, which matches relative paths with `/` as separator
+func GlobToRegex(pattern string) (string, error) {
+	var r strings.Builder
+	r.WriteString("^")
+
+	runes := []rune(pattern)
+	inAlternatives := false
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		switch c {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				// `**`
+				i++
+				if i+1 < len(runes) && runes[i+1] == '/' {
+					// `**/` => zero or more directories
+					i++
+					r.WriteString("(?:.*/)?")
+				} else {
+					r.WriteString(".*")
+				}
+			} else {
+				r.WriteString("[^/]*")
+			}
+		case '?':
+			r.WriteString("[^/]")
+		case '[':
+			end := -1
+			for j := i + 1; j < len(runes); j++ {
+				if runes[j] == ']' {
+					end = j
+					break
+				}
+			}
+			if end < 0 {
+				return "", fmt.Errorf("missing ']' in glob pattern '%v'", pattern)
+			}
+
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			r.WriteString("[" + class + "]")
+			i = end
+		case '{':
+			if inAlternatives {
+				return "", fmt.Errorf("nested '{' in glob pattern '%v'", pattern)
+			}
+
+			inAlternatives = true
+			r.WriteString("(?:")
+		case '}':
+			if !inAlternatives {
+				return "", fmt.Errorf("unexpected '}' in glob pattern '%v'", pattern)
+			}
+
+			inAlternatives = false
+			r.WriteString(")")
+		case ',':
+			if inAlternatives {
+				r.WriteString("|")
+			} else {
+				r.WriteString(",")
+			}
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				r.WriteString(regexp.QuoteMeta(string(runes[i])))
+			} else {
+				r.WriteString(regexp.QuoteMeta(string(c)))
+			}
+		default:
+			r.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if inAlternatives {
+		return "", fmt.Errorf("missing '}' in glob pattern '%v'", pattern)
+	}
+
+	r.WriteString("$")
+	return r.String(), nil
+}
+
+// ListFilesByGlob() - lists all files and directories inside `dir`,
+// whose relative path matches a glob pattern like `**/*.go`
+func ListFilesByGlob(dir string, pattern string) ([]string, error) {
+	var matchingFiles []string
+
+	regexPattern, err := GlobToRegex(pattern)
+	if err != nil {
+		return matchingFiles, err
+	}
+
+	r, err := regexp.Compile(regexPattern)
+	if err != nil {
+		return matchingFiles, err
+	}
+
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		if r.MatchString(filepath.ToSlash(relPath)) {
+			matchingFiles = append(matchingFiles, p)
+		}
+
+		return nil
+	})
+	return matchingFiles, err
+}
@@ -0,0 +1,122 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestGlobToRegex(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		matches   []string
+		noMatches []string
+	}{
+		{"*.go", []string{"main.go"}, []string{"cmd/main.go", "main.go.bak"}},
+		{"**/*.go", []string{"main.go", "cmd/main.go", "a/b/c.go"}, []string{"main.txt"}},
+		{"cmd/**", []string{"cmd/a", "cmd/a/b.go"}, []string{"cmd", "other/cmd/a"}},
+		{"file?.txt", []string{"file1.txt"}, []string{"file10.txt", "file/.txt"}},
+		{"[ab].txt", []string{"a.txt", "b.txt"}, []string{"c.txt"}},
+		{"[!ab].txt", []string{"c.txt"}, []string{"a.txt"}},
+		{"*.{md,txt}", []string{"README.md", "LICENSE.txt"}, []string{"main.go"}},
+		{"a+b (1).txt", []string{"a+b (1).txt"}, []string{"aab (1).txt"}},
+		{`\*.txt`, []string{"*.txt"}, []string{"a.txt"}},
+	}
+
+	for _, test := range tests {
+		rx, err := CompileFilePattern(GlobPatternPrefix + test.pattern)
+		if err != nil {
+			t.Fatalf("'%v': %v", test.pattern, err)
+		}
+
+		for _, m := range test.matches {
+			if !rx.MatchString(m) {
+				t.Errorf("'%v' should match '%v' (%v)", test.pattern, m, rx)
+			}
+		}
+		for _, m := range test.noMatches {
+			if rx.MatchString(m) {
+				t.Errorf("'%v' should not match '%v' (%v)", test.pattern, m, rx)
+			}
+		}
+	}
+}
+
+func TestGlobToRegexInvalid(t *testing.T) {
+	for _, pattern := range []string{"[abc", "{a,b", "a}", "{a,{b}}"} {
+		if _, err := GlobToRegex(pattern); err == nil {
+			t.Errorf("expected error for '%v'", pattern)
+		}
+	}
+}
+
+func TestCompileFilePatternRegex(t *testing.T) {
+	rx, err := CompileFilePattern(`\.go$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rx.MatchString("cmd/main.go") {
+		t.Error("regular expression should match 'cmd/main.go'")
+	}
+
+	_, err = CompileFilePattern(`(`)
+	if err == nil {
+		t.Error("expected error for invalid regular expression")
+	}
+}
+
+func TestListFilesByGlob(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "README.md", "cmd/app/app.go", "cmd/app/app_test.txt"} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(name), 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := ListFilesByGlob(dir, "**/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var relPaths []string
+	for _, f := range files {
+		relPath, err := filepath.Rel(dir, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		relPaths = append(relPaths, filepath.ToSlash(relPath))
+	}
+	slices.Sort(relPaths)
+
+	expected := []string{"cmd/app/app.go", "main.go"}
+	if !slices.Equal(relPaths, expected) {
+		t.Errorf("expected %v, got %v", expected, relPaths)
+	}
+}
@@ -0,0 +1,141 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}
+
+// StripImageMetadata() - removes metadata, like EXIF and GPS information, from JPEG
+// and PNG images and returns the new data with the number of removed bytes;
+// other formats are returned unchanged
+func StripImageMetadata(data []byte) ([]byte, int, error) {
+	if len(data) >= 2 && data[0] == 0xff && data[1] == 0xd8 {
+		return stripJpegMetadata(data)
+	}
+	if bytes.HasPrefix(data, pngSignature) {
+		return stripPngMetadata(data)
+	}
+
+	return data, 0, nil
+}
+
+// stripJpegMetadata() - removes APP1 (EXIF / XMP), APP13 (IPTC)
+// and comment segments from a JPEG image
+func stripJpegMetadata(data []byte) ([]byte, int, error) {
+	var result bytes.Buffer
+	result.Write(data[0:2]) // SOI
+
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xff {
+			return nil, 0, fmt.Errorf("invalid JPEG marker at position %v", pos)
+		}
+
+		// skip fill bytes
+		markerStart := pos
+		for pos < len(data) && data[pos] == 0xff {
+			pos++
+		}
+		if pos >= len(data) {
+			break
+		}
+
+		marker := data[pos]
+		pos++
+
+		if marker == 0xd9 || marker == 0xda {
+			// EOI or start of scan => copy the rest
+			result.Write(data[markerStart:])
+			break
+		}
+		if (marker >= 0xd0 && marker <= 0xd7) || marker == 0x01 {
+			// markers without length
+			result.Write(data[markerStart:pos])
+			continue
+		}
+
+		if pos+2 > len(data) {
+			return nil, 0, fmt.Errorf("unexpected end of JPEG data")
+		}
+
+		// length includes its own 2 bytes
+		segmentLength := int(binary.BigEndian.Uint16(data[pos : pos+2]))
+		if segmentLength < 2 {
+			return nil, 0, fmt.Errorf("invalid JPEG segment length at position %v", pos)
+		}
+
+		segmentEnd := pos + segmentLength
+		if segmentEnd > len(data) {
+			return nil, 0, fmt.Errorf("unexpected end of JPEG data")
+		}
+
+		if marker != 0xe1 && marker != 0xed && marker != 0xfe {
+			result.Write(data[markerStart:segmentEnd])
+		}
+
+		pos = segmentEnd
+	}
+
+	return result.Bytes(), len(data) - result.Len(), nil
+}
+
+// stripPngMetadata() - removes `eXIf`, `tEXt`, `zTXt`, `iTXt`
+// and `tIME` chunks from a PNG image
+func stripPngMetadata(data []byte) ([]byte, int, error) {
+	var result bytes.Buffer
+	result.Write(pngSignature)
+
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, 0, fmt.Errorf("unexpected end of PNG data")
+		}
+
+		chunkLength := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		chunkType := string(data[pos+4 : pos+8])
+
+		chunkEnd := pos + 12 + chunkLength // length + type + data + CRC
+		if chunkLength < 0 || chunkEnd > len(data) {
+			return nil, 0, fmt.Errorf("unexpected end of PNG data")
+		}
+
+		switch chunkType {
+		case "eXIf", "iTXt", "tEXt", "tIME", "zTXt":
+			// skip
+		default:
+			result.Write(data[pos:chunkEnd])
+		}
+
+		pos = chunkEnd
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	return result.Bytes(), len(data) - result.Len(), nil
+}
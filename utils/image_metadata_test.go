@@ -0,0 +1,239 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func createTestImage() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for x := 0; x < 8; x++ {
+		for y := 0; y < 8; y++ {
+			img.Set(x, y, color.RGBA{uint8(x * 32), uint8(y * 32), 128, 255})
+		}
+	}
+
+	return img
+}
+
+func createTestJpegSegment(marker byte, payload []byte) []byte {
+	segment := []byte{0xff, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+
+	return append(segment, payload...)
+}
+
+func createTestPngChunk(chunkType string, payload []byte) []byte {
+	chunk := make([]byte, 8)
+	binary.BigEndian.PutUint32(chunk, uint32(len(payload)))
+	copy(chunk[4:], chunkType)
+	chunk = append(chunk, payload...)
+
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(chunk[4:]))
+
+	return append(chunk, crc...)
+}
+
+func assertSameImage(t *testing.T, expected image.Image, actual image.Image) {
+	if expected.Bounds() != actual.Bounds() {
+		t.Fatalf("expected bounds %v, got %v", expected.Bounds(), actual.Bounds())
+	}
+
+	b := expected.Bounds()
+	for x := b.Min.X; x < b.Max.X; x++ {
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			if expected.At(x, y) != actual.At(x, y) {
+				t.Fatalf("pixel %v,%v differs: expected %v, got %v", x, y, expected.At(x, y), actual.At(x, y))
+			}
+		}
+	}
+}
+
+func TestStripJpegMetadata(t *testing.T) {
+	var original bytes.Buffer
+	err := jpeg.Encode(&original, createTestImage(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exif := createTestJpegSegment(0xe1, append([]byte("Exif\x00\x00"), bytes.Repeat([]byte{'G', 'P', 'S'}, 100)...))
+	iptc := createTestJpegSegment(0xed, []byte("Photoshop 3.0\x00IPTC"))
+	comment := createTestJpegSegment(0xfe, []byte("secret comment"))
+
+	// SOI + metadata + rest of the original image
+	var withMetadata bytes.Buffer
+	withMetadata.Write(original.Bytes()[:2])
+	withMetadata.Write(exif)
+	withMetadata.Write(iptc)
+	withMetadata.Write(comment)
+	withMetadata.Write(original.Bytes()[2:])
+
+	stripped, removed, err := StripImageMetadata(withMetadata.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removed != len(exif)+len(iptc)+len(comment) {
+		t.Errorf("expected %v removed bytes, got %v", len(exif)+len(iptc)+len(comment), removed)
+	}
+	if !bytes.Equal(stripped, original.Bytes()) {
+		t.Error("stripped data does not match original image")
+	}
+	for _, s := range []string{"Exif", "GPS", "IPTC", "secret comment"} {
+		if bytes.Contains(stripped, []byte(s)) {
+			t.Errorf("'%v' has not been removed", s)
+		}
+	}
+
+	originalImage, err := jpeg.Decode(bytes.NewReader(original.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	strippedImage, err := jpeg.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameImage(t, originalImage, strippedImage)
+}
+
+func TestStripPngMetadata(t *testing.T) {
+	var original bytes.Buffer
+	err := png.Encode(&original, createTestImage())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := [][]byte{
+		createTestPngChunk("tEXt", []byte("Comment\x00secret comment")),
+		createTestPngChunk("eXIf", append([]byte("MM\x00\x2a"), bytes.Repeat([]byte{'G', 'P', 'S'}, 100)...)),
+		createTestPngChunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00xmp")),
+		createTestPngChunk("zTXt", []byte("Author\x00\x00compressed")),
+		createTestPngChunk("tIME", []byte{0x07, 0xe8, 1, 2, 3, 4, 5}),
+	}
+
+	// signature + IHDR, metadata, IDAT + IEND
+	ihdrEnd := len(pngSignature) + 12 + 13
+	metadataSize := 0
+
+	var withMetadata bytes.Buffer
+	withMetadata.Write(original.Bytes()[:ihdrEnd])
+	for _, chunk := range metadata {
+		withMetadata.Write(chunk)
+		metadataSize += len(chunk)
+	}
+	withMetadata.Write(original.Bytes()[ihdrEnd:])
+
+	// valid PNG with metadata
+	_, err = png.Decode(bytes.NewReader(withMetadata.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stripped, removed, err := StripImageMetadata(withMetadata.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removed != metadataSize {
+		t.Errorf("expected %v removed bytes, got %v", metadataSize, removed)
+	}
+	if !bytes.Equal(stripped, original.Bytes()) {
+		t.Error("stripped data does not match original image")
+	}
+	for _, s := range []string{"tEXt", "eXIf", "iTXt", "zTXt", "tIME", "secret comment", "GPS"} {
+		if bytes.Contains(stripped, []byte(s)) {
+			t.Errorf("'%v' has not been removed", s)
+		}
+	}
+
+	strippedImage, err := png.Decode(bytes.NewReader(stripped))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameImage(t, createTestImage(), strippedImage)
+}
+
+func TestStripImageMetadataWithOtherFormat(t *testing.T) {
+	data := []byte("GIF89a...")
+
+	stripped, removed, err := StripImageMetadata(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 0 || !bytes.Equal(stripped, data) {
+		t.Errorf("expected unchanged data, got %q", stripped)
+	}
+}
+
+func TestStripImageMetadataWithMalformedData(t *testing.T) {
+	var jpegData bytes.Buffer
+	err := jpeg.Encode(&jpegData, createTestImage(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exif := createTestJpegSegment(0xe1, []byte("Exif\x00\x00data"))
+	jpegWithExif := append(append(append([]byte{}, jpegData.Bytes()[:2]...), exif...), jpegData.Bytes()[2:]...)
+
+	var pngData bytes.Buffer
+	err = png.Encode(&pngData, createTestImage())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// truncated data must not panic or loop forever
+	for _, data := range [][]byte{jpegWithExif, pngData.Bytes()} {
+		for i := 0; i < len(data); i++ {
+			StripImageMetadata(data[:i])
+		}
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"JPEG segment after header is cut", jpegWithExif[:10]},
+		{"JPEG without marker", []byte{0xff, 0xd8, 0x00, 0x01}},
+		{"JPEG with zero segment length", []byte{0xff, 0xd8, 0xff, 0xe1, 0x00, 0x00, 0xff, 0xd9}},
+		{"JPEG with segment length of 1", []byte{0xff, 0xd8, 0xff, 0xe1, 0x00, 0x01, 0xff, 0xd9}},
+		{"JPEG segment too long", []byte{0xff, 0xd8, 0xff, 0xe1, 0xff, 0xff, 0x00}},
+		{"PNG chunk header is cut", append(append([]byte{}, pngSignature...), 0, 0, 0)},
+		{"PNG chunk too long", append(append([]byte{}, pngSignature...), 0xff, 0xff, 0xff, 0xff, 'I', 'H', 'D', 'R')},
+		{"PNG with cut IEND chunk", pngData.Bytes()[:len(pngData.Bytes())-12-1]},
+	}
+
+	for _, test := range tests {
+		_, _, err := StripImageMetadata(test.data)
+		if err == nil {
+			t.Errorf("%v: expected error", test.name)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// ValidateJsonSchema() - validates a value, which has been unmarshaled from JSON,
+// against a JSON schema and returns the list of found issues; an error is returned
+// if the schema itself is invalid
+func ValidateJsonSchema(value interface{}, schema map[string]interface{}) ([]string, error) {
+	schemaData, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+	compiler.LoadURL = func(url string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("loading external schema '%s' is not supported", url)
+	}
+
+	err = compiler.AddResource("gpm://schema.json", bytes.NewReader(schemaData))
+	if err != nil {
+		return nil, err
+	}
+
+	compiledSchema, err := compiler.Compile("gpm://schema.json")
+	if err != nil {
+		return nil, err
+	}
+
+	err = compiledSchema.Validate(value)
+	if err == nil {
+		return nil, nil
+	}
+
+	var validationError *jsonschema.ValidationError
+	if !errors.As(err, &validationError) {
+		return nil, err
+	}
+
+	var issues []string
+	collectJsonSchemaIssues(validationError, &issues)
+	sort.Strings(issues)
+
+	return issues, nil
+}
+
+func collectJsonSchemaIssues(validationError *jsonschema.ValidationError, issues *[]string) {
+	// causes of `anyOf` and `oneOf` are alternatives,
+	// so report the keyword itself instead
+	isCombination := strings.HasSuffix(validationError.KeywordLocation, "/anyOf") ||
+		strings.HasSuffix(validationError.KeywordLocation, "/oneOf")
+
+	if len(validationError.Causes) == 0 || isCombination {
+		*issues = append(*issues, fmt.Sprintf("$%s: %s", validationError.InstanceLocation, validationError.Message))
+		return
+	}
+
+	for _, cause := range validationError.Causes {
+		collectJsonSchemaIssues(cause, issues)
+	}
+}
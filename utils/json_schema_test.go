@@ -0,0 +1,216 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testJsonSchema = `{
+	"type": "object",
+	"required": ["name", "version"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 1, "maxLength": 10, "pattern": "^[a-z]+$"},
+		"version": {"type": "integer", "minimum": 1, "maximum": 3},
+		"ratio": {"type": "number"},
+		"level": {"enum": ["low", "high", 1]},
+		"tags": {"type": "array", "minItems": 1, "maxItems": 2, "items": {"type": "string"}},
+		"owner": {
+			"type": "object",
+			"required": ["email"],
+			"properties": {
+				"email": {"type": "string"},
+				"age": {"type": ["integer", "null"]}
+			}
+		}
+	}
+}`
+
+func TestValidateJsonSchema(t *testing.T) {
+	var schema map[string]interface{}
+	err := json.Unmarshal([]byte(testJsonSchema), &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{"valid", `{"name": "gpm", "version": 2, "ratio": 0.5, "level": "high", "tags": ["a"], "owner": {"email": "a@b", "age": null}}`, nil},
+		{"integer is a number", `{"name": "gpm", "version": 1, "ratio": 2}`, nil},
+		{"enum with number", `{"name": "gpm", "version": 1, "level": 1}`, nil},
+
+		{"no object", `[]`, []string{"$: expected object, but got array"}},
+		{"required", `{"name": "gpm"}`, []string{"$: missing properties: 'version'"}},
+		{"additional property", `{"name": "gpm", "version": 1, "other": true}`, []string{"$: additionalProperties 'other' not allowed"}},
+		{"string type", `{"name": 1, "version": 1}`, []string{"$/name: expected string, but got number"}},
+		{"string limits", `{"name": "", "version": 1}`, []string{"$/name: does not match pattern '^[a-z]+$'", "$/name: length must be >= 1, but got 0"}},
+		{"string max length", `{"name": "abcdefghijk", "version": 1}`, []string{"$/name: length must be <= 10, but got 11"}},
+		{"integer type", `{"name": "gpm", "version": 1.5}`, []string{"$/version: expected integer, but got number"}},
+		{"number type", `{"name": "gpm", "version": 1, "ratio": "0.5"}`, []string{"$/ratio: expected number, but got string"}},
+		{"minimum", `{"name": "gpm", "version": 0}`, []string{"$/version: must be >= 1 but found 0"}},
+		{"maximum", `{"name": "gpm", "version": 4}`, []string{"$/version: must be <= 3 but found 4"}},
+		{"enum", `{"name": "gpm", "version": 1, "level": "medium"}`, []string{`$/level: value must be one of "low", "high", "1"`}},
+		{"min items", `{"name": "gpm", "version": 1, "tags": []}`, []string{"$/tags: minimum 1 items required, but found 0 items"}},
+		{"max items", `{"name": "gpm", "version": 1, "tags": ["a", "b", "c"]}`, []string{"$/tags: maximum 2 items required, but found 3 items"}},
+		{"array items", `{"name": "gpm", "version": 1, "tags": ["a", 2]}`, []string{"$/tags/1: expected string, but got number"}},
+		{"nested required", `{"name": "gpm", "version": 1, "owner": {}}`, []string{"$/owner: missing properties: 'email'"}},
+		{"nested multiple types", `{"name": "gpm", "version": 1, "owner": {"email": "a@b", "age": "old"}}`, []string{"$/owner/age: expected integer or null, but got string"}},
+		{"multiple issues", `{"version": "1", "tags": [1]}`, []string{"$/tags/0: expected string, but got number", "$/version: expected integer, but got string", "$: missing properties: 'name'"}},
+	}
+
+	for _, test := range tests {
+		var value interface{}
+		err := json.Unmarshal([]byte(test.value), &value)
+		if err != nil {
+			t.Fatalf("%v: %v", test.name, err)
+		}
+
+		issues, err := ValidateJsonSchema(value, schema)
+		if err != nil {
+			t.Fatalf("%v: %v", test.name, err)
+		}
+		if strings.Join(issues, "\n") != strings.Join(test.expected, "\n") {
+			t.Errorf("%v: expected %q, got %q", test.name, test.expected, issues)
+		}
+	}
+}
+
+func validateJsonSchemaForTest(t *testing.T, value interface{}, schema map[string]interface{}) []string {
+	issues, err := ValidateJsonSchema(value, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return issues
+}
+
+func TestValidateJsonSchemaWithCombinations(t *testing.T) {
+	schema := map[string]interface{}{
+		"oneOf": []interface{}{
+			map[string]interface{}{"type": "string"},
+			map[string]interface{}{"type": "integer"},
+			map[string]interface{}{"type": "number"},
+		},
+	}
+
+	if issues := validateJsonSchemaForTest(t, "a", schema); len(issues) != 0 {
+		t.Errorf("expected no issues for string, got %q", issues)
+	}
+	if issues := validateJsonSchemaForTest(t, 1.5, schema); len(issues) != 0 {
+		t.Errorf("expected no issues for number, got %q", issues)
+	}
+	// integers are also numbers
+	if issues := validateJsonSchemaForTest(t, float64(1), schema); len(issues) != 1 {
+		t.Errorf("expected 1 issue for integer, got %q", issues)
+	}
+
+	schema = map[string]interface{}{
+		"anyOf": []map[string]interface{}{
+			{"type": "string"},
+			{"type": "boolean"},
+		},
+		"allOf": []interface{}{
+			map[string]interface{}{"enum": []string{"a", "b", "true"}},
+		},
+	}
+
+	if issues := validateJsonSchemaForTest(t, "a", schema); len(issues) != 0 {
+		t.Errorf("expected no issues for 'a', got %q", issues)
+	}
+	if issues := validateJsonSchemaForTest(t, true, schema); len(issues) != 1 {
+		t.Errorf("expected 1 issue for true, got %q", issues)
+	}
+	if issues := validateJsonSchemaForTest(t, nil, schema); len(issues) != 2 {
+		t.Errorf("expected 2 issues for null, got %q", issues)
+	}
+}
+
+func TestValidateJsonSchemaWithRef(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"owner": map[string]interface{}{"$ref": "#/$defs/person"},
+		},
+		"$defs": map[string]interface{}{
+			"person": map[string]interface{}{
+				"type":     "object",
+				"required": []string{"name"},
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{"type": "string"},
+				},
+			},
+		},
+	}
+
+	if issues := validateJsonSchemaForTest(t, map[string]interface{}{"owner": map[string]interface{}{"name": "gpm"}}, schema); len(issues) != 0 {
+		t.Errorf("expected no issues, got %q", issues)
+	}
+
+	issues := validateJsonSchemaForTest(t, map[string]interface{}{"owner": map[string]interface{}{"name": 1.0}}, schema)
+	expected := []string{"$/owner/name: expected string, but got number"}
+	if strings.Join(issues, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected %q, got %q", expected, issues)
+	}
+
+	issues = validateJsonSchemaForTest(t, map[string]interface{}{"owner": map[string]interface{}{}}, schema)
+	expected = []string{"$/owner: missing properties: 'name'"}
+	if strings.Join(issues, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected %q, got %q", expected, issues)
+	}
+}
+
+func TestValidateJsonSchemaWithConst(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"kind": map[string]interface{}{"const": "step"},
+		},
+	}
+
+	if issues := validateJsonSchemaForTest(t, map[string]interface{}{"kind": "step"}, schema); len(issues) != 0 {
+		t.Errorf("expected no issues, got %q", issues)
+	}
+
+	issues := validateJsonSchemaForTest(t, map[string]interface{}{"kind": "other"}, schema)
+	if len(issues) != 1 || !strings.HasPrefix(issues[0], "$/kind: ") {
+		t.Errorf("expected 1 issue for 'kind', got %q", issues)
+	}
+}
+
+func TestValidateJsonSchemaWithInvalidSchema(t *testing.T) {
+	for _, schema := range []map[string]interface{}{
+		{"type": "unknown"},
+		{"$ref": "https://example.com/schema.json"},
+	} {
+		_, err := ValidateJsonSchema("a", schema)
+		if err == nil {
+			t.Errorf("expected error for schema %v", schema)
+		}
+	}
+}
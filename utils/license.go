@@ -0,0 +1,103 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// licenseFileNameRegex matches names of files, which usually contain a license
+var licenseFileNameRegex = regexp.MustCompile(`(?i)^(LICEN[CS]E|COPYING)(\.md|\.txt)?$`)
+
+// licenseSignatures stores SPDX IDs and texts, which are characteristic for a license;
+// more specific licenses have to be checked first
+var licenseSignatures = []struct {
+	id    string
+	texts []string
+}{
+	{id: "AGPL-3.0", texts: []string{"GNU AFFERO GENERAL PUBLIC LICENSE"}},
+	{id: "LGPL-3.0", texts: []string{"GNU LESSER GENERAL PUBLIC LICENSE", "Version 3"}},
+	{id: "LGPL-2.1", texts: []string{"GNU LESSER GENERAL PUBLIC LICENSE", "Version 2.1"}},
+	{id: "GPL-3.0", texts: []string{"GNU GENERAL PUBLIC LICENSE", "Version 3"}},
+	{id: "GPL-2.0", texts: []string{"GNU GENERAL PUBLIC LICENSE", "Version 2"}},
+	{id: "Apache-2.0", texts: []string{"Apache License", "Version 2.0"}},
+	{id: "MPL-2.0", texts: []string{"Mozilla Public License", "2.0"}},
+	{id: "BSD-3-Clause", texts: []string{"Redistribution and use in source and binary forms", "Neither the name"}},
+	{id: "BSD-2-Clause", texts: []string{"Redistribution and use in source and binary forms"}},
+	{id: "ISC", texts: []string{"Permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{id: "MIT", texts: []string{"Permission is hereby granted, free of charge"}},
+	{id: "Unlicense", texts: []string{"This is free and unencumbered software released into the public domain"}},
+}
+
+// DetectLicense() - tries to detect the SPDX ID of the license of the
+// files inside a directory, returns an empty string if not detectable
+func DetectLicense(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !licenseFileNameRegex.MatchString(e.Name()) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+
+		id := DetectLicenseFromText(string(data))
+		if id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+// DetectLicenseFromText() - tries to detect the SPDX ID of a license text,
+// returns an empty string if not detectable
+func DetectLicenseFromText(text string) string {
+	// normalize whitespaces
+	text = strings.Join(strings.Fields(text), " ")
+
+	for _, s := range licenseSignatures {
+		isMatching := true
+		for _, t := range s.texts {
+			if !strings.Contains(strings.ToLower(text), strings.ToLower(t)) {
+				isMatching = false
+				break
+			}
+		}
+
+		if isMatching {
+			return s.id
+		}
+	}
+
+	return ""
+}
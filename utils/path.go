@@ -25,7 +25,11 @@
 package utils
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 )
 
 // SanitizeFilenameOptions stores options for `SanitizeFilename()` function
@@ -33,6 +37,50 @@ type SanitizeFilenameOptions struct {
 	Replacement *string // character to replace unsafe characters with
 }
 
+// IsInPathEnv() - checks if a directory is part of the
+// `PATH` environment variable
+func IsInPathEnv(dir string) bool {
+	dir = filepath.Clean(dir)
+
+	for _, p := range filepath.SplitList(os.Getenv("PATH")) {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		p = filepath.Clean(p)
+		if p == dir || (IsWindows() && strings.EqualFold(p, dir)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SafeJoin() - joins a relative path like an archive entry or a path
+// suggested by an AI to a base directory and returns an error if the
+// result would be outside of it
+func SafeJoin(base string, entry string) (string, error) {
+	base = filepath.Clean(base)
+	entry = strings.TrimSpace(entry)
+
+	if filepath.IsAbs(entry) || strings.HasPrefix(entry, "/") || filepath.VolumeName(entry) != "" {
+		return "", fmt.Errorf("absolute path '%v' is not allowed", entry)
+	}
+
+	joined := filepath.Join(base, filepath.FromSlash(entry))
+
+	rel, err := filepath.Rel(base, joined)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path '%v' is outside of '%v'", entry, base)
+	}
+
+	return joined, nil
+}
+
 // SanitizeFilename() - cleans up an input string to one which can be used in a filename
 func SanitizeFilename(input string, options ...SanitizeFilenameOptions) string {
 	var replacement string = ""
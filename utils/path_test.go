@@ -0,0 +1,63 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeJoin(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "target")
+
+	valid := map[string]string{
+		"file.txt":            filepath.Join(base, "file.txt"),
+		"sub/dir/file.txt":    filepath.Join(base, "sub", "dir", "file.txt"),
+		"./sub/../file.txt":   filepath.Join(base, "file.txt"),
+		"sub/../../target/ok": filepath.Join(base, "ok"),
+	}
+	for entry, expected := range valid {
+		actual, err := SafeJoin(base, entry)
+		if err != nil {
+			t.Errorf("'%v': unexpected error: %v", entry, err)
+			continue
+		}
+
+		if actual != expected {
+			t.Errorf("'%v': expected '%v', got '%v'", entry, expected, actual)
+		}
+	}
+
+	malicious := []string{
+		"../../etc/passwd",
+		"..",
+		"sub/../../outside.txt",
+		"/etc/passwd",
+		"../target-other/file.txt",
+	}
+	for _, entry := range malicious {
+		actual, err := SafeJoin(base, entry)
+		if err == nil {
+			t.Errorf("'%v': expected error, got '%v'", entry, actual)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+//go:build !windows
+
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// PrepareProcessGroup() - lets a command start in its own process group,
+// so it can be stopped with all of its child processes
+func PrepareProcessGroup(p *exec.Cmd) {
+	if p.SysProcAttr == nil {
+		p.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	p.SysProcAttr.Setpgid = true
+}
+
+// StopProcessGroup() - sends SIGINT to the process group of a started command
+// and SIGKILL if it is still running after `timeout`
+func StopProcessGroup(p *exec.Cmd, done <-chan struct{}, timeout time.Duration) error {
+	if p.Process == nil {
+		return nil
+	}
+
+	pgid, err := syscall.Getpgid(p.Process.Pid)
+	if err != nil {
+		return nil // already finished
+	}
+
+	err = syscall.Kill(-pgid, syscall.SIGINT)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return syscall.Kill(-pgid, syscall.SIGKILL)
+	}
+}
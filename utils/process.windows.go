@@ -0,0 +1,58 @@
+//go:build windows
+
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// PrepareProcessGroup() - does nothing on Windows, because
+// `StopProcessGroup()` stops the whole process tree
+func PrepareProcessGroup(p *exec.Cmd) {
+	// not required
+}
+
+// StopProcessGroup() - stops a started command with all of its child processes
+// and waits up to `timeout` for it
+func StopProcessGroup(p *exec.Cmd, done <-chan struct{}, timeout time.Duration) error {
+	if p.Process == nil {
+		return nil
+	}
+
+	// there is no SIGINT for other processes on Windows
+	err := exec.Command("taskkill", "/T", "/F", "/PID", fmt.Sprint(p.Process.Pid)).Run()
+	if err != nil {
+		return p.Process.Kill()
+	}
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var safeShellArgRegex = regexp.MustCompile(`^[a-zA-Z0-9_./:=@%+,-]+$`)
+
+// QuoteShellArg() - quotes an argument, so it can be used as one single
+// literal value in commands of `CreateShellCommand()`
+func QuoteShellArg(arg string) string {
+	if IsWindows() {
+		return quoteWindowsShellArg(arg)
+	}
+	return quotePosixShellArg(arg)
+}
+
+// quotePosixShellArg() - quotes an argument for `sh -c`
+func quotePosixShellArg(arg string) string {
+	if safeShellArgRegex.MatchString(arg) {
+		return arg
+	}
+
+	// single quotes cannot be escaped inside single quotes
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// quoteWindowsShellArg() - quotes an argument for `cmd /C`
+func quoteWindowsShellArg(arg string) string {
+	if safeShellArgRegex.MatchString(arg) && !strings.Contains(arg, "%") {
+		return arg
+	}
+
+	// `&`, `|`, `<` and `>` are no operators inside double quotes
+	// and `%` cannot be escaped there, so break out for it
+	arg = strings.ReplaceAll(arg, `"`, `""`)
+	arg = strings.ReplaceAll(arg, "%", `"^%"`)
+
+	return `"` + arg + `"`
+}
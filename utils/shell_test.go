@@ -0,0 +1,89 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestQuotePosixShellArg(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"abc", "abc"},
+		{"--flag=./a/b.txt", "--flag=./a/b.txt"},
+		{"", "''"},
+		{"b c", "'b c'"},
+		{"x; rm -rf ~", "'x; rm -rf ~'"},
+		{"$(whoami) `id` $HOME", "'$(whoami) `id` $HOME'"},
+		{"it's", `'it'\''s'`},
+	}
+
+	for _, test := range tests {
+		actual := quotePosixShellArg(test.arg)
+		if actual != test.expected {
+			t.Errorf("'%v': expected '%v', got '%v'", test.arg, test.expected, actual)
+		}
+	}
+}
+
+func TestQuotePosixShellArgWithShell(t *testing.T) {
+	if IsWindows() {
+		t.Skip("no POSIX shell")
+	}
+
+	args := []string{"", "b c", "x; rm -rf ~", "$(echo injected) `echo injected` $HOME", "it's \"quoted\"", "a\nb", `\n*`}
+	for _, arg := range args {
+		output, err := exec.Command("sh", "-c", "printf '%s' "+quotePosixShellArg(arg)).Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(output) != arg {
+			t.Errorf("expected '%v', got '%v'", arg, string(output))
+		}
+	}
+}
+
+func TestQuoteWindowsShellArg(t *testing.T) {
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{"abc", "abc"},
+		{"", `""`},
+		{"b c", `"b c"`},
+		{"x & del /q *", `"x & del /q *"`},
+		{`say "hi"`, `"say ""hi"""`},
+		{"%PATH%", `""^%"PATH"^%""`},
+	}
+
+	for _, test := range tests {
+		actual := quoteWindowsShellArg(test.arg)
+		if actual != test.expected {
+			t.Errorf("'%v': expected '%v', got '%v'", test.arg, test.expected, actual)
+		}
+	}
+}
@@ -25,6 +25,9 @@ package utils
 import (
 	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"io"
 	mathRand "math/rand"
@@ -37,6 +40,8 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	ui "github.com/gizak/termui/v3"
 	"github.com/gizak/termui/v3/widgets"
@@ -45,12 +50,25 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// DownloadOptions stores settings for `DownloadFromUrl()` and
+// `DownloadFromUrlTo()` functions
+type DownloadOptions struct {
+	Checksum  string            // expected SHA256 checksum of the downloaded data as hex string
+	Headers   map[string]string // custom HTTP request headers
+	Progress  bool              // show a progress bar or a spinner, if size is unknown, in STDERR
+	Token     string            // optional bearer token for `Authorization` header
+	UserAgent string            // custom user agent
+}
+
 type SpliTextOptions struct {
 	MaxChunkSize     *int // default 3000
 	MaxOverheadWords *int // default 15
 	MaxOverheadChars *int // default 100
 }
 
+// functions, which are executed by `Exit()`
+var exitHandlers []func()
+
 // Base64FromDataURI() - extracts Base64 part from data URI
 func Base64FromDataURI(dataURI string) (string, error) {
 	dataURI = strings.TrimSpace(dataURI)
@@ -105,7 +123,91 @@ func ClearConsole() error {
 // CloseWithError() - exits with code 1 and output an error
 func CloseWithError(err error) {
 	fmt.Println(err)
-	os.Exit(1)
+	Exit(1)
+}
+
+// CopyDir() - copies all files and sub directories of `srcDir` to `targetDir`,
+// `.git` folders are skipped
+func CopyDir(srcDir string, targetDir string) error {
+	return filepath.Walk(srcDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+
+		targetPath := filepath.Join(targetDir, relPath)
+
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return os.MkdirAll(targetPath, 0750)
+		}
+		if !info.Mode().IsRegular() {
+			return nil // skip links and other special files
+		}
+
+		srcFile, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer srcFile.Close()
+
+		targetFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		defer targetFile.Close()
+
+		_, err = io.Copy(targetFile, srcFile)
+		return err
+	})
+}
+
+// CreateCompletionFunc() - creates a function for `ValidArgsFunction` of a `cobra.Command`,
+// which completes from the values returned by `getValues`
+func CreateCompletionFunc(getValues func() []string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		completions := []string{}
+
+		for _, v := range getValues() {
+			if IndexOfString(args, v) > -1 {
+				continue // already used
+			}
+
+			if strings.HasPrefix(v, toComplete) {
+				completions = append(completions, v)
+			}
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CreateDownloadProgressBar() - creates a progress bar for downloads, which writes to STDERR
+// and displays a spinner if `totalBytes` is `-1`
+func CreateDownloadProgressBar(totalBytes int64, description string) *progressbar.ProgressBar {
+	newBar := progressbar.NewOptions64(
+		totalBytes,
+		progressbar.OptionEnableColorCodes(true),
+		progressbar.OptionSetWidth(15),
+		progressbar.OptionSetDescription(description),
+		progressbar.OptionSetWriter(os.Stderr),
+		progressbar.OptionShowBytes(true),
+		progressbar.OptionSetTheme(progressbar.Theme{
+			Saucer:        "[green]=[reset]",
+			SaucerHead:    "[green]>[reset]",
+			SaucerPadding: " ",
+			BarStart:      "[",
+			BarEnd:        "]",
+		}))
+
+	return newBar
 }
 
 // CreateProgressBar() - creates a simple progress bar with default settings
@@ -153,26 +255,89 @@ func CreateShellCommandByArgs(c string, args ...string) *exec.Cmd {
 }
 
 // DownloadFromUrl() - downloads data from URL
-func DownloadFromUrl(url string) ([]byte, error) {
+func DownloadFromUrl(url string, options ...DownloadOptions) ([]byte, error) {
 	buffer := bytes.Buffer{}
-	_, err := DownloadFromUrlTo(&buffer, url)
+	_, err := DownloadFromUrlTo(&buffer, url, options...)
 
 	return buffer.Bytes(), err
 }
 
 // DownloadFromUrlTo() - downloads data from URL to an io.Writer
-func DownloadFromUrlTo(w io.Writer, url string) (int64, error) {
+func DownloadFromUrlTo(w io.Writer, url string, options ...DownloadOptions) (int64, error) {
 	if !IsDownloadUrl(url) {
 		url = "https://" + url
 	}
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, o := range options {
+		for name, value := range o.Headers {
+			req.Header.Set(name, value)
+		}
+
+		token := strings.TrimSpace(o.Token)
+		if token != "" {
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		userAgent := strings.TrimSpace(o.UserAgent)
+		if userAgent != "" {
+			req.Header.Set("User-Agent", userAgent)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return 0, err
 	}
 	defer resp.Body.Close()
 
-	return io.Copy(w, resp.Body)
+	expectedChecksum := ""
+	showProgress := false
+	for _, o := range options {
+		if strings.TrimSpace(o.Checksum) != "" {
+			expectedChecksum = strings.TrimSpace(strings.ToLower(o.Checksum))
+		}
+
+		showProgress = showProgress || o.Progress
+	}
+
+	writers := []io.Writer{w}
+
+	// compute hash while streaming
+	hash := sha256.New()
+	if expectedChecksum != "" {
+		writers = append(writers, hash)
+	}
+
+	if showProgress {
+		// `-1` for unknown content length will display a spinner
+		bar := CreateDownloadProgressBar(resp.ContentLength, "Downloading ...")
+		defer func() {
+			bar.Finish()
+			fmt.Fprintln(os.Stderr)
+		}()
+
+		writers = append(writers, bar)
+	}
+
+	written, err := io.Copy(io.MultiWriter(writers...), resp.Body)
+	if err != nil {
+		return written, err
+	}
+
+	if expectedChecksum != "" {
+		actualChecksum := fmt.Sprintf("%x", hash.Sum(nil))
+
+		if subtle.ConstantTimeCompare([]byte(actualChecksum), []byte(expectedChecksum)) != 1 {
+			return written, fmt.Errorf("SHA256 checksum mismatch for '%v': expected '%v' but got '%v'", url, expectedChecksum, actualChecksum)
+		}
+	}
+
+	return written, nil
 }
 
 // EnsureMaxSliceLength() - ensures that the length of an array is
@@ -185,6 +350,36 @@ func EnsureMaxSliceLength[T any](slice []T, maxLength int) []T {
 	return slice
 }
 
+// Exit() - runs all functions registered by `OnExit()` and
+// exits the process with a specific code
+func Exit(code int) {
+	handlers := exitHandlers
+	exitHandlers = nil // run each handler only once
+
+	for _, h := range handlers {
+		h()
+	}
+
+	os.Exit(code)
+}
+
+// FormatByteSize() - formats a size in bytes to a human readable string like `1.5 MB`
+func FormatByteSize(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
 // GenerateRandomUint16() - creates a new random uint16 value
 func GenerateRandomUint16() uint16 {
 	return uint16(mathRand.Intn(1 << 16)) // 1 << 16 is 65536, the range of uint16
@@ -252,7 +447,7 @@ func GetDefaultAIChatModel() string {
 // GetEnvVar() - returns, if found, the value of an existing environment
 // variable by its name ignoring case sensitivity
 func GetEnvVar(name string) *string {
-	lowerName := strings.TrimSpace(strings.ToUpper(name))
+	lowerName := strings.TrimSpace(strings.ToLower(name))
 
 	var value *string = nil
 
@@ -264,7 +459,7 @@ func GetEnvVar(name string) *string {
 		var v string
 		if sep > -1 {
 			n = kv[0:sep]
-			v = kv[:sep+1]
+			v = kv[sep+1:]
 		} else {
 			n = kv
 		}
@@ -325,13 +520,13 @@ func GetShell() string {
 	shellName := ""
 
 	if IsWindows() {
-		comspec := GetEnvVar("COMSPEC")
-		if comspec != nil {
-			shellName = *comspec
+		// COMSPEC is always set, so check PowerShell first
+		if isPowerShellSession() {
+			shellName = "PowerShell"
 		} else {
-			powershell := GetEnvVar("PSModulePath")
-			if powershell != nil && *powershell != "" {
-				shellName = "PowerShell"
+			comspec := GetEnvVar("COMSPEC")
+			if comspec != nil {
+				shellName = *comspec
 			}
 		}
 	} else {
@@ -350,6 +545,8 @@ func GetShell() string {
 			shellName = "Z shell"
 		} else if strings.Contains(lowerShellName, "bash") {
 			shellName = "Bash"
+		} else if strings.Contains(lowerShellName, "fish") {
+			shellName = "Fish"
 		}
 	}
 
@@ -404,6 +601,71 @@ func IsFileExisting(fp string) (bool, error) {
 	return !info.IsDir(), nil
 }
 
+// isPowerShellSession() - returns `true` if the current process has been started
+// from PowerShell, which adds the modules folder inside the user's home directory
+// to `PSModulePath`, while cmd.exe only knows the system wide folders
+func isPowerShellSession() bool {
+	modulePath := GetEnvVar("PSModulePath")
+	if modulePath == nil || strings.TrimSpace(*modulePath) == "" {
+		return false
+	}
+
+	userProfile := GetEnvVar("USERPROFILE")
+	if userProfile == nil || strings.TrimSpace(*userProfile) == "" {
+		return true
+	}
+
+	homeDir := strings.ToLower(strings.TrimRight(strings.TrimSpace(*userProfile), `\/`))
+	for _, p := range strings.Split(*modulePath, ";") {
+		p = strings.ToLower(strings.TrimSpace(p))
+
+		if strings.HasPrefix(p, homeDir+`\`) || strings.HasPrefix(p, homeDir+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsReadableText() - checks if data is valid UTF-8 text without binary
+// content, like NUL bytes or a high amount of control characters
+func IsReadableText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+
+	controlChars := 0
+	totalChars := 0
+	for _, r := range string(data) {
+		totalChars++
+
+		if r == 0 {
+			return false
+		}
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' && r != '\f' {
+			controlChars++
+		}
+	}
+
+	// allow some, like ANSI escape sequences
+	return controlChars*100 <= totalChars
+}
+
+// IsTerminal() - checks if a writer is an interactive terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return (stat.Mode() & os.ModeCharDevice) != 0
+}
+
 // LoadFromSTDINIfAvailable() - loads data from STDIN if available
 func LoadFromSTDINIfAvailable() (*[]byte, error) {
 	if stat, _ := os.Stdin.Stat(); (stat.Mode() & os.ModeCharDevice) == 0 {
@@ -457,6 +719,12 @@ func MaxUint16(a, b uint16, more ...uint16) uint16 {
 	return result
 }
 
+// OnExit() - registers a function, which is executed by `Exit()`,
+// before the process is terminated
+func OnExit(handler func()) {
+	exitHandlers = append(exitHandlers, handler)
+}
+
 // OpenUrl() - opens a URL by the default application handler
 func OpenUrl(url string) error {
 	var args []string
@@ -492,13 +760,39 @@ func RemoveDuplicatesInStringList(arr []string) []string {
 	return result
 }
 
-// RunCommand() - runs a command and exists on error
+// RunCommand() - runs a command and exits with the code of the child process on error
 func RunCommand(p *exec.Cmd, additionalArgs ...string) {
+	exitCode, err := RunCommandWithExitCode(p, additionalArgs...)
+	if err != nil {
+		CloseWithError(err)
+	}
+
+	if exitCode != 0 {
+		// exit with code of child process
+		fmt.Fprintf(os.Stderr, "exit status %v%v", exitCode, fmt.Sprintln())
+		if exitCode < 0 {
+			exitCode = 1
+		}
+		Exit(exitCode)
+	}
+}
+
+// RunCommandWithExitCode() - runs a command and returns its exit code,
+// the error is only set if the command could not be executed at all
+func RunCommandWithExitCode(p *exec.Cmd, additionalArgs ...string) (int, error) {
 	p.Args = append(p.Args, additionalArgs...)
 
-	if err := p.Run(); err != nil {
-		CloseWithError(err)
+	err := p.Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+
+		return -1, err
 	}
+
+	return 0, nil
 }
 
 // Slugify() - slugifies a string
@@ -0,0 +1,102 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDownloadFromUrlWithChecksum(t *testing.T) {
+	content := []byte("pinned resource")
+	checksum := HashSHA256(content)
+
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	// valid, with upper case hex
+	data, err := DownloadFromUrl(server.URL, DownloadOptions{
+		Checksum: " " + strings.ToUpper(checksum) + " ",
+		Token:    "my-token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, content) {
+		t.Errorf("expected '%s', got '%s'", content, data)
+	}
+	if authorization != "Bearer my-token" {
+		t.Errorf("unexpected Authorization header '%v'", authorization)
+	}
+
+	// invalid
+	_, err = DownloadFromUrl(server.URL, DownloadOptions{
+		Checksum: HashSHA256([]byte("tampered resource")),
+	})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected checksum mismatch, got %v", err)
+	}
+
+	// no checksum
+	var buffer bytes.Buffer
+	written, err := DownloadFromUrlTo(&buffer, server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(len(content)) || !bytes.Equal(buffer.Bytes(), content) {
+		t.Errorf("expected '%s', got '%s'", content, buffer.Bytes())
+	}
+}
+
+func TestIsPowerShellSession(t *testing.T) {
+	tests := []struct {
+		modulePath  string
+		userProfile string
+		expected    bool
+	}{
+		// cmd.exe
+		{`C:\Program Files\WindowsPowerShell\Modules;C:\WINDOWS\system32\WindowsPowerShell\v1.0\Modules`, `C:\Users\gpm`, false},
+		{"", `C:\Users\gpm`, false},
+		// PowerShell
+		{`C:\Users\gpm\Documents\WindowsPowerShell\Modules;C:\Program Files\WindowsPowerShell\Modules`, `C:\Users\gpm`, true},
+		{`c:\users\GPM\Documents\PowerShell\Modules;C:\Program Files\PowerShell\7\Modules`, `C:\Users\gpm\`, true},
+		{`C:\Users\gpm2\Documents\WindowsPowerShell\Modules`, `C:\Users\gpm`, false},
+	}
+
+	for _, test := range tests {
+		t.Setenv("PSModulePath", test.modulePath)
+		t.Setenv("USERPROFILE", test.userProfile)
+
+		if actual := isPowerShellSession(); actual != test.expected {
+			t.Errorf("'%v': expected %v, got %v", test.modulePath, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchFilesOptions stores settings for `WatchFiles()` function
+type WatchFilesOptions struct {
+	Debounce *time.Duration // time without changes before `onChange` is called, default: 300ms
+}
+
+// watchIgnoredDirs contains names of directories, which are not watched
+var watchIgnoredDirs = []string{".git", "node_modules", "vendor"}
+
+// addWatchDirs() - adds `dir` and all its sub directories, except ignored ones,
+// to `watcher` and returns the paths of all files found in them
+func addWatchDirs(watcher *fsnotify.Watcher, dir string) ([]string, error) {
+	var files []string
+
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // ignore files which have been removed in the meantime
+		}
+
+		if !info.IsDir() {
+			files = append(files, p)
+			return nil
+		}
+
+		if p != dir && slices.Contains(watchIgnoredDirs, info.Name()) {
+			return filepath.SkipDir
+		}
+
+		return watcher.Add(p)
+	})
+
+	return files, err
+}
+
+// WatchFiles() - watches all files inside `dir`, whose relative paths match one of the
+// regular expressions or globs with `glob:` prefix in `patterns` and calls `onChange` with the list of changed files,
+// until `ctx` is done
+func WatchFiles(ctx context.Context, dir string, patterns []string, onChange func(changedFiles []string), options ...WatchFilesOptions) error {
+	debounce := 300 * time.Millisecond
+	for _, o := range options {
+		if o.Debounce != nil {
+			debounce = *o.Debounce
+		}
+	}
+
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		rx, err := CompileFilePattern(p)
+		if err != nil {
+			return err
+		}
+
+		regexps = append(regexps, rx)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	_, err = addWatchDirs(watcher, dir)
+	if err != nil {
+		return err
+	}
+
+	pendingFiles := map[string]bool{}
+	addPendingFile := func(p string) {
+		relPath, err := filepath.Rel(dir, p)
+		if err != nil {
+			return
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		for _, rx := range regexps {
+			if rx.MatchString(relPath) {
+				pendingFiles[relPath] = true
+				break
+			}
+		}
+	}
+
+	debounceTimer := time.NewTimer(debounce)
+	debounceTimer.Stop()
+	defer debounceTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			return err
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if event.Has(fsnotify.Chmod) && !event.Has(fsnotify.Write) {
+				continue // attributes only
+			}
+
+			if event.Has(fsnotify.Create) {
+				info, err := os.Stat(event.Name)
+				if err == nil && info.IsDir() {
+					if slices.Contains(watchIgnoredDirs, info.Name()) {
+						continue
+					}
+
+					// watch new directory and take files,
+					// which have been created in it so far
+					files, err := addWatchDirs(watcher, event.Name)
+					if err != nil {
+						return err
+					}
+
+					for _, f := range files {
+						addPendingFile(f)
+					}
+				} else {
+					addPendingFile(event.Name)
+				}
+			} else {
+				addPendingFile(event.Name)
+			}
+
+			if len(pendingFiles) > 0 {
+				debounceTimer.Reset(debounce)
+			}
+		case <-debounceTimer.C:
+			if len(pendingFiles) == 0 {
+				continue
+			}
+
+			changedFiles := make([]string, 0, len(pendingFiles))
+			for p := range pendingFiles {
+				changedFiles = append(changedFiles, p)
+			}
+			sort.Strings(changedFiles)
+
+			pendingFiles = map[string]bool{}
+
+			onChange(changedFiles)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"pkg", "vendor"} {
+		if err := os.Mkdir(filepath.Join(dir, d), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	debounce := 50 * time.Millisecond
+	changes := make(chan []string, 10)
+	watchErr := make(chan error, 1)
+	go func() {
+		watchErr <- WatchFiles(ctx, dir, []string{"glob:**/*.go"}, func(changedFiles []string) {
+			changes <- changedFiles
+		}, WatchFilesOptions{
+			Debounce: &debounce,
+		})
+	}()
+
+	// give the watcher time to register directories
+	time.Sleep(500 * time.Millisecond)
+
+	writeFile := func(name string) {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("package foo\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("main.go")
+	writeFile("README.md")
+	writeFile("pkg/foo.go")
+	writeFile("vendor/bar.go")
+
+	// changes can be reported in more than one call
+	// if the system is slow
+	expectChanges := func(expected []string) {
+		var changedFiles []string
+		for len(changedFiles) < len(expected) {
+			select {
+			case files := <-changes:
+				for _, f := range files {
+					if !slices.Contains(expected, f) {
+						t.Fatalf("unexpected change of '%v', expected %v", f, expected)
+					}
+					if !slices.Contains(changedFiles, f) {
+						changedFiles = append(changedFiles, f)
+					}
+				}
+			case err := <-watchErr:
+				t.Fatalf("watcher stopped: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("expected changes of %v, got %v", expected, changedFiles)
+			}
+		}
+	}
+
+	expectChanges([]string{"main.go", "pkg/foo.go"})
+
+	// files in new directories
+	writeFile("cmd/app/app.go")
+
+	expectChanges([]string{"cmd/app/app.go"})
+
+	cancel()
+
+	select {
+	case err := <-watchErr:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher did not stop")
+	}
+}
+
+func TestWatchFilesInvalidPattern(t *testing.T) {
+	err := WatchFiles(context.Background(), t.TempDir(), []string{`(`}, func(changedFiles []string) {})
+	if err == nil {
+		t.Fatal("expected error for invalid pattern")
+	}
+}
@@ -0,0 +1,94 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// SetYamlRootValue() - sets a top-level value of a YAML document with a mapping
+// as root and keeps comments and the order of all other keys
+func SetYamlRootValue(yamlData []byte, key string, value interface{}) ([]byte, error) {
+	file, err := parser.ParseBytes(yamlData, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *ast.MappingNode
+	if len(file.Docs) > 0 {
+		switch body := file.Docs[0].Body.(type) {
+		case *ast.MappingNode:
+			root = body
+		case nil, *ast.CommentGroupNode:
+			// empty document
+		default:
+			return nil, fmt.Errorf("root of YAML document is no mapping")
+		}
+	}
+
+	newEntryData, err := yaml.Marshal(map[string]interface{}{
+		key: value,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if root == nil {
+		// append to comments, if there are any
+		yamlData = bytes.TrimRight(yamlData, "\r\n")
+		if len(yamlData) > 0 {
+			yamlData = append(yamlData, '\n')
+		}
+
+		return append(yamlData, newEntryData...), nil
+	}
+
+	newEntryFile, err := parser.ParseBytes(newEntryData, 0)
+	if err != nil {
+		return nil, err
+	}
+	newEntry := newEntryFile.Docs[0].Body.(*ast.MappingNode).Values[0]
+
+	isExisting := false
+	for _, v := range root.Values {
+		if v.Key.GetToken().Value == key {
+			err = v.Replace(newEntry.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			isExisting = true
+			break
+		}
+	}
+	if !isExisting {
+		root.Values = append(root.Values, newEntry)
+	}
+
+	return append(bytes.TrimRight([]byte(file.String()), "\r\n"), '\n'), nil
+}
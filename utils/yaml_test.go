@@ -0,0 +1,75 @@
+// MIT License
+//
+// Copyright (c) 2024 Marcel Joachim Kloubert (https://marcel.coffee)
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestSetYamlRootValue(t *testing.T) {
+	tests := []struct {
+		yaml     string
+		key      string
+		value    interface{}
+		expected string
+	}{
+		{
+			"",
+			"tools", []string{"a"},
+			"tools:\n- a\n",
+		},
+		{
+			"# only a comment\n",
+			"name", "foo",
+			"# only a comment\nname: foo\n",
+		},
+		{
+			"# my project\nname: foo # the name\n\nscripts:\n  test: go test ./...\n",
+			"tools", []string{"golang.org/x/tools/cmd/stringer"},
+			"# my project\nname: foo # the name\n\nscripts:\n  test: go test ./...\ntools:\n- golang.org/x/tools/cmd/stringer\n",
+		},
+		{
+			"# my project\ntools:\n  - a # first tool\nname: foo # the name\n",
+			"tools", []string{"a", "b"},
+			"# my project\ntools:\n  - a\n  - b\nname: foo # the name\n",
+		},
+	}
+
+	for i, test := range tests {
+		yamlData, err := SetYamlRootValue([]byte(test.yaml), test.key, test.value)
+		if err != nil {
+			t.Fatalf("#%v: %v", i, err)
+		}
+
+		if string(yamlData) != test.expected {
+			t.Errorf("#%v: expected %q, got %q", i, test.expected, string(yamlData))
+		}
+	}
+}
+
+func TestSetYamlRootValueWithoutMapping(t *testing.T) {
+	_, err := SetYamlRootValue([]byte("- a\n- b\n"), "tools", []string{"c"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}